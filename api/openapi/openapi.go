@@ -0,0 +1,94 @@
+// Package openapi embeds the OpenAPI spec of the HTTP API.
+// The spec is the source of truth of the API: the request and response types are generated from it
+// and the requests are validated against it.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// Spec is the loaded OpenAPI spec.
+type Spec struct {
+	Doc *openapi3.T
+	// Router finds the operation of the requests.
+	Router routers.Router
+	// JSON is the spec encoded as JSON, as served by the API.
+	JSON []byte
+}
+
+// Load parses and validates the embedded spec. The spec is loaded once.
+var Load = sync.OnceValues(func() (Spec, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return Spec{}, fmt.Errorf("loading openapi spec: %w", err)
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return Spec{}, fmt.Errorf("encoding openapi spec: %w", err)
+	}
+
+	// the JSON keeps the 3.1 schemas, so it's encoded before the downgrade.
+	for _, ref := range doc.Components.Schemas {
+		downgradeNullTypes(ref, map[*openapi3.Schema]bool{})
+	}
+
+	if err = doc.Validate(context.Background()); err != nil {
+		return Spec{}, fmt.Errorf("validating openapi spec: %w", err)
+	}
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return Spec{}, fmt.Errorf("building openapi router: %w", err)
+	}
+
+	return Spec{Doc: doc, Router: router, JSON: b}, nil
+})
+
+// MustLoad is like Load but panics if the spec is invalid.
+func MustLoad() Spec {
+	s, err := Load()
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// downgradeNullTypes replaces the "null" of the 3.1 type arrays by the nullable flag of 3.0,
+// the schema version validated by kin-openapi. Both are equivalent for the request and response validation.
+func downgradeNullTypes(ref *openapi3.SchemaRef, visited map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || visited[ref.Value] {
+		return
+	}
+
+	s := ref.Value
+	visited[s] = true
+
+	if s.Type.Includes(openapi3.TypeNull) {
+		types := make(openapi3.Types, 0, len(*s.Type))
+		for _, t := range *s.Type {
+			if t != openapi3.TypeNull {
+				types = append(types, t)
+			}
+		}
+		s.Type = &types
+		s.Nullable = true
+	}
+
+	for _, p := range s.Properties {
+		downgradeNullTypes(p, visited)
+	}
+	downgradeNullTypes(s.Items, visited)
+}
@@ -0,0 +1,33 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/api/openapi"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	// execute
+	spec, err := openapi.Load()
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", spec.Doc.OpenAPI)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(spec.JSON, &doc))
+	assert.Equal(t, "3.1.0", doc["openapi"])
+
+	route, params, err := spec.Router.FindRoute(httptest.NewRequest(http.MethodGet, "/api/v1/accounts/123/balance", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "GetBalance", route.Operation.OperationID)
+	assert.Equal(t, map[string]string{"account_id": "123"}, params)
+}
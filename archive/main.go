@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/cli"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Archives the partitions of the transfers older than the retention window. Each partition is exported
+// to a gzipped CSV file in the output directory and then detached from the transfers table:
+//
+//	go run ./archive -retention 12 -dir /var/backups/ecorp
+func main() {
+	var (
+		retention = flag.Int("retention", 12, "number of months of transfers kept, besides the current one")
+		dir       = flag.String("dir", ".", "directory the partitions are exported to")
+		drop      = flag.Bool("drop", false, "drop the partitions once exported and detached")
+		dryRun    = flag.Bool("dry-run", false, "list the partitions to archive without archiving them")
+	)
+	flag.Parse()
+
+	if *retention < 0 {
+		fmt.Fprintln(os.Stderr, "-retention must be greater than or equal to zero")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var p postgres.Partitioner
+	cli.Run(fx.Options(Options, fx.Populate(&p)), func(ctx context.Context) error {
+		if err := archive(ctx, p, cutoff(time.Now(), *retention), *dir, *drop, *dryRun); err != nil {
+			return fmt.Errorf("archiving transfer partitions: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// cutoff returns the start of the oldest month kept, in UTC.
+// The partitions that end until it are archived.
+func cutoff(now time.Time, retention int) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month()-time.Month(retention), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// archive archives the partitions that end until the cutoff, from the oldest.
+// It stops on the first failure, so the partitions are archived in order.
+func archive(ctx context.Context, p postgres.Partitioner, cutoff time.Time, dir string, drop, dryRun bool) error {
+	if !dryRun {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+	}
+
+	partitions, err := p.ListPartitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range partitions {
+		if part.To.After(cutoff) {
+			break
+		}
+
+		if dryRun {
+			logger.Info(ctx, "partition to archive",
+				zap.String("partition", part.Name),
+				zap.Time("from", part.From),
+				zap.Time("to", part.To),
+			)
+			continue
+		}
+
+		archived, err := p.Archive(ctx, part, dir)
+		if err != nil {
+			return err
+		}
+
+		logger.Info(ctx, "partition archived",
+			zap.String("partition", part.Name),
+			zap.String("path", archived.Path),
+			zap.Int64("rows", archived.Rows),
+		)
+
+		if !drop {
+			continue
+		}
+
+		if err = p.Drop(ctx, part.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var Options = fx.Options(
+	logger.Module,
+	apictx.Module,
+	config.Module,
+	dbpool.Module,
+	fx.Provide(postgres.NewPartitioner),
+)
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/fx"
+)
+
+func TestApp(t *testing.T) {
+	t.Parallel()
+
+	err := fx.ValidateApp(Options)
+	assert.NoError(t, err)
+}
+
+func TestCutoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		retention int
+		expected  time.Time
+	}{
+		{
+			name:      "keeps the months of the retention",
+			now:       time.Date(2024, time.May, 20, 10, 0, 0, 0, time.UTC),
+			retention: 3,
+			expected:  time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "previous year",
+			now:       time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			retention: 12,
+			expected:  time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "only the current month",
+			now:       time.Date(2024, time.May, 20, 10, 0, 0, 0, time.UTC),
+			retention: 0,
+			expected:  time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "month in UTC",
+			now:       time.Date(2024, time.May, 31, 22, 0, 0, 0, time.FixedZone("UTC-3", -3*60*60)),
+			retention: 1,
+			expected:  time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			got := cutoff(tt.now, tt.retention)
+
+			// assert
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -12,19 +12,20 @@ import (
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
 	"github.com/higordasneves/e-corp/pkg/gateway/controller"
 	"github.com/higordasneves/e-corp/pkg/gateway/controller/server"
+	"github.com/higordasneves/e-corp/pkg/gateway/grpcserver"
+	"github.com/higordasneves/e-corp/pkg/gateway/health"
+	"github.com/higordasneves/e-corp/pkg/gateway/metrics"
+	"github.com/higordasneves/e-corp/pkg/gateway/notification"
 	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
 	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
 	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+	"github.com/higordasneves/e-corp/pkg/gateway/stream"
+	"github.com/higordasneves/e-corp/pkg/gateway/tracing"
+	"github.com/higordasneves/e-corp/pkg/gateway/webhook"
 	"github.com/higordasneves/e-corp/utils/apictx"
 	"github.com/higordasneves/e-corp/utils/logger"
 )
 
-// @Title Ecorp API
-// @Version 1.0
-// @Description A MVP of an API for banking accounts
-
-// @in header
-// @name Authorization
 func main() {
 	app := fx.New(Options)
 	if err := app.Err(); err != nil {
@@ -39,11 +40,25 @@ var Options = fx.Options(
 	apictx.Module,
 	config.Module,
 	dbpool.Module,
+	postgres.PartitionModule,
+	metrics.Module,
+	health.Module,
+	tracing.Module,
+	fx.Supply(tracing.ServiceName("ecorp-api")),
 	server.Module,
-	rabbitmq.ModuleConn,
+	grpcserver.Module,
+	rabbitmq.ModuleBroker,
 	rabbitmq.ModulePub,
-	fx.Invoke(func(ctx context.Context, pool *pgxpool.Pool) error {
-		err := postgres.Migration(ctx, "pkg/gateway/postgres/migrations", pool)
+	rabbitmq.ModuleSub,
+	webhook.Module,
+	notification.Module,
+	stream.Module,
+	fx.Invoke(func(ctx context.Context, cfg config.Config, pool *pgxpool.Pool) error {
+		if !cfg.DB.AutoMigrate {
+			return nil
+		}
+
+		err := postgres.NewMigrator(pool).Up(ctx)
 		if err != nil {
 			return fmt.Errorf("executing migrations: %w", err)
 		}
@@ -51,7 +66,17 @@ var Options = fx.Options(
 		return nil
 	}),
 	fx.Provide(
-		postgres.NewRepository,
+		fx.Annotate(
+			postgres.NewRepository,
+			fx.As(fx.Self()),
+			fx.As(new(rabbitmq.InboxStore)),
+			fx.As(new(rabbitmq.EventStore)),
+		),
+		// messages of the in-memory broker only reach the consumers of the same process.
+		func(cfg config.Config) rabbitmq.RunConsumers {
+			return cfg.MQ.Driver == rabbitmq.DriverMemory
+		},
+		controller.NewUseCases,
 		fx.Annotate(
 			controller.NewApi,
 			fx.As(new(server.API)),
@@ -1,15 +1,18 @@
 package main
 
 import (
-	"context"
-	"fmt"
-
 	_ "github.com/joho/godotenv/autoload"
 	_ "github.com/lib/pq"
 	"go.uber.org/fx"
 
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/metrics"
+	"github.com/higordasneves/e-corp/pkg/gateway/notification"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
 	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+	"github.com/higordasneves/e-corp/pkg/gateway/tracing"
+	"github.com/higordasneves/e-corp/pkg/gateway/webhook"
 	"github.com/higordasneves/e-corp/utils/apictx"
 	"github.com/higordasneves/e-corp/utils/logger"
 )
@@ -27,13 +30,20 @@ var Options = fx.Options(
 	logger.Module,
 	apictx.Module,
 	config.Module,
-	rabbitmq.ModuleConn,
+	dbpool.Module,
+	metrics.Module,
+	tracing.Module,
+	fx.Supply(tracing.ServiceName("ecorp-consumer")),
+	rabbitmq.ModuleBroker,
 	rabbitmq.ModuleSub,
-	fx.Invoke(func(ctx context.Context, c rabbitmq.Consumer) error {
-		if err := c.Run(ctx); err != nil {
-			return fmt.Errorf("failed to start consumer: %w", err)
-		}
-
-		return nil
-	}),
+	webhook.Module,
+	notification.Module,
+	fx.Supply(rabbitmq.RunConsumers(true)),
+	fx.Provide(
+		fx.Annotate(
+			postgres.NewRepository,
+			fx.As(fx.Self()),
+			fx.As(new(rabbitmq.InboxStore)),
+		),
+	),
 )
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/cli"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Creates the accounts of a CSV or JSON Lines file, in the format of the import endpoint.
+// The rows whose account can't be created are logged with the reason:
+//
+//	go run ./import -file employees.csv
+func main() {
+	var (
+		file   = flag.String("file", "", "CSV or JSON Lines file with the name, document and secret of the accounts")
+		format = flag.String("format", "", "format of the file, csv or jsonl. Defaults to the extension of the file")
+	)
+	flag.Parse()
+
+	mediaType, err := parseArgs(*file, *format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var uc usecase.ImportAccountsUC
+	cli.Run(fx.Options(Options, fx.Populate(&uc)), func(ctx context.Context) error {
+		if err := importAccounts(ctx, uc, *file, mediaType); err != nil {
+			return fmt.Errorf("importing accounts: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// formats maps the formats of the files to the media types of the import endpoint.
+var formats = map[string]string{
+	"csv":    requests.MediaTypeCSV,
+	"jsonl":  requests.MediaTypeJSONL,
+	"ndjson": requests.MediaTypeJSONL,
+}
+
+// parseArgs returns the media type of the file.
+func parseArgs(file, format string) (string, error) {
+	if file == "" {
+		return "", errors.New("-file is required")
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(file), ".")
+	}
+
+	mediaType, ok := formats[strings.ToLower(format)]
+	if !ok {
+		return "", fmt.Errorf("unsupported format %q, use -format csv or -format jsonl", format)
+	}
+
+	return mediaType, nil
+}
+
+// importAccounts imports the accounts of the file and logs the rows that failed.
+func importAccounts(ctx context.Context, uc usecase.ImportAccountsUC, file, mediaType string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	rows, err := requests.ReadAccountImport(f, mediaType)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	output, err := uc.ImportAccounts(ctx, usecase.ImportAccountsInput{Rows: rows})
+
+	for _, failure := range output.Failures {
+		fields := []zap.Field{zap.Int("row", failure.Row), zap.Error(failure.Err)}
+		if domainErr, ok := domain.AsError(failure.Err); ok {
+			fields = append(fields, zap.String("code", string(domainErr.Code)))
+		}
+		logger.Info(ctx, "account not imported", fields...)
+	}
+
+	logger.Info(ctx, "accounts imported",
+		zap.Int("rows", len(rows)),
+		zap.Int("created", len(output.Accounts)),
+		zap.Int("failed", len(output.Failures)),
+	)
+
+	return err
+}
+
+var Options = fx.Options(
+	logger.Module,
+	apictx.Module,
+	config.Module,
+	dbpool.Module,
+	rabbitmq.ModuleBroker,
+	rabbitmq.ModulePub,
+	fx.Provide(
+		fx.Annotate(
+			postgres.NewRepository,
+			fx.As(fx.Self()),
+			fx.As(new(rabbitmq.EventStore)),
+		),
+		func(r postgres.Repository, p rabbitmq.Publisher, cfg config.Config) usecase.ImportAccountsUC {
+			return usecase.NewImportAccountsUC(r, p, &cfg.Import)
+		},
+	),
+)
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+)
+
+func TestApp(t *testing.T) {
+	t.Parallel()
+
+	err := fx.ValidateApp(Options)
+	assert.NoError(t, err)
+}
+
+func TestParseArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		file        string
+		format      string
+		expected    string
+		expectedErr bool
+	}{
+		{name: "csv extension", file: "employees.csv", expected: requests.MediaTypeCSV},
+		{name: "jsonl extension", file: "data/employees.JSONL", expected: requests.MediaTypeJSONL},
+		{name: "format overrides extension", file: "employees.txt", format: "csv", expected: requests.MediaTypeCSV},
+		{name: "unknown extension", file: "employees.txt", expectedErr: true},
+		{name: "missing file", format: "csv", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			got, err := parseArgs(tt.file, tt.format)
+
+			// assert
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/cli"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+const usage = `Applies the migrations embedded in the binary to the database configured by the environment.
+
+Usage:
+	go run ./migrate up           apply all the pending migrations
+	go run ./migrate down N       revert the last N migrations
+	go run ./migrate to VERSION   apply or revert the migrations up to VERSION
+	go run ./migrate status       print the current version and the pending migrations
+	go run ./migrate force VERSION
+	                              set the version without running the migrations, after fixing a failed one
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+
+	cmd, err := parseCommand(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var migrator postgres.Migrator
+	cli.Run(fx.Options(Options, fx.Populate(&migrator)), func(ctx context.Context) error {
+		if err := cmd.run(ctx, migrator); err != nil {
+			return fmt.Errorf("migrating %s: %w", cmd.name, err)
+		}
+
+		return nil
+	})
+}
+
+type command struct {
+	name string
+	arg  int
+}
+
+func parseCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{}, errors.New("missing command")
+	}
+
+	cmd := command{name: args[0]}
+	switch cmd.name {
+	case "up", "status":
+		if len(args) != 1 {
+			return command{}, fmt.Errorf("%s doesn't take arguments", cmd.name)
+		}
+		return cmd, nil
+	case "down", "to", "force":
+		if len(args) != 2 {
+			return command{}, fmt.Errorf("%s takes one argument", cmd.name)
+		}
+	default:
+		return command{}, fmt.Errorf("unknown command %q", cmd.name)
+	}
+
+	n, err := strconv.Atoi(args[1])
+	if err != nil {
+		return command{}, fmt.Errorf("invalid argument of %s: %w", cmd.name, err)
+	}
+
+	switch {
+	case cmd.name == "down" && n <= 0:
+		return command{}, errors.New("down takes a positive number of migrations")
+	case cmd.name == "to" && n < 0:
+		return command{}, errors.New("to takes a version greater than or equal to zero")
+	case cmd.name == "force" && n < -1:
+		return command{}, errors.New("force takes a version greater than or equal to -1")
+	}
+	cmd.arg = n
+
+	return cmd, nil
+}
+
+func (c command) run(ctx context.Context, m postgres.Migrator) error {
+	switch c.name {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		return m.Down(ctx, c.arg)
+	case "to":
+		return m.To(ctx, uint(c.arg))
+	case "force":
+		return m.Force(ctx, c.arg)
+	}
+
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "migration status",
+		zap.Uint("version", status.Version),
+		zap.Bool("dirty", status.Dirty),
+		zap.Uint("latest", status.Latest),
+		zap.Uints("pending", status.Pending),
+	)
+
+	return nil
+}
+
+var Options = fx.Options(
+	logger.Module,
+	apictx.Module,
+	config.Module,
+	dbpool.Module,
+	fx.Provide(postgres.NewMigrator),
+)
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/fx"
+)
+
+func TestApp(t *testing.T) {
+	t.Parallel()
+
+	err := fx.ValidateApp(Options)
+	assert.NoError(t, err)
+}
+
+func TestParseCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        []string
+		expected    command
+		expectedErr bool
+	}{
+		{name: "up", args: []string{"up"}, expected: command{name: "up"}},
+		{name: "status", args: []string{"status"}, expected: command{name: "status"}},
+		{name: "down", args: []string{"down", "2"}, expected: command{name: "down", arg: 2}},
+		{name: "to", args: []string{"to", "5"}, expected: command{name: "to", arg: 5}},
+		{name: "force to no migration", args: []string{"force", "-1"}, expected: command{name: "force", arg: -1}},
+		{name: "missing command", args: nil, expectedErr: true},
+		{name: "unknown command", args: []string{"drop"}, expectedErr: true},
+		{name: "down without the number", args: []string{"down"}, expectedErr: true},
+		{name: "down of zero migrations", args: []string{"down", "0"}, expectedErr: true},
+		{name: "to a negative version", args: []string{"to", "-1"}, expectedErr: true},
+		{name: "invalid version", args: []string{"force", "v1"}, expectedErr: true},
+		{name: "up with arguments", args: []string{"up", "1"}, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			cmd, err := parseCommand(tt.args)
+
+			// assert
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cmd)
+		})
+	}
+}
@@ -16,4 +16,7 @@ type Account struct {
 	Secret    vos.Secret
 	Balance   int
 	CreatedAt time.Time
+	// Version is incremented on every update of the account, starting at 1.
+	// The updates conditioned on it fail if the account changed since it was read.
+	Version int
 }
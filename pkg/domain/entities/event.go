@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// EventAggregateType is the kind of entity an event refers to.
+type EventAggregateType string
+
+const (
+	EventAggregateAccount  EventAggregateType = "account"
+	EventAggregateTransfer EventAggregateType = "transfer"
+)
+
+// Event is a message published to the broker, as recorded in the event store.
+type Event struct {
+	// ID is also the ID of the published message.
+	ID uuid.UUID
+	// Sequence is the position of the event in the event store, assigned when it's appended.
+	// The events are listed in its order, which the ids don't follow.
+	Sequence      int64
+	AggregateType EventAggregateType
+	AggregateID   uuid.UUID
+	// RoutingKey is the routing key the event was originally published to.
+	RoutingKey string
+	Payload    []byte
+	CreatedAt  time.Time
+}
+
+// EventFilter selects events from the event store. Zero values don't filter.
+type EventFilter struct {
+	AggregateID uuid.UUID
+	// From is the inclusive lower bound of the event creation time.
+	From time.Time
+	// To is the exclusive upper bound of the event creation time.
+	To time.Time
+}
+
+// AccountEvent is an event in the stream of the events of an account.
+type AccountEvent struct {
+	AccountID uuid.UUID
+	// Sequence is the position of the event in the stream of the account. It's taken in the order the events
+	// of the account are committed, unlike the sequence of the event store, taken when they are appended.
+	Sequence int64
+	// Balance is the balance of the account when the event was appended.
+	Balance int
+	Event   Event
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// NotificationEvent represents an event notified to the account holder.
+type NotificationEvent string
+
+const (
+	NotificationAccountCreated   NotificationEvent = "account.created"
+	NotificationTransferReceived NotificationEvent = "transfer.received"
+	// NotificationLargeTransferSent is notified to the origin account of a transfer whose amount
+	// reaches the threshold of the account preferences.
+	NotificationLargeTransferSent NotificationEvent = "transfer.large_sent"
+)
+
+// NotificationEvents lists all the supported notification events.
+var NotificationEvents = []NotificationEvent{
+	NotificationAccountCreated,
+	NotificationTransferReceived,
+	NotificationLargeTransferSent,
+}
+
+// NotificationChannel represents a channel the notifications are sent on.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+	// NotificationChannelPush targets the devices of the account, so it doesn't need an address.
+	NotificationChannelPush NotificationChannel = "push"
+)
+
+// NotificationChannels lists all the supported notification channels.
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelSMS,
+	NotificationChannelPush,
+}
+
+// Locale is the language the notifications are written in.
+type Locale string
+
+const (
+	LocalePtBR Locale = "pt-BR"
+	LocaleEn   Locale = "en"
+)
+
+// Locales lists all the supported locales.
+var Locales = []Locale{LocalePtBR, LocaleEn}
+
+// NotificationPreferences represents how an account wants to be notified.
+type NotificationPreferences struct {
+	AccountID uuid.UUID
+	Locale    Locale
+	Channels  []NotificationChannel
+	Events    []NotificationEvent
+	// Email is the address of the email channel.
+	Email string
+	// Phone is the E.164 number of the sms channel.
+	Phone string
+	// LargeTransferAmount is the amount from which an outgoing transfer is notified.
+	LargeTransferAmount int
+	UpdatedAt           time.Time
+}
+
+// Recipient returns the address of the channel.
+func (p NotificationPreferences) Recipient(channel NotificationChannel) string {
+	switch channel {
+	case NotificationChannelEmail:
+		return p.Email
+	case NotificationChannelSMS:
+		return p.Phone
+	default:
+		return p.AccountID.String()
+	}
+}
+
+// NotificationStatus represents the status of a notification.
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+)
+
+// Notification represents an event notified on a channel.
+// There is only one notification for each event, account and channel.
+type Notification struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	// EventID identifies the notified event.
+	EventID   string
+	Event     NotificationEvent
+	Channel   NotificationChannel
+	Recipient string
+	Subject   string
+	Body      string
+	Status    NotificationStatus
+	// Attempts is the number of times the notification was sent or tried to.
+	Attempts  int
+	SentAt    *time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// WebhookEventType represents the type of event notified by a webhook.
+type WebhookEventType string
+
+const (
+	// WebhookEventTransferSent is notified to the origin account of a transfer.
+	WebhookEventTransferSent WebhookEventType = "transfer.sent"
+	// WebhookEventTransferReceived is notified to the destination account of a transfer.
+	WebhookEventTransferReceived WebhookEventType = "transfer.received"
+)
+
+// WebhookEventTypes lists all the supported webhook event types.
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventTransferSent,
+	WebhookEventTransferReceived,
+}
+
+// WebhookSubscription represents an account subscription to receive events over HTTP.
+type WebhookSubscription struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	URL       string
+	// EventTypes are the events notified to the subscription.
+	EventTypes []WebhookEventType
+	// Secret is the key used to sign the payloads sent to the subscription.
+	Secret    string
+	CreatedAt time.Time
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery represents the delivery of an event to a webhook subscription and its attempts log.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	// EventID identifies the event, so the receiver can discard duplicates.
+	EventID   string
+	EventType WebhookEventType
+	Payload   []byte
+	Status    WebhookDeliveryStatus
+	Attempts  int
+	// ResponseStatus is the HTTP status code returned by the receiver on the last attempt.
+	ResponseStatus int
+	// LastError describes the failure of the last attempt.
+	LastError     string
+	NextAttemptAt time.Time
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+}
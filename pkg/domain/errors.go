@@ -1,9 +1,160 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrNotFound         = errors.New("not found")
 	ErrInvalidParameter = errors.New("invalid parameter")
 	ErrUnauthorized     = errors.New("unauthorized")
+	ErrForbidden        = errors.New("forbidden")
+	ErrConflict         = errors.New("conflict")
+)
+
+// Code is a stable machine-readable identifier of an error.
+// Clients may rely on it, so existing values must never change.
+type Code string
+
+const (
+	CodeInvalidParameter Code = "invalid_parameter"
+	CodeValidationFailed Code = "validation_failed"
+	CodeNotFound         Code = "not_found"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeForbidden        Code = "forbidden"
+	CodeConflict         Code = "conflict"
+	CodeInternal         Code = "internal_error"
+
+	CodeInvalidRequestBody  Code = "invalid_request_body"
+	CodeRequestBodyTooLarge Code = "request_body_too_large"
+	CodeRateLimited         Code = "rate_limited"
+	CodeInvalidCredentials  Code = "invalid_credentials"
+
+	CodeAccountNotFound        Code = "account_not_found"
+	CodeAccountAlreadyExists   Code = "account_already_exists"
+	CodeAccountVersionConflict Code = "account_version_conflict"
+	CodeInsufficientFunds      Code = "insufficient_funds"
+	CodeSameAccountTransfer    Code = "same_account_transfer"
+
+	CodeWebhookNotFound                 Code = "webhook_not_found"
+	CodeWebhookDeliveryNotFound         Code = "webhook_delivery_not_found"
+	CodeWebhookDeliveryChanged          Code = "webhook_delivery_changed"
+	CodeNotificationPreferencesNotFound Code = "notification_preferences_not_found"
+
+	// Field level codes.
+	CodeRequired         Code = "required"
+	CodeInvalid          Code = "invalid"
+	CodeUnsupported      Code = "unsupported"
+	CodeMustBePositive   Code = "must_be_positive"
+	CodeDocumentInvalid  Code = "document_invalid"
+	CodeSecretTooShort   Code = "secret_too_short"
+	CodeInvalidPageToken Code = "invalid_page_token"
 )
+
+// FieldError describes why a single input field is invalid.
+type FieldError struct {
+	Field   string
+	Code    Code
+	Message string
+}
+
+// Error is the typed error returned by the use cases.
+// Kind is one of the sentinel errors above and defines the category of the error,
+// so errors.Is(err, ErrNotFound) keeps working. Code identifies the error for clients
+// and Fields lists the invalid fields of a validation error.
+type Error struct {
+	Kind    error
+	Code    Code
+	Message string
+	Fields  []FieldError
+	// Err is the underlying cause, if any.
+	Err error
+}
+
+// NewError returns an Error of the given kind and code.
+func NewError(kind error, code Code, format string, args ...any) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// NewValidationError returns an ErrInvalidParameter error listing the invalid fields.
+func NewValidationError(fields ...FieldError) *Error {
+	return &Error{
+		Kind:   ErrInvalidParameter,
+		Code:   CodeValidationFailed,
+		Fields: fields,
+	}
+}
+
+// NewFieldError returns a validation error for a single field.
+func NewFieldError(field string, code Code, message string) *Error {
+	return NewValidationError(FieldError{Field: field, Code: code, Message: message})
+}
+
+// WithCause sets the underlying cause of the error.
+func (e *Error) WithCause(err error) *Error {
+	e.Err = err
+	return e
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Kind.Error())
+
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+
+	for i, f := range e.Fields {
+		if i > 0 {
+			b.WriteString(";")
+		}
+		fmt.Fprintf(&b, " (%s): %s", f.Field, f.Message)
+	}
+
+	return b.String()
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// AsError returns the Error in the chain of err.
+// Errors that are not typed but wrap one of the sentinel errors are converted
+// to an Error with the default code of the sentinel.
+// Returns false if err doesn't belong to any known kind.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+
+	for _, k := range kinds {
+		if errors.Is(err, k.kind) {
+			return &Error{Kind: k.kind, Code: k.code, Err: err}, true
+		}
+	}
+
+	return nil, false
+}
+
+// kinds holds the default code of each sentinel error.
+var kinds = []struct {
+	kind error
+	code Code
+}{
+	{ErrInvalidParameter, CodeInvalidParameter},
+	{ErrNotFound, CodeNotFound},
+	{ErrUnauthorized, CodeUnauthorized},
+	{ErrForbidden, CodeForbidden},
+	{ErrConflict, CodeConflict},
+}
@@ -11,14 +11,14 @@ import (
 
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
-	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
 	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
 )
 
 func TestAccountUseCase_GetBalance(t *testing.T) {
 	t.Parallel()
 
-	r := postgres.NewRepository(NewDB(t))
+	r := memory.NewRepository()
 	err := r.CreateAccount(context.Background(), entities.Account{
 		ID:        uuid.FromStringOrNil("5f2d4920-89c3-4ed5-af8e-1d411588746d"),
 		Name:      "Elliot",
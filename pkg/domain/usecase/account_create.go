@@ -48,44 +48,52 @@ type CreateAccountOutput struct {
 // - the account name is not filled;
 // - the number of characters of the document is not valid;
 // - the format of the document is not valid;
-// - the number of the characters of the secret is less than the minimum;
-// - the account already exists.
+// - the number of the characters of the secret is less than the minimum.
+// Returns domain.ErrConflict if the account already exists.
 func (accUseCase CreateAccountUC) CreateAccount(ctx context.Context, input CreateAccountInput) (CreateAccountOutput, error) {
+	account, err := newAccount(input)
+	if err != nil {
+		return CreateAccountOutput{}, err
+	}
+
+	err = accUseCase.R.CreateAccount(ctx, account)
+	if err != nil {
+		return CreateAccountOutput{}, fmt.Errorf("creating account in the database: %w", err)
+	}
+
+	err = accUseCase.B.NotifyAccountCreation(ctx, account)
+	if err != nil {
+		return CreateAccountOutput{}, fmt.Errorf("notifying account creation in the broker: %w", err)
+	}
+
+	return CreateAccountOutput{account}, nil
+}
+
+// newAccount validates the input and returns the account to create, with the hash of its secret.
+func newAccount(input CreateAccountInput) (entities.Account, error) {
 	input = input.removeBlankSpaces()
 	if input.Name == "" {
-		return CreateAccountOutput{}, fmt.Errorf("%w (name): required field", domain.ErrInvalidParameter)
+		return entities.Account{}, domain.NewFieldError("name", domain.CodeRequired, "required field")
 	}
 
 	document, err := vos.NewDocument(input.Document)
 	if err != nil {
-		return CreateAccountOutput{}, fmt.Errorf("%w (document): %w", domain.ErrInvalidParameter, err)
+		return entities.Account{}, domain.NewFieldError("document", domain.CodeDocumentInvalid, err.Error()).WithCause(err)
 	}
 
 	secret, err := vos.NewSecret(input.Secret)
 	if err != nil {
-		return CreateAccountOutput{}, fmt.Errorf("%w (secret): %w", domain.ErrInvalidParameter, err)
+		return entities.Account{}, domain.NewFieldError("secret", domain.CodeSecretTooShort, err.Error()).WithCause(err)
 	}
 
-	account := entities.Account{
+	return entities.Account{
 		ID:        uuid.Must(uuid.NewV7()),
 		Name:      input.Name,
 		Document:  document,
 		Secret:    secret,
 		Balance:   0,
 		CreatedAt: time.Now().Truncate(time.Second),
-	}
-
-	err = accUseCase.R.CreateAccount(ctx, account)
-	if err != nil {
-		return CreateAccountOutput{}, fmt.Errorf("creating account in the database: %w", err)
-	}
-
-	err = accUseCase.B.NotifyAccountCreation(ctx, account)
-	if err != nil {
-		return CreateAccountOutput{}, fmt.Errorf("notifying account creation in the broker: %w", err)
-	}
-
-	return CreateAccountOutput{account}, nil
+	}, nil
 }
 
 // removeBlankSpaces removes blank spaces of account fields
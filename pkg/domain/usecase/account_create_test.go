@@ -13,7 +13,7 @@ import (
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
 	"github.com/higordasneves/e-corp/pkg/domain/vos"
-	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
 	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
 )
 
@@ -22,7 +22,7 @@ func TestAccountUseCase_CreateAccount_Success(t *testing.T) {
 
 	// setup
 	ctx := thelp.NewCtx(t)
-	r := postgres.NewRepository(NewDB(t))
+	r := memory.NewRepository()
 	uc := usecase.CreateAccountUC{R: r, B: &mocks.CreateAccountUCBrokerMock{}}
 
 	// execute
@@ -45,19 +45,19 @@ func TestAccountUseCase_CreateAccount_Failure(t *testing.T) {
 
 	// setup
 	ctx := thelp.NewCtx(t)
-	r := postgres.NewRepository(NewDB(t))
+	r := memory.NewRepository()
 	uc := usecase.CreateAccountUC{R: r, B: &mocks.CreateAccountUCBrokerMock{}}
 
 	tests := []struct {
 		name        string
-		setup       func(t *testing.T, r postgres.Repository)
+		setup       func(t *testing.T, r memory.Repository)
 		input       usecase.CreateAccountInput
 		wantErr     error
 		errContains string
 	}{
 		{
 			name:  "empty account name",
-			setup: func(t *testing.T, r postgres.Repository) {},
+			setup: func(t *testing.T, r memory.Repository) {},
 			input: usecase.CreateAccountInput{
 				Name:     "",
 				Document: "1234567899",
@@ -68,7 +68,7 @@ func TestAccountUseCase_CreateAccount_Failure(t *testing.T) {
 		},
 		{
 			name:  "document length",
-			setup: func(t *testing.T, r postgres.Repository) {},
+			setup: func(t *testing.T, r memory.Repository) {},
 			input: usecase.CreateAccountInput{
 				Name:     "Elliot",
 				Document: "123",
@@ -79,7 +79,7 @@ func TestAccountUseCase_CreateAccount_Failure(t *testing.T) {
 		},
 		{
 			name:  "document format",
-			setup: func(t *testing.T, r postgres.Repository) {},
+			setup: func(t *testing.T, r memory.Repository) {},
 			input: usecase.CreateAccountInput{
 				Name:     "Elliot",
 				Document: "1234567890A",
@@ -90,7 +90,7 @@ func TestAccountUseCase_CreateAccount_Failure(t *testing.T) {
 		},
 		{
 			name:  "secret length",
-			setup: func(t *testing.T, r postgres.Repository) {},
+			setup: func(t *testing.T, r memory.Repository) {},
 			input: usecase.CreateAccountInput{
 				Name:     "Elliot",
 				Document: "12345678901",
@@ -101,7 +101,7 @@ func TestAccountUseCase_CreateAccount_Failure(t *testing.T) {
 		},
 		{
 			name: "account already exists",
-			setup: func(t *testing.T, r postgres.Repository) {
+			setup: func(t *testing.T, r memory.Repository) {
 				acc := entities.Account{
 					ID:        uuid.Must(uuid.NewV7()),
 					Name:      "Elliot",
@@ -117,7 +117,7 @@ func TestAccountUseCase_CreateAccount_Failure(t *testing.T) {
 				Document: "43663312344",
 				Secret:   "validsecret123",
 			},
-			wantErr:     domain.ErrInvalidParameter,
+			wantErr:     domain.ErrConflict,
 			errContains: "account with document 43663312344 already exists",
 		},
 	}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type ListAccountEventsUCRepository interface {
+	GetBalance(ctx context.Context, id uuid.UUID) (int, error)
+	ListAccountEvents(ctx context.Context, accountID uuid.UUID, afterSequence int64, pageSize int) ([]entities.AccountEvent, error)
+}
+
+type ListAccountEventsUC struct {
+	R ListAccountEventsUCRepository
+}
+
+func NewListAccountEventsUC(r ListAccountEventsUCRepository) ListAccountEventsUC {
+	return ListAccountEventsUC{R: r}
+}
+
+type ListAccountEventsInput struct {
+	AccountID uuid.UUID
+	// AfterSequence is the sequence of the last event of the account received, the events are listed after it.
+	AfterSequence int64
+	PageSize      int
+}
+
+type ListAccountEventsOutput struct {
+	Events []entities.AccountEvent
+}
+
+// ListAccountEvents lists the events of the transfers sent or received by the account in the order they were committed,
+// so a client can resume from the last event it received.
+// Returns domain.ErrNotFound if the account not exists.
+func (uc ListAccountEventsUC) ListAccountEvents(ctx context.Context, input ListAccountEventsInput) (ListAccountEventsOutput, error) {
+	// Just checking if the account exists. the repository returns domain.ErrNotFound if not exits.
+	if _, err := uc.R.GetBalance(ctx, input.AccountID); err != nil {
+		return ListAccountEventsOutput{}, fmt.Errorf("getting balance: %w", err)
+	}
+
+	events, err := uc.R.ListAccountEvents(ctx, input.AccountID, input.AfterSequence, input.PageSize)
+	if err != nil {
+		return ListAccountEventsOutput{}, fmt.Errorf("listing events: %w", err)
+	}
+
+	return ListAccountEventsOutput{Events: events}, nil
+}
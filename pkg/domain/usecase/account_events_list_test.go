@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestListAccountEventsUC_ListAccountEvents(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+
+	accountID := uuid.Must(uuid.NewV7())
+	otherID := uuid.Must(uuid.NewV7())
+	for i, id := range []uuid.UUID{accountID, otherID} {
+		require.NoError(t, r.CreateAccount(ctx, entities.Account{
+			ID:        id,
+			Name:      "Elliot",
+			Document:  vos.Document(fmt.Sprintf("1234567890%d", i)),
+			Secret:    "secret",
+			Balance:   1000,
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}))
+	}
+
+	transferEvent := func(origin, destination uuid.UUID) entities.Event {
+		t.Helper()
+
+		id := uuid.Must(uuid.NewV7())
+		payload, err := json.Marshal(entities.Transfer{
+			ID:                   id,
+			AccountOriginID:      origin,
+			AccountDestinationID: destination,
+			Amount:               100,
+			CreatedAt:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		return entities.Event{
+			ID:            id,
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   id,
+			RoutingKey:    "ecorp.transferCreation",
+			Payload:       payload,
+			CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	sent := transferEvent(accountID, otherID)
+	received := transferEvent(otherID, accountID)
+	unrelated := transferEvent(otherID, uuid.Must(uuid.NewV7()))
+	// appended first, the event sent is at the position 1 of the stream of the account.
+	for _, e := range []entities.Event{sent, unrelated, received} {
+		require.NoError(t, r.AppendEvent(ctx, e))
+	}
+
+	tests := []struct {
+		name    string
+		input   usecase.ListAccountEventsInput
+		want    []uuid.UUID
+		wantErr error
+	}{
+		{
+			name:  "lists the transfers sent and received by the account",
+			input: usecase.ListAccountEventsInput{AccountID: accountID, PageSize: 10},
+			want:  []uuid.UUID{sent.ID, received.ID},
+		},
+		{
+			name:  "lists the events after the last event received",
+			input: usecase.ListAccountEventsInput{AccountID: accountID, AfterSequence: 1, PageSize: 10},
+			want:  []uuid.UUID{received.ID},
+		},
+		{
+			name:  "limits the events to the page size",
+			input: usecase.ListAccountEventsInput{AccountID: accountID, PageSize: 1},
+			want:  []uuid.UUID{sent.ID},
+		},
+		{
+			name:    "account not found",
+			input:   usecase.ListAccountEventsInput{AccountID: uuid.Must(uuid.NewV7()), PageSize: 10},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			uc := usecase.NewListAccountEventsUC(r)
+
+			// execute
+			got, err := uc.ListAccountEvents(ctx, tt.input)
+
+			// assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			var ids []uuid.UUID
+			for _, e := range got.Events {
+				ids = append(ids, e.Event.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
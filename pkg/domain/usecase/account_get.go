@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type GetAccountUCRepository interface {
+	GetAccount(ctx context.Context, id uuid.UUID) (entities.Account, error)
+}
+
+type GetAccountUC struct {
+	R GetAccountUCRepository
+}
+
+func NewGetAccountUC(r GetAccountUCRepository) GetAccountUC {
+	return GetAccountUC{R: r}
+}
+
+// GetAccount returns the account, with the version it's at.
+// Returns domain.ErrNotFound if the account not exists.
+func (uc GetAccountUC) GetAccount(ctx context.Context, id uuid.UUID) (entities.Account, error) {
+	acc, err := uc.R.GetAccount(ctx, id)
+	if err != nil {
+		return entities.Account{}, fmt.Errorf("getting account: %w", err)
+	}
+
+	return acc, nil
+}
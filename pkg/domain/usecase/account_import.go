@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+type ImportAccountsUCRepository interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	ListExistingDocuments(ctx context.Context, documents []vos.Document) ([]vos.Document, error)
+	CreateAccounts(ctx context.Context, accs []entities.Account) error
+}
+
+type ImportAccountsUC struct {
+	R ImportAccountsUCRepository
+	B CreateAccountUCBroker
+	// Workers is the number of rows validated at the same time. The validation hashes the secret,
+	// which takes a CPU for tens of milliseconds, so it defaults to the number of CPUs.
+	Workers int
+	// MaxRows is the maximum number of rows of an import, zero is unlimited.
+	MaxRows int
+}
+
+func NewImportAccountsUC(accountRepo ImportAccountsUCRepository, broker CreateAccountUCBroker, cfg *config.ImportConfig) ImportAccountsUC {
+	return ImportAccountsUC{R: accountRepo, B: broker, Workers: cfg.Workers, MaxRows: cfg.MaxRows}
+}
+
+type ImportAccountsInput struct {
+	Rows []CreateAccountInput
+}
+
+type ImportAccountsOutput struct {
+	// Accounts are the accounts created, in the order of their rows.
+	Accounts []ImportedAccount
+	Failures []ImportAccountFailure
+}
+
+// ImportedAccount is an account created from the row of an import.
+type ImportedAccount struct {
+	// Row is the position of the row in the input, from 1.
+	Row     int
+	Account entities.Account
+	// NotifyErr is the error of the notification of the account creation, which doesn't undo it.
+	NotifyErr error
+}
+
+// ImportAccountFailure describes why the account of a row was not created.
+type ImportAccountFailure struct {
+	// Row is the position of the row in the input, from 1.
+	Row int
+	// Err is a domain.Error.
+	Err error
+}
+
+// ImportAccounts validates the rows with the rules of CreateAccount and creates the accounts of the valid ones at once.
+// The rows whose account can't be created are reported as failures, with the same errors of CreateAccount:
+// domain.ErrInvalidParameter if the row is not valid and domain.ErrConflict if the document belongs to an account,
+// or to a previous row. The creation of every account is notified, as CreateAccount does, but the accounts
+// whose notification fails are created anyway and report the error.
+// Returns domain.ErrInvalidParameter if there are more rows than the maximum, before validating any of them.
+// Returns domain.ErrConflict if an account with the document of a row is created during the import,
+// in which case none of the accounts is created.
+func (uc ImportAccountsUC) ImportAccounts(ctx context.Context, input ImportAccountsInput) (ImportAccountsOutput, error) {
+	if uc.MaxRows > 0 && len(input.Rows) > uc.MaxRows {
+		return ImportAccountsOutput{}, domain.NewFieldError("rows", domain.CodeInvalid, fmt.Sprintf("must have at most %d rows", uc.MaxRows))
+	}
+
+	accounts, errs, err := uc.newAccounts(ctx, input.Rows)
+	if err != nil {
+		return ImportAccountsOutput{}, err
+	}
+
+	documents := make([]vos.Document, 0, len(accounts))
+	for i, acc := range accounts {
+		if errs[i] == nil {
+			documents = append(documents, acc.Document)
+		}
+	}
+
+	// the documents are checked in the transaction of the creation, so they are read from the primary
+	// and not from a replica that may miss the accounts just created.
+	var output ImportAccountsOutput
+	err = uc.R.WithTx(ctx, func(ctx context.Context) error {
+		output = ImportAccountsOutput{}
+
+		existing, err := uc.R.ListExistingDocuments(ctx, documents)
+		if err != nil {
+			return fmt.Errorf("listing existing documents: %w", err)
+		}
+
+		seen := make(map[vos.Document]bool, len(documents))
+		for _, document := range existing {
+			seen[document] = true
+		}
+
+		toCreate := make([]entities.Account, 0, len(documents))
+		for i, acc := range accounts {
+			rowErr := errs[i]
+			if rowErr == nil && seen[acc.Document] {
+				rowErr = domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account with document %s already exists", acc.Document)
+			}
+
+			if rowErr != nil {
+				output.Failures = append(output.Failures, ImportAccountFailure{Row: i + 1, Err: rowErr})
+				continue
+			}
+
+			seen[acc.Document] = true
+			toCreate = append(toCreate, acc)
+			output.Accounts = append(output.Accounts, ImportedAccount{Row: i + 1, Account: acc})
+		}
+
+		if len(toCreate) == 0 {
+			return nil
+		}
+
+		if err = uc.R.CreateAccounts(ctx, toCreate); err != nil {
+			return fmt.Errorf("creating accounts in the database: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ImportAccountsOutput{}, err
+	}
+
+	// the accounts are created, so a failed notification doesn't stop the others nor fail the import.
+	for i, imported := range output.Accounts {
+		if err = uc.B.NotifyAccountCreation(ctx, imported.Account); err != nil {
+			output.Accounts[i].NotifyErr = fmt.Errorf("notifying creation of account %s in the broker: %w", imported.Account.ID, err)
+		}
+	}
+
+	return output, nil
+}
+
+// newAccounts validates the rows in a pool of workers and returns the account or the validation error of each row.
+func (uc ImportAccountsUC) newAccounts(ctx context.Context, rows []CreateAccountInput) ([]entities.Account, []error, error) {
+	workers := uc.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		accounts = make([]entities.Account, len(rows))
+		errs     = make([]error, len(rows))
+		next     = make(chan int)
+		wg       sync.WaitGroup
+	)
+	for range min(workers, len(rows)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				accounts[i], errs[i] = newAccount(rows[i])
+			}
+		}()
+	}
+
+	for i := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, fmt.Errorf("validating accounts: %w", err)
+	}
+
+	return accounts, errs, nil
+}
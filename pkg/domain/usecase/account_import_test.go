@@ -0,0 +1,145 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestAccountUseCase_ImportAccounts(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+	broker := &mocks.CreateAccountUCBrokerMock{}
+	existing, err := usecase.NewCreateAccountUC(r, broker).CreateAccount(ctx, usecase.CreateAccountInput{
+		Name:     "Elliot",
+		Document: "43663312487",
+		Secret:   "password123@",
+	})
+	require.NoError(t, err)
+
+	uc := usecase.NewImportAccountsUC(r, broker, &config.ImportConfig{Workers: 2, MaxRows: 10})
+
+	// execute
+	got, err := uc.ImportAccounts(ctx, usecase.ImportAccountsInput{Rows: []usecase.CreateAccountInput{
+		{Name: " Darlene ", Document: "55566677780", Secret: "password123@"},
+		{Name: "", Document: "55566677781", Secret: "password123@"},
+		{Name: "Angela", Document: "55566677782", Secret: "short"},
+		{Name: "Tyrell", Document: existing.Account.Document.String(), Secret: "password123@"},
+		{Name: "Mr. Robot", Document: "55566677783", Secret: "password123@"},
+		{Name: "Darlene", Document: "55566677780", Secret: "password123@"},
+	}})
+
+	// assert: the failures are reported by row
+	require.NoError(t, err)
+	require.Len(t, got.Failures, 4)
+	for i, want := range []struct {
+		row  int
+		kind error
+		code domain.Code
+	}{
+		{row: 2, kind: domain.ErrInvalidParameter, code: domain.CodeValidationFailed},
+		{row: 3, kind: domain.ErrInvalidParameter, code: domain.CodeValidationFailed},
+		{row: 4, kind: domain.ErrConflict, code: domain.CodeAccountAlreadyExists},
+		{row: 6, kind: domain.ErrConflict, code: domain.CodeAccountAlreadyExists},
+	} {
+		failure := got.Failures[i]
+		assert.Equal(t, want.row, failure.Row)
+		assert.ErrorIs(t, failure.Err, want.kind)
+		domainErr, ok := domain.AsError(failure.Err)
+		require.True(t, ok)
+		assert.Equal(t, want.code, domainErr.Code)
+	}
+
+	require.Len(t, got.Accounts, 2)
+	assert.Equal(t, 1, got.Accounts[0].Row)
+	assert.Equal(t, "Darlene", got.Accounts[0].Account.Name)
+	assert.Equal(t, 5, got.Accounts[1].Row)
+	assert.Equal(t, vos.Document("55566677783"), got.Accounts[1].Account.Document)
+
+	for _, imported := range got.Accounts {
+		acc, err := r.GetAccount(ctx, imported.Account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, imported.Account.Document, acc.Document)
+		assert.NoError(t, acc.Secret.CompareHashSecret("password123@"))
+	}
+
+	calls := broker.NotifyAccountCreationCalls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, got.Accounts[0].Account, calls[1].Account)
+	assert.Equal(t, got.Accounts[1].Account, calls[2].Account)
+}
+
+func TestAccountUseCase_ImportAccounts_NotificationFailure(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+	errBroker := errors.New("broker unavailable")
+	broker := &mocks.CreateAccountUCBrokerMock{
+		NotifyAccountCreationFunc: func(_ context.Context, account entities.Account) error {
+			if account.Document == "55566677780" {
+				return errBroker
+			}
+			return nil
+		},
+	}
+	uc := usecase.NewImportAccountsUC(r, broker, &config.ImportConfig{})
+
+	// execute
+	got, err := uc.ImportAccounts(ctx, usecase.ImportAccountsInput{Rows: []usecase.CreateAccountInput{
+		{Name: "Darlene", Document: "55566677780", Secret: "password123@"},
+		{Name: "Angela", Document: "55566677781", Secret: "password123@"},
+	}})
+
+	// assert: the accounts are created, the other notifications are sent and the failure is reported
+	require.NoError(t, err)
+	require.Len(t, got.Accounts, 2)
+	assert.ErrorIs(t, got.Accounts[0].NotifyErr, errBroker)
+	assert.NoError(t, got.Accounts[1].NotifyErr)
+	assert.Len(t, broker.NotifyAccountCreationCalls(), 2)
+
+	_, err = r.GetAccount(ctx, got.Accounts[0].Account.ID)
+	assert.NoError(t, err)
+
+	_, err = r.GetAccount(ctx, got.Accounts[1].Account.ID)
+	assert.NoError(t, err)
+}
+
+func TestAccountUseCase_ImportAccounts_TooManyRows(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+	broker := &mocks.CreateAccountUCBrokerMock{}
+	uc := usecase.NewImportAccountsUC(r, broker, &config.ImportConfig{MaxRows: 1})
+
+	// execute
+	_, err := uc.ImportAccounts(ctx, usecase.ImportAccountsInput{Rows: []usecase.CreateAccountInput{
+		{Name: "Darlene", Document: "55566677780", Secret: "password123@"},
+		{Name: "Angela", Document: "55566677781", Secret: "password123@"},
+	}})
+
+	// assert: no row is validated nor created
+	assert.ErrorIs(t, err, domain.ErrInvalidParameter)
+	assert.Empty(t, broker.NotifyAccountCreationCalls())
+
+	_, err = r.GetAccountByDocument(ctx, "55566677780")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type UpdateAccountUCRepository interface {
+	UpdateAccount(ctx context.Context, acc entities.Account, expectedVersion int) (entities.Account, error)
+}
+
+type UpdateAccountUC struct {
+	R UpdateAccountUCRepository
+}
+
+func NewUpdateAccountUC(r UpdateAccountUCRepository) UpdateAccountUC {
+	return UpdateAccountUC{R: r}
+}
+
+// UpdateAccountInput represents the profile of an account.
+type UpdateAccountInput struct {
+	ID   uuid.UUID
+	Name string
+	// Version is the version of the account the changes were made on.
+	// Zero updates the current version.
+	Version int
+}
+
+type UpdateAccountOutput struct {
+	Account entities.Account
+}
+
+// UpdateAccount validates the input and updates the profile of the account.
+// Returns domain.ErrInvalidParameter if the account name is not filled.
+// Returns domain.ErrNotFound if the account not exists.
+// Returns domain.ErrConflict if the account is not at the version of the input.
+func (uc UpdateAccountUC) UpdateAccount(ctx context.Context, input UpdateAccountInput) (UpdateAccountOutput, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return UpdateAccountOutput{}, domain.NewFieldError("name", domain.CodeRequired, "required field")
+	}
+
+	// the version is checked by the update itself, as a read before it could come from a lagging replica.
+	acc, err := uc.R.UpdateAccount(ctx, entities.Account{ID: input.ID, Name: name}, input.Version)
+	if err != nil {
+		return UpdateAccountOutput{}, fmt.Errorf("updating account: %w", err)
+	}
+
+	return UpdateAccountOutput{Account: acc}, nil
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestUpdateAccountUC_UpdateAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       func(acc entities.Account) usecase.UpdateAccountInput
+		wantName    string
+		wantVersion int
+		wantErr     error
+	}{
+		{
+			name: "success",
+			input: func(acc entities.Account) usecase.UpdateAccountInput {
+				return usecase.UpdateAccountInput{ID: acc.ID, Name: " Elliot Alderson ", Version: 1}
+			},
+			wantName:    "Elliot Alderson",
+			wantVersion: 2,
+		},
+		{
+			name: "success - current version",
+			input: func(acc entities.Account) usecase.UpdateAccountInput {
+				return usecase.UpdateAccountInput{ID: acc.ID, Name: "Elliot Alderson"}
+			},
+			wantName:    "Elliot Alderson",
+			wantVersion: 2,
+		},
+		{
+			name: "fail - name not filled",
+			input: func(acc entities.Account) usecase.UpdateAccountInput {
+				return usecase.UpdateAccountInput{ID: acc.ID, Name: " "}
+			},
+			wantName:    "Elliot",
+			wantVersion: 1,
+			wantErr:     domain.ErrInvalidParameter,
+		},
+		{
+			name: "fail - outdated version",
+			input: func(acc entities.Account) usecase.UpdateAccountInput {
+				return usecase.UpdateAccountInput{ID: acc.ID, Name: "Elliot Alderson", Version: 2}
+			},
+			wantName:    "Elliot",
+			wantVersion: 1,
+			wantErr:     domain.ErrConflict,
+		},
+		{
+			name: "fail - account not found",
+			input: func(entities.Account) usecase.UpdateAccountInput {
+				return usecase.UpdateAccountInput{ID: uuid.Must(uuid.NewV7()), Name: "Elliot Alderson"}
+			},
+			wantName:    "Elliot",
+			wantVersion: 1,
+			wantErr:     domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			ctx := thelp.NewCtx(t)
+			r := memory.NewRepository()
+			acc := entities.Account{
+				ID:        uuid.Must(uuid.NewV7()),
+				Name:      "Elliot",
+				Document:  "33344455566",
+				Secret:    "password",
+				CreatedAt: time.Now().Truncate(time.Second),
+			}
+			require.NoError(t, r.CreateAccount(ctx, acc))
+
+			uc := usecase.NewUpdateAccountUC(r)
+
+			// execute
+			output, err := uc.UpdateAccount(ctx, tt.input(acc))
+
+			// assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantName, output.Account.Name)
+				assert.Equal(t, tt.wantVersion, output.Account.Version)
+			}
+
+			stored, err := r.GetAccount(ctx, acc.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, stored.Name)
+			assert.Equal(t, tt.wantVersion, stored.Version)
+		})
+	}
+}
@@ -50,7 +50,7 @@ func (uc AuthUC) Login(ctx context.Context, input LoginInput) (LoginOutput, erro
 
 	err = acc.Secret.CompareHashSecret(input.Secret)
 	if err != nil {
-		return LoginOutput{}, fmt.Errorf("%w: %w", domain.ErrInvalidParameter, err)
+		return LoginOutput{}, domain.NewError(domain.ErrInvalidParameter, domain.CodeInvalidCredentials, "invalid credentials").WithCause(err)
 	}
 
 	now := time.Now()
@@ -13,14 +13,14 @@ import (
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
 	"github.com/higordasneves/e-corp/pkg/domain/vos"
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
-	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
 	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
 )
 
 func TestAuthUC_Login_Success(t *testing.T) {
 	t.Parallel()
 
-	r := postgres.NewRepository(NewDB(t))
+	r := memory.NewRepository()
 
 	secret, err := vos.NewSecret("password123")
 	require.NoError(t, err)
@@ -52,7 +52,7 @@ func TestAuthUC_Login_Success(t *testing.T) {
 func TestAuthUC_Login_Failure_InvalidPass(t *testing.T) {
 	t.Parallel()
 
-	r := postgres.NewRepository(NewDB(t))
+	r := memory.NewRepository()
 
 	secret, err := vos.NewSecret("password123")
 	require.NoError(t, err)
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+//go:generate moq -stub -pkg mocks -out mocks/event_replay.go . ReplayEventsUCBroker
+
+// replayPageSize is the number of events read from the event store at a time.
+const replayPageSize = 500
+
+type ReplayEventsUCRepository interface {
+	ListEvents(ctx context.Context, filter entities.EventFilter, afterSequence int64, pageSize int) ([]entities.Event, error)
+}
+
+type ReplayEventsUCBroker interface {
+	RepublishEvent(ctx context.Context, event entities.Event, routingKey string) error
+}
+
+type ReplayEventsUC struct {
+	R ReplayEventsUCRepository
+	B ReplayEventsUCBroker
+}
+
+func NewReplayEventsUC(r ReplayEventsUCRepository, b ReplayEventsUCBroker) ReplayEventsUC {
+	return ReplayEventsUC{R: r, B: b}
+}
+
+type ReplayEventsInput struct {
+	Filter entities.EventFilter
+	// RoutingKey is where the events are republished, usually bound only to the queue being rebuilt.
+	RoutingKey string
+	// Rate is the maximum number of events republished per second. Zero means unlimited.
+	Rate int
+	// DryRun counts the events without republishing them.
+	DryRun bool
+}
+
+type ReplayEventsOutput struct {
+	// Matched is the number of events selected by the filter.
+	Matched int
+	// Replayed is the number of events republished.
+	Replayed int
+}
+
+// ReplayEvents republishes the events of the event store matching the filter, in the order they were appended.
+// Returns domain.ErrInvalidParameter if the routing key is empty or the rate is negative.
+func (uc ReplayEventsUC) ReplayEvents(ctx context.Context, input ReplayEventsInput) (ReplayEventsOutput, error) {
+	if input.RoutingKey == "" {
+		return ReplayEventsOutput{}, domain.NewFieldError("routing_key", domain.CodeRequired, "must not be empty")
+	}
+
+	if input.Rate < 0 {
+		return ReplayEventsOutput{}, domain.NewFieldError("rate", domain.CodeInvalid, "must not be negative")
+	}
+
+	var throttle <-chan time.Time
+	if input.Rate > 0 && !input.DryRun {
+		ticker := time.NewTicker(time.Second / time.Duration(input.Rate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	var output ReplayEventsOutput
+	var afterSequence int64
+	for {
+		events, err := uc.R.ListEvents(ctx, input.Filter, afterSequence, replayPageSize)
+		if err != nil {
+			return output, fmt.Errorf("listing events: %w", err)
+		}
+
+		for _, e := range events {
+			output.Matched++
+			if input.DryRun {
+				continue
+			}
+
+			if throttle != nil {
+				select {
+				case <-ctx.Done():
+					return output, fmt.Errorf("replaying events: %w", ctx.Err())
+				case <-throttle:
+				}
+			}
+
+			err = uc.B.RepublishEvent(ctx, e, input.RoutingKey)
+			if err != nil {
+				return output, fmt.Errorf("republishing event: %w", err)
+			}
+			output.Replayed++
+		}
+
+		if len(events) < replayPageSize {
+			return output, nil
+		}
+		afterSequence = events[len(events)-1].Sequence
+	}
+}
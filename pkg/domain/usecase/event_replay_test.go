@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestReplayEventsUC_ReplayEvents(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+
+	accountID := uuid.Must(uuid.NewV7())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []entities.Event{
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateAccount,
+			AggregateID:   accountID,
+			RoutingKey:    "ecorp.accountCreation",
+			Payload:       []byte(`{}`),
+			CreatedAt:     start,
+		},
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   uuid.Must(uuid.NewV7()),
+			RoutingKey:    "ecorp.transferCreation",
+			Payload:       []byte(`{}`),
+			CreatedAt:     start.Add(time.Hour),
+		},
+	}
+	for _, e := range events {
+		require.NoError(t, r.AppendEvent(ctx, e))
+	}
+
+	tests := []struct {
+		name         string
+		input        usecase.ReplayEventsInput
+		want         usecase.ReplayEventsOutput
+		wantReplayed []uuid.UUID
+		wantErr      error
+	}{
+		{
+			name:         "replays the events of the aggregate",
+			input:        usecase.ReplayEventsInput{Filter: entities.EventFilter{AggregateID: accountID}, RoutingKey: "ecorp.replay.projection", Rate: 100},
+			want:         usecase.ReplayEventsOutput{Matched: 1, Replayed: 1},
+			wantReplayed: []uuid.UUID{events[0].ID},
+		},
+		{
+			name:         "replays the events of the time range",
+			input:        usecase.ReplayEventsInput{Filter: entities.EventFilter{From: start, To: start.Add(2 * time.Hour)}, RoutingKey: "ecorp.replay.projection"},
+			want:         usecase.ReplayEventsOutput{Matched: 2, Replayed: 2},
+			wantReplayed: []uuid.UUID{events[0].ID, events[1].ID},
+		},
+		{
+			name:  "dry run doesn't republish the events",
+			input: usecase.ReplayEventsInput{Filter: entities.EventFilter{From: start}, RoutingKey: "ecorp.replay.projection", DryRun: true},
+			want:  usecase.ReplayEventsOutput{Matched: 2},
+		},
+		{
+			name:    "empty routing key",
+			input:   usecase.ReplayEventsInput{},
+			wantErr: domain.ErrInvalidParameter,
+		},
+		{
+			name:    "negative rate",
+			input:   usecase.ReplayEventsInput{RoutingKey: "ecorp.replay.projection", Rate: -1},
+			wantErr: domain.ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			var replayed []uuid.UUID
+			broker := &mocks.ReplayEventsUCBrokerMock{
+				RepublishEventFunc: func(ctx context.Context, event entities.Event, routingKey string) error {
+					assert.Equal(t, tt.input.RoutingKey, routingKey)
+					replayed = append(replayed, event.ID)
+					return nil
+				},
+			}
+			uc := usecase.NewReplayEventsUC(r, broker)
+
+			// execute
+			got, err := uc.ReplayEvents(ctx, tt.input)
+
+			// assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantReplayed, replayed)
+		})
+	}
+}
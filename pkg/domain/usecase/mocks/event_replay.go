@@ -0,0 +1,92 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"sync"
+)
+
+// Ensure, that ReplayEventsUCBrokerMock does implement usecase.ReplayEventsUCBroker.
+// If this is not the case, regenerate this file with moq.
+var _ usecase.ReplayEventsUCBroker = &ReplayEventsUCBrokerMock{}
+
+// ReplayEventsUCBrokerMock is a mock implementation of usecase.ReplayEventsUCBroker.
+//
+//	func TestSomethingThatUsesReplayEventsUCBroker(t *testing.T) {
+//
+//		// make and configure a mocked usecase.ReplayEventsUCBroker
+//		mockedReplayEventsUCBroker := &ReplayEventsUCBrokerMock{
+//			RepublishEventFunc: func(ctx context.Context, event entities.Event, routingKey string) error {
+//				panic("mock out the RepublishEvent method")
+//			},
+//		}
+//
+//		// use mockedReplayEventsUCBroker in code that requires usecase.ReplayEventsUCBroker
+//		// and then make assertions.
+//
+//	}
+type ReplayEventsUCBrokerMock struct {
+	// RepublishEventFunc mocks the RepublishEvent method.
+	RepublishEventFunc func(ctx context.Context, event entities.Event, routingKey string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// RepublishEvent holds details about calls to the RepublishEvent method.
+		RepublishEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Event is the event argument value.
+			Event entities.Event
+			// RoutingKey is the routingKey argument value.
+			RoutingKey string
+		}
+	}
+	lockRepublishEvent sync.RWMutex
+}
+
+// RepublishEvent calls RepublishEventFunc.
+func (mock *ReplayEventsUCBrokerMock) RepublishEvent(ctx context.Context, event entities.Event, routingKey string) error {
+	callInfo := struct {
+		Ctx        context.Context
+		Event      entities.Event
+		RoutingKey string
+	}{
+		Ctx:        ctx,
+		Event:      event,
+		RoutingKey: routingKey,
+	}
+	mock.lockRepublishEvent.Lock()
+	mock.calls.RepublishEvent = append(mock.calls.RepublishEvent, callInfo)
+	mock.lockRepublishEvent.Unlock()
+	if mock.RepublishEventFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.RepublishEventFunc(ctx, event, routingKey)
+}
+
+// RepublishEventCalls gets all the calls that were made to RepublishEvent.
+// Check the length with:
+//
+//	len(mockedReplayEventsUCBroker.RepublishEventCalls())
+func (mock *ReplayEventsUCBrokerMock) RepublishEventCalls() []struct {
+	Ctx        context.Context
+	Event      entities.Event
+	RoutingKey string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Event      entities.Event
+		RoutingKey string
+	}
+	mock.lockRepublishEvent.RLock()
+	calls = mock.calls.RepublishEvent
+	mock.lockRepublishEvent.RUnlock()
+	return calls
+}
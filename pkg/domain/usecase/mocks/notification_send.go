@@ -0,0 +1,169 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"sync"
+)
+
+// Ensure, that NotificationTemplatesMock does implement usecase.NotificationTemplates.
+// If this is not the case, regenerate this file with moq.
+var _ usecase.NotificationTemplates = &NotificationTemplatesMock{}
+
+// NotificationTemplatesMock is a mock implementation of usecase.NotificationTemplates.
+//
+//	func TestSomethingThatUsesNotificationTemplates(t *testing.T) {
+//
+//		// make and configure a mocked usecase.NotificationTemplates
+//		mockedNotificationTemplates := &NotificationTemplatesMock{
+//			RenderFunc: func(locale entities.Locale, event entities.NotificationEvent, data usecase.NotificationData) (string, string, error) {
+//				panic("mock out the Render method")
+//			},
+//		}
+//
+//		// use mockedNotificationTemplates in code that requires usecase.NotificationTemplates
+//		// and then make assertions.
+//
+//	}
+type NotificationTemplatesMock struct {
+	// RenderFunc mocks the Render method.
+	RenderFunc func(locale entities.Locale, event entities.NotificationEvent, data usecase.NotificationData) (string, string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Render holds details about calls to the Render method.
+		Render []struct {
+			// Locale is the locale argument value.
+			Locale entities.Locale
+			// Event is the event argument value.
+			Event entities.NotificationEvent
+			// Data is the data argument value.
+			Data usecase.NotificationData
+		}
+	}
+	lockRender sync.RWMutex
+}
+
+// Render calls RenderFunc.
+func (mock *NotificationTemplatesMock) Render(locale entities.Locale, event entities.NotificationEvent, data usecase.NotificationData) (string, string, error) {
+	callInfo := struct {
+		Locale entities.Locale
+		Event  entities.NotificationEvent
+		Data   usecase.NotificationData
+	}{
+		Locale: locale,
+		Event:  event,
+		Data:   data,
+	}
+	mock.lockRender.Lock()
+	mock.calls.Render = append(mock.calls.Render, callInfo)
+	mock.lockRender.Unlock()
+	if mock.RenderFunc == nil {
+		var (
+			subjectOut string
+			bodyOut    string
+			errOut     error
+		)
+		return subjectOut, bodyOut, errOut
+	}
+	return mock.RenderFunc(locale, event, data)
+}
+
+// RenderCalls gets all the calls that were made to Render.
+// Check the length with:
+//
+//	len(mockedNotificationTemplates.RenderCalls())
+func (mock *NotificationTemplatesMock) RenderCalls() []struct {
+	Locale entities.Locale
+	Event  entities.NotificationEvent
+	Data   usecase.NotificationData
+} {
+	var calls []struct {
+		Locale entities.Locale
+		Event  entities.NotificationEvent
+		Data   usecase.NotificationData
+	}
+	mock.lockRender.RLock()
+	calls = mock.calls.Render
+	mock.lockRender.RUnlock()
+	return calls
+}
+
+// Ensure, that NotificationSenderMock does implement usecase.NotificationSender.
+// If this is not the case, regenerate this file with moq.
+var _ usecase.NotificationSender = &NotificationSenderMock{}
+
+// NotificationSenderMock is a mock implementation of usecase.NotificationSender.
+//
+//	func TestSomethingThatUsesNotificationSender(t *testing.T) {
+//
+//		// make and configure a mocked usecase.NotificationSender
+//		mockedNotificationSender := &NotificationSenderMock{
+//			SendFunc: func(ctx context.Context, n entities.Notification) error {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedNotificationSender in code that requires usecase.NotificationSender
+//		// and then make assertions.
+//
+//	}
+type NotificationSenderMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(ctx context.Context, n entities.Notification) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// N is the n argument value.
+			N entities.Notification
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *NotificationSenderMock) Send(ctx context.Context, n entities.Notification) error {
+	callInfo := struct {
+		Ctx context.Context
+		N   entities.Notification
+	}{
+		Ctx: ctx,
+		N:   n,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	if mock.SendFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.SendFunc(ctx, n)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedNotificationSender.SendCalls())
+func (mock *NotificationSenderMock) SendCalls() []struct {
+	Ctx context.Context
+	N   entities.Notification
+} {
+	var calls []struct {
+		Ctx context.Context
+		N   entities.Notification
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
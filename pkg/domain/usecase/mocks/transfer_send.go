@@ -0,0 +1,86 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"sync"
+)
+
+// Ensure, that TransferUCBrokerMock does implement usecase.TransferUCBroker.
+// If this is not the case, regenerate this file with moq.
+var _ usecase.TransferUCBroker = &TransferUCBrokerMock{}
+
+// TransferUCBrokerMock is a mock implementation of usecase.TransferUCBroker.
+//
+//	func TestSomethingThatUsesTransferUCBroker(t *testing.T) {
+//
+//		// make and configure a mocked usecase.TransferUCBroker
+//		mockedTransferUCBroker := &TransferUCBrokerMock{
+//			NotifyTransferCreationFunc: func(ctx context.Context, transfer entities.Transfer) error {
+//				panic("mock out the NotifyTransferCreation method")
+//			},
+//		}
+//
+//		// use mockedTransferUCBroker in code that requires usecase.TransferUCBroker
+//		// and then make assertions.
+//
+//	}
+type TransferUCBrokerMock struct {
+	// NotifyTransferCreationFunc mocks the NotifyTransferCreation method.
+	NotifyTransferCreationFunc func(ctx context.Context, transfer entities.Transfer) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// NotifyTransferCreation holds details about calls to the NotifyTransferCreation method.
+		NotifyTransferCreation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Transfer is the transfer argument value.
+			Transfer entities.Transfer
+		}
+	}
+	lockNotifyTransferCreation sync.RWMutex
+}
+
+// NotifyTransferCreation calls NotifyTransferCreationFunc.
+func (mock *TransferUCBrokerMock) NotifyTransferCreation(ctx context.Context, transfer entities.Transfer) error {
+	callInfo := struct {
+		Ctx      context.Context
+		Transfer entities.Transfer
+	}{
+		Ctx:      ctx,
+		Transfer: transfer,
+	}
+	mock.lockNotifyTransferCreation.Lock()
+	mock.calls.NotifyTransferCreation = append(mock.calls.NotifyTransferCreation, callInfo)
+	mock.lockNotifyTransferCreation.Unlock()
+	if mock.NotifyTransferCreationFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.NotifyTransferCreationFunc(ctx, transfer)
+}
+
+// NotifyTransferCreationCalls gets all the calls that were made to NotifyTransferCreation.
+// Check the length with:
+//
+//	len(mockedTransferUCBroker.NotifyTransferCreationCalls())
+func (mock *TransferUCBrokerMock) NotifyTransferCreationCalls() []struct {
+	Ctx      context.Context
+	Transfer entities.Transfer
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Transfer entities.Transfer
+	}
+	mock.lockNotifyTransferCreation.RLock()
+	calls = mock.calls.NotifyTransferCreation
+	mock.lockNotifyTransferCreation.RUnlock()
+	return calls
+}
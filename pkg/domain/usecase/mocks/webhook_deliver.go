@@ -0,0 +1,93 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"sync"
+)
+
+// Ensure, that WebhookSenderMock does implement usecase.WebhookSender.
+// If this is not the case, regenerate this file with moq.
+var _ usecase.WebhookSender = &WebhookSenderMock{}
+
+// WebhookSenderMock is a mock implementation of usecase.WebhookSender.
+//
+//	func TestSomethingThatUsesWebhookSender(t *testing.T) {
+//
+//		// make and configure a mocked usecase.WebhookSender
+//		mockedWebhookSender := &WebhookSenderMock{
+//			SendFunc: func(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error) {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedWebhookSender in code that requires usecase.WebhookSender
+//		// and then make assertions.
+//
+//	}
+type WebhookSenderMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Sub is the sub argument value.
+			Sub entities.WebhookSubscription
+			// D is the d argument value.
+			D entities.WebhookDelivery
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *WebhookSenderMock) Send(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error) {
+	callInfo := struct {
+		Ctx context.Context
+		Sub entities.WebhookSubscription
+		D   entities.WebhookDelivery
+	}{
+		Ctx: ctx,
+		Sub: sub,
+		D:   d,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	if mock.SendFunc == nil {
+		var (
+			nOut   int
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.SendFunc(ctx, sub, d)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedWebhookSender.SendCalls())
+func (mock *WebhookSenderMock) SendCalls() []struct {
+	Ctx context.Context
+	Sub entities.WebhookSubscription
+	D   entities.WebhookDelivery
+} {
+	var calls []struct {
+		Ctx context.Context
+		Sub entities.WebhookSubscription
+		D   entities.WebhookDelivery
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+type GetNotificationPreferencesUCRepository interface {
+	GetNotificationPreferences(ctx context.Context, accountID uuid.UUID) (entities.NotificationPreferences, error)
+}
+
+type GetNotificationPreferencesUC struct {
+	R        GetNotificationPreferencesUCRepository
+	defaults notificationDefaults
+}
+
+func NewGetNotificationPreferencesUC(r GetNotificationPreferencesUCRepository, cfg *config.NotificationConfig) GetNotificationPreferencesUC {
+	return GetNotificationPreferencesUC{R: r, defaults: newNotificationDefaults(cfg)}
+}
+
+type GetNotificationPreferencesOutput struct {
+	Preferences entities.NotificationPreferences
+}
+
+// GetNotificationPreferences returns the notification preferences of the account,
+// or the default ones if the account didn't set them.
+func (uc GetNotificationPreferencesUC) GetNotificationPreferences(ctx context.Context, accountID uuid.UUID) (GetNotificationPreferencesOutput, error) {
+	prefs, err := notificationPreferences(ctx, uc.R, uc.defaults, accountID)
+	if err != nil {
+		return GetNotificationPreferencesOutput{}, err
+	}
+
+	return GetNotificationPreferencesOutput{Preferences: prefs}, nil
+}
+
+// notificationDefaults are the preferences of the accounts that didn't set them.
+type notificationDefaults struct {
+	locale              entities.Locale
+	largeTransferAmount int
+}
+
+func newNotificationDefaults(cfg *config.NotificationConfig) notificationDefaults {
+	return notificationDefaults{
+		locale:              entities.Locale(cfg.DefaultLocale),
+		largeTransferAmount: cfg.LargeTransferAmount,
+	}
+}
+
+// notificationPreferences fetches the preferences of the account. If the account didn't set them,
+// it is notified of every event by push, which is the only channel that doesn't need an address.
+func notificationPreferences(ctx context.Context, r GetNotificationPreferencesUCRepository, defaults notificationDefaults, accountID uuid.UUID) (entities.NotificationPreferences, error) {
+	prefs, err := r.GetNotificationPreferences(ctx, accountID)
+	if err == nil {
+		return prefs, nil
+	}
+
+	if !errors.Is(err, domain.ErrNotFound) {
+		return entities.NotificationPreferences{}, fmt.Errorf("getting notification preferences: %w", err)
+	}
+
+	return entities.NotificationPreferences{
+		AccountID:           accountID,
+		Locale:              defaults.locale,
+		Channels:            []entities.NotificationChannel{entities.NotificationChannelPush},
+		Events:              entities.NotificationEvents,
+		LargeTransferAmount: defaults.largeTransferAmount,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// phoneRegexp matches phone numbers in the E.164 format.
+var phoneRegexp = regexp.MustCompile(`^\+[1-9]\d{7,14}$`)
+
+type UpdateNotificationPreferencesUCRepository interface {
+	GetBalance(ctx context.Context, id uuid.UUID) (int, error)
+	SaveNotificationPreferences(ctx context.Context, p entities.NotificationPreferences) (entities.NotificationPreferences, error)
+}
+
+type UpdateNotificationPreferencesUC struct {
+	R UpdateNotificationPreferencesUCRepository
+}
+
+func NewUpdateNotificationPreferencesUC(r UpdateNotificationPreferencesUCRepository) UpdateNotificationPreferencesUC {
+	return UpdateNotificationPreferencesUC{R: r}
+}
+
+// UpdateNotificationPreferencesInput represents the notification preferences of an account.
+// They replace the current ones entirely.
+type UpdateNotificationPreferencesInput struct {
+	AccountID           uuid.UUID
+	Locale              entities.Locale
+	Channels            []entities.NotificationChannel
+	Events              []entities.NotificationEvent
+	Email               string
+	Phone               string
+	LargeTransferAmount int
+}
+
+type UpdateNotificationPreferencesOutput struct {
+	Preferences entities.NotificationPreferences
+}
+
+// UpdateNotificationPreferences validates and saves the notification preferences of the account.
+// Returns domain.ErrInvalidParameter if:
+// - the locale, a channel or an event is not supported;
+// - the email channel is selected without a valid email;
+// - the sms channel is selected without a phone in the E.164 format;
+// - the large transfer amount is not positive.
+// Returns domain.ErrNotFound if the account not exists.
+func (uc UpdateNotificationPreferencesUC) UpdateNotificationPreferences(ctx context.Context, input UpdateNotificationPreferencesInput) (UpdateNotificationPreferencesOutput, error) {
+	if !slices.Contains(entities.Locales, input.Locale) {
+		return UpdateNotificationPreferencesOutput{}, domain.NewFieldError("locale", domain.CodeUnsupported, fmt.Sprintf("must be one of %v", entities.Locales))
+	}
+
+	channels := make([]entities.NotificationChannel, 0, len(input.Channels))
+	for _, c := range input.Channels {
+		if !slices.Contains(entities.NotificationChannels, c) {
+			return UpdateNotificationPreferencesOutput{}, domain.NewFieldError("channels", domain.CodeUnsupported, fmt.Sprintf("channel %s not supported", c))
+		}
+		if !slices.Contains(channels, c) {
+			channels = append(channels, c)
+		}
+	}
+
+	events := make([]entities.NotificationEvent, 0, len(input.Events))
+	for _, e := range input.Events {
+		if !slices.Contains(entities.NotificationEvents, e) {
+			return UpdateNotificationPreferencesOutput{}, domain.NewFieldError("events", domain.CodeUnsupported, fmt.Sprintf("event %s not supported", e))
+		}
+		if !slices.Contains(events, e) {
+			events = append(events, e)
+		}
+	}
+
+	email := strings.TrimSpace(input.Email)
+	if email != "" || slices.Contains(channels, entities.NotificationChannelEmail) {
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			return UpdateNotificationPreferencesOutput{}, domain.NewFieldError("email", domain.CodeInvalid, "invalid email")
+		}
+	}
+
+	phone := strings.TrimSpace(input.Phone)
+	if phone != "" || slices.Contains(channels, entities.NotificationChannelSMS) {
+		if !phoneRegexp.MatchString(phone) {
+			return UpdateNotificationPreferencesOutput{}, domain.NewFieldError("phone", domain.CodeInvalid, "must be in the E.164 format")
+		}
+	}
+
+	if input.LargeTransferAmount <= 0 {
+		return UpdateNotificationPreferencesOutput{}, domain.NewFieldError("large_transfer_amount", domain.CodeMustBePositive, "must be positive")
+	}
+
+	if _, err := uc.R.GetBalance(ctx, input.AccountID); err != nil {
+		return UpdateNotificationPreferencesOutput{}, fmt.Errorf("getting account: %w", err)
+	}
+
+	prefs, err := uc.R.SaveNotificationPreferences(ctx, entities.NotificationPreferences{
+		AccountID:           input.AccountID,
+		Locale:              input.Locale,
+		Channels:            channels,
+		Events:              events,
+		Email:               email,
+		Phone:               phone,
+		LargeTransferAmount: input.LargeTransferAmount,
+	})
+	if err != nil {
+		return UpdateNotificationPreferencesOutput{}, fmt.Errorf("saving notification preferences: %w", err)
+	}
+
+	return UpdateNotificationPreferencesOutput{Preferences: prefs}, nil
+}
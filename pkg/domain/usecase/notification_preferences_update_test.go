@@ -0,0 +1,131 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestUpdateNotificationPreferencesUC_UpdateNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+	uc := usecase.NewUpdateNotificationPreferencesUC(r)
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	valid := func(update func(*usecase.UpdateNotificationPreferencesInput)) usecase.UpdateNotificationPreferencesInput {
+		input := usecase.UpdateNotificationPreferencesInput{
+			AccountID:           account.ID,
+			Locale:              entities.LocaleEn,
+			Channels:            []entities.NotificationChannel{entities.NotificationChannelSMS, entities.NotificationChannelSMS},
+			Events:              []entities.NotificationEvent{entities.NotificationTransferReceived},
+			Phone:               "+5511999999999",
+			LargeTransferAmount: 100,
+		}
+		update(&input)
+		return input
+	}
+
+	tests := []struct {
+		name        string
+		input       usecase.UpdateNotificationPreferencesInput
+		wantErr     error
+		errContains string
+	}{
+		{
+			name:  "success",
+			input: valid(func(*usecase.UpdateNotificationPreferencesInput) {}),
+		},
+		{
+			name:        "unsupported locale",
+			input:       valid(func(i *usecase.UpdateNotificationPreferencesInput) { i.Locale = "es" }),
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(locale)",
+		},
+		{
+			name: "unsupported channel",
+			input: valid(func(i *usecase.UpdateNotificationPreferencesInput) {
+				i.Channels = []entities.NotificationChannel{"pigeon"}
+			}),
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(channels)",
+		},
+		{
+			name: "unsupported event",
+			input: valid(func(i *usecase.UpdateNotificationPreferencesInput) {
+				i.Events = []entities.NotificationEvent{"account.deleted"}
+			}),
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(events)",
+		},
+		{
+			name: "email channel without email",
+			input: valid(func(i *usecase.UpdateNotificationPreferencesInput) {
+				i.Channels = []entities.NotificationChannel{entities.NotificationChannelEmail}
+			}),
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(email)",
+		},
+		{
+			name:        "phone not in the E.164 format",
+			input:       valid(func(i *usecase.UpdateNotificationPreferencesInput) { i.Phone = "11 99999-9999" }),
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(phone)",
+		},
+		{
+			name:        "large transfer amount not positive",
+			input:       valid(func(i *usecase.UpdateNotificationPreferencesInput) { i.LargeTransferAmount = 0 }),
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(large_transfer_amount)",
+		},
+		{
+			name:    "account not found",
+			input:   valid(func(i *usecase.UpdateNotificationPreferencesInput) { i.AccountID = uuid.Must(uuid.NewV7()) }),
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			got, err := uc.UpdateNotificationPreferences(ctx, tt.input)
+
+			// assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+
+			assert.Equal(t, []entities.NotificationChannel{entities.NotificationChannelSMS}, got.Preferences.Channels)
+			assert.False(t, got.Preferences.UpdatedAt.IsZero())
+
+			stored, err := usecase.NewGetNotificationPreferencesUC(r, &config.NotificationConfig{}).GetNotificationPreferences(ctx, account.ID)
+			require.NoError(t, err)
+			assert.Equal(t, got.Preferences, stored.Preferences)
+		})
+	}
+}
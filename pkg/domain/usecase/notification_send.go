@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+//go:generate moq -stub -pkg mocks -out mocks/notification_send.go . NotificationTemplates NotificationSender
+
+type SendNotificationsUCRepository interface {
+	GetAccount(ctx context.Context, id uuid.UUID) (entities.Account, error)
+	GetNotificationPreferences(ctx context.Context, accountID uuid.UUID) (entities.NotificationPreferences, error)
+	UpsertNotification(ctx context.Context, n entities.Notification) (entities.Notification, error)
+	MarkNotificationSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error
+}
+
+// NotificationTemplates renders the messages of the notifications.
+type NotificationTemplates interface {
+	Render(locale entities.Locale, event entities.NotificationEvent, data NotificationData) (subject, body string, err error)
+}
+
+// NotificationSender sends a notification on its channel.
+type NotificationSender interface {
+	Send(ctx context.Context, n entities.Notification) error
+}
+
+// NotificationData is the data available to the notification templates.
+type NotificationData struct {
+	// Name is the name of the notified account holder.
+	Name string
+	// Counterpart is the name of the holder of the other account of a transfer.
+	Counterpart string
+	Amount      int
+	CreatedAt   time.Time
+}
+
+type SendNotificationsUC struct {
+	R        SendNotificationsUCRepository
+	T        NotificationTemplates
+	S        NotificationSender
+	defaults notificationDefaults
+}
+
+func NewSendNotificationsUC(r SendNotificationsUCRepository, t NotificationTemplates, s NotificationSender, cfg *config.NotificationConfig) SendNotificationsUC {
+	return SendNotificationsUC{R: r, T: t, S: s, defaults: newNotificationDefaults(cfg)}
+}
+
+type NotifyAccountCreationInput struct {
+	// EventID identifies the account creation event.
+	EventID   string
+	AccountID uuid.UUID
+}
+
+type NotifyTransferInput struct {
+	// EventID identifies the transfer creation event.
+	EventID  string
+	Transfer entities.Transfer
+}
+
+type SendNotificationsOutput struct {
+	// Sent is the number of notifications sent.
+	Sent int
+	// Skipped is the number of notifications not sent because they were already.
+	Skipped int
+}
+
+// NotifyAccountCreation notifies the holder of the created account.
+// Returns domain.ErrNotFound if the account not exists.
+func (uc SendNotificationsUC) NotifyAccountCreation(ctx context.Context, input NotifyAccountCreationInput) (SendNotificationsOutput, error) {
+	acc, err := uc.R.GetAccount(ctx, input.AccountID)
+	if err != nil {
+		return SendNotificationsOutput{}, fmt.Errorf("getting account: %w", err)
+	}
+
+	prefs, err := notificationPreferences(ctx, uc.R, uc.defaults, acc.ID)
+	if err != nil {
+		return SendNotificationsOutput{}, err
+	}
+
+	return uc.send(ctx, prefs, entities.NotificationAccountCreated, input.EventID, NotificationData{
+		Name:      acc.Name,
+		CreatedAt: acc.CreatedAt,
+	})
+}
+
+// NotifyTransfer notifies the destination account of the received transfer, and the origin account
+// if the amount reaches the large transfer amount of its preferences.
+// Returns domain.ErrNotFound if an account not exists.
+func (uc SendNotificationsUC) NotifyTransfer(ctx context.Context, input NotifyTransferInput) (SendNotificationsOutput, error) {
+	origin, err := uc.R.GetAccount(ctx, input.Transfer.AccountOriginID)
+	if err != nil {
+		return SendNotificationsOutput{}, fmt.Errorf("getting origin account: %w", err)
+	}
+
+	destination, err := uc.R.GetAccount(ctx, input.Transfer.AccountDestinationID)
+	if err != nil {
+		return SendNotificationsOutput{}, fmt.Errorf("getting destination account: %w", err)
+	}
+
+	prefs, err := notificationPreferences(ctx, uc.R, uc.defaults, destination.ID)
+	if err != nil {
+		return SendNotificationsOutput{}, err
+	}
+
+	output, err := uc.send(ctx, prefs, entities.NotificationTransferReceived, input.EventID, NotificationData{
+		Name:        destination.Name,
+		Counterpart: origin.Name,
+		Amount:      input.Transfer.Amount,
+		CreatedAt:   input.Transfer.CreatedAt,
+	})
+	if err != nil {
+		return output, err
+	}
+
+	prefs, err = notificationPreferences(ctx, uc.R, uc.defaults, origin.ID)
+	if err != nil {
+		return output, err
+	}
+
+	if input.Transfer.Amount < prefs.LargeTransferAmount {
+		return output, nil
+	}
+
+	sent, err := uc.send(ctx, prefs, entities.NotificationLargeTransferSent, input.EventID, NotificationData{
+		Name:        origin.Name,
+		Counterpart: destination.Name,
+		Amount:      input.Transfer.Amount,
+		CreatedAt:   input.Transfer.CreatedAt,
+	})
+	output.Sent += sent.Sent
+	output.Skipped += sent.Skipped
+
+	return output, err
+}
+
+// send notifies the event on every channel of the preferences, unless the account opted out of it.
+// A notification is recorded before being sent, so the channels that already received the event
+// are skipped when it is redelivered.
+func (uc SendNotificationsUC) send(ctx context.Context, prefs entities.NotificationPreferences, event entities.NotificationEvent, eventID string, data NotificationData) (SendNotificationsOutput, error) {
+	var output SendNotificationsOutput
+	if !slices.Contains(prefs.Events, event) || len(prefs.Channels) == 0 {
+		return output, nil
+	}
+
+	subject, body, err := uc.T.Render(prefs.Locale, event, data)
+	if err != nil {
+		return output, fmt.Errorf("rendering %s notification: %w", event, err)
+	}
+
+	now := time.Now()
+	for _, channel := range prefs.Channels {
+		n, err := uc.R.UpsertNotification(ctx, entities.Notification{
+			ID:        uuid.Must(uuid.NewV7()),
+			AccountID: prefs.AccountID,
+			EventID:   eventID,
+			Event:     event,
+			Channel:   channel,
+			Recipient: prefs.Recipient(channel),
+			Subject:   subject,
+			Body:      body,
+			Status:    entities.NotificationPending,
+			CreatedAt: now.Truncate(time.Second),
+		})
+		if err != nil {
+			return output, fmt.Errorf("recording notification: %w", err)
+		}
+
+		if n.Status == entities.NotificationSent {
+			output.Skipped++
+			continue
+		}
+
+		if err = uc.S.Send(ctx, n); err != nil {
+			return output, fmt.Errorf("sending %s notification: %w", channel, err)
+		}
+
+		if err = uc.R.MarkNotificationSent(ctx, n.ID, time.Now()); err != nil {
+			return output, fmt.Errorf("marking notification as sent: %w", err)
+		}
+		output.Sent++
+	}
+
+	return output, nil
+}
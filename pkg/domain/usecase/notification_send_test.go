@@ -0,0 +1,122 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestSendNotificationsUC_NotifyTransfer(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+
+	origin := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		Balance:   1000000,
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	destination := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Mr.Robot",
+		Document:  "33344455568",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, origin))
+	require.NoError(t, r.CreateAccount(ctx, destination))
+
+	// the destination is notified by email and push, the origin uses the default preferences.
+	_, err := usecase.NewUpdateNotificationPreferencesUC(r).UpdateNotificationPreferences(ctx, usecase.UpdateNotificationPreferencesInput{
+		AccountID:           destination.ID,
+		Locale:              entities.LocaleEn,
+		Channels:            []entities.NotificationChannel{entities.NotificationChannelEmail, entities.NotificationChannelPush},
+		Events:              []entities.NotificationEvent{entities.NotificationTransferReceived},
+		Email:               "mr.robot@fsociety.com",
+		LargeTransferAmount: 100,
+	})
+	require.NoError(t, err)
+
+	templates := &mocks.NotificationTemplatesMock{
+		RenderFunc: func(locale entities.Locale, event entities.NotificationEvent, data usecase.NotificationData) (string, string, error) {
+			return string(event), string(locale) + " " + data.Name, nil
+		},
+	}
+
+	var (
+		mu   sync.Mutex
+		sent []entities.Notification
+		fail = true
+	)
+	sender := &mocks.NotificationSenderMock{
+		SendFunc: func(ctx context.Context, n entities.Notification) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			// the push provider is unavailable on the first attempt.
+			if n.Channel == entities.NotificationChannelPush && n.AccountID == destination.ID && fail {
+				fail = false
+				return errors.New("notification not accepted by the provider: status code 503")
+			}
+			sent = append(sent, n)
+			return nil
+		},
+	}
+
+	uc := usecase.NewSendNotificationsUC(r, templates, sender, &config.NotificationConfig{DefaultLocale: "pt-BR", LargeTransferAmount: 50000})
+	input := usecase.NotifyTransferInput{
+		EventID: uuid.Must(uuid.NewV7()).String(),
+		Transfer: entities.Transfer{
+			ID:                   uuid.Must(uuid.NewV7()),
+			AccountOriginID:      origin.ID,
+			AccountDestinationID: destination.ID,
+			Amount:               50000,
+			CreatedAt:            time.Now().Truncate(time.Second),
+		},
+	}
+
+	// execute: the push fails after the email is sent
+	got, err := uc.NotifyTransfer(ctx, input)
+	require.Error(t, err)
+	assert.Equal(t, usecase.SendNotificationsOutput{Sent: 1}, got)
+
+	// execute: redelivery of the event
+	got, err = uc.NotifyTransfer(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, usecase.SendNotificationsOutput{Sent: 2, Skipped: 1}, got)
+
+	// execute: duplicated delivery of the event
+	got, err = uc.NotifyTransfer(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, usecase.SendNotificationsOutput{Skipped: 3}, got)
+
+	// assert
+	require.Len(t, sent, 3)
+	assert.Equal(t, entities.NotificationChannelEmail, sent[0].Channel)
+	assert.Equal(t, "mr.robot@fsociety.com", sent[0].Recipient)
+	assert.Equal(t, "en Mr.Robot", sent[0].Body)
+	assert.Equal(t, entities.NotificationChannelPush, sent[1].Channel)
+	assert.Equal(t, destination.ID.String(), sent[1].Recipient)
+	assert.Equal(t, 2, sent[1].Attempts)
+	assert.Equal(t, entities.NotificationLargeTransferSent, sent[2].Event)
+	assert.Equal(t, origin.ID.String(), sent[2].Recipient)
+	assert.Equal(t, "pt-BR Elliot", sent[2].Body)
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the use cases. It uses the global tracer provider.
+var tracer = otel.Tracer("github.com/higordasneves/e-corp/pkg/domain/usecase")
+
+// endSpan records the error, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/higordasneves/e-corp/pkg/domain"
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
-	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
 )
 
 func TestTransferUseCase_ListAccountTransfers_Success(t *testing.T) {
@@ -41,7 +41,7 @@ func TestTransferUseCase_ListAccountTransfers_Success(t *testing.T) {
 		},
 	}
 
-	r := postgres.NewRepository(NewDB(t))
+	r := memory.NewRepository()
 	ctxDB := context.Background()
 	for _, acc := range accounts {
 		err := r.CreateAccount(ctxDB, acc)
@@ -87,7 +87,7 @@ func TestTransferUseCase_ListAccountTransfers_Failure_NotFound(t *testing.T) {
 	t.Parallel()
 
 	// setup
-	r := postgres.NewRepository(NewDB(t))
+	r := memory.NewRepository()
 	uc := usecase.ListAccountTransfersUC{R: r}
 	// execute
 	_, err := uc.ListAccountTransfers(context.Background(), usecase.ListAccountTransfersInput{AccountID: uuid.Must(uuid.NewV7())})
@@ -6,30 +6,44 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/higordasneves/e-corp/pkg/domain"
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/metrics"
 )
 
+//go:generate moq -stub -pkg mocks -out mocks/transfer_send.go . TransferUCBroker
+
 type TransferUCRepository interface {
 	GetBalance(ctx context.Context, id uuid.UUID) (int, error)
 	GetAccountByDocument(ctx context.Context, cpf vos.Document) (entities.Account, error)
 	UpdateBalance(ctx context.Context, id uuid.UUID, transactionAmount int) error
 
 	CreateTransfer(ctx context.Context, transfer entities.Transfer) error
+}
 
-	BeginTX(ctx context.Context) (context.Context, error)
-	CommitTX(ctx context.Context) error
-	RollbackTX(ctx context.Context) error
+// TxRunner runs a unit of work in a transaction, which is committed if fn succeeds and rolled back otherwise.
+// The unit of work is run again when it fails on a conflict with concurrent transactions,
+// so fn must not have effects outside the transaction.
+type TxRunner interface {
+	RunTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type TransferUCBroker interface {
+	NotifyTransferCreation(ctx context.Context, transfer entities.Transfer) error
 }
 
 type TransferUC struct {
-	R TransferUCRepository
+	R  TransferUCRepository
+	Tx TxRunner
+	B  TransferUCBroker
 }
 
-func NewTransferUC(r TransferUCRepository) TransferUC {
-	return TransferUC{R: r}
+func NewTransferUC(r TransferUCRepository, tx TxRunner, broker TransferUCBroker) TransferUC {
+	return TransferUC{R: r, Tx: tx, B: broker}
 }
 
 // TransferInput represents information necessary to transfer money between bank accounts
@@ -49,13 +63,20 @@ type TransferOutput struct {
 // - The amount is less than or equal to zero.
 // - The origin accounts doesn't have enough funds to complete the transfer.
 // Returns domain.ErrNotFound if the origin or destination account not exists.
-func (tUseCase TransferUC) Transfer(ctx context.Context, input TransferInput) (TransferOutput, error) {
+func (tUseCase TransferUC) Transfer(ctx context.Context, input TransferInput) (_ TransferOutput, err error) {
+	ctx, span := tracer.Start(ctx, "TransferUC.Transfer", trace.WithAttributes(
+		attribute.String("transfer.origin_id", input.AccountOriginID.String()),
+		attribute.String("transfer.destination_id", input.AccountDestinationID.String()),
+		attribute.Int("transfer.amount", input.Amount),
+	))
+	defer func() { endSpan(span, err) }()
+
 	ctx, cancel := context.WithTimeout(ctx, 90*time.Second)
 	defer cancel()
 
-	err := ValidateTransferInput(input)
+	err = ValidateTransferInput(input)
 	if err != nil {
-		return TransferOutput{}, fmt.Errorf("%w: %w", domain.ErrInvalidParameter, err)
+		return TransferOutput{}, err
 	}
 
 	transfer := entities.Transfer{
@@ -65,35 +86,40 @@ func (tUseCase TransferUC) Transfer(ctx context.Context, input TransferInput) (T
 		Amount:               input.Amount,
 		CreatedAt:            time.Now().Truncate(time.Second),
 	}
+	span.SetAttributes(attribute.String("transfer.id", transfer.ID.String()))
 
-	err = tUseCase.validate(ctx, transfer)
-	if err != nil {
-		return TransferOutput{}, fmt.Errorf("%w: %w", domain.ErrInvalidParameter, err)
-	}
+	// the balance is checked in the transaction, so a concurrent transfer that spends it
+	// makes the transaction fail and run again.
+	err = tUseCase.Tx.RunTx(ctx, func(ctx context.Context) error {
+		if err := tUseCase.validate(ctx, transfer); err != nil {
+			return err
+		}
 
-	ctx, err = tUseCase.R.BeginTX(ctx)
-	if err != nil {
-		return TransferOutput{}, fmt.Errorf("starting transaction: %w", err)
-	}
-	defer tUseCase.R.RollbackTX(ctx) // nolint:errcheck
+		if err := tUseCase.R.CreateTransfer(ctx, transfer); err != nil {
+			return fmt.Errorf("error creating transfer: %w", err)
+		}
 
-	err = tUseCase.R.CreateTransfer(ctx, transfer)
-	if err != nil {
-		return TransferOutput{}, fmt.Errorf("error creating transfer: %w", err)
-	}
+		if err := tUseCase.R.UpdateBalance(ctx, transfer.AccountOriginID, -transfer.Amount); err != nil {
+			return fmt.Errorf("error updating origin account balance: %w", err)
+		}
 
-	err = tUseCase.R.UpdateBalance(ctx, transfer.AccountOriginID, -transfer.Amount)
-	if err != nil {
-		return TransferOutput{}, fmt.Errorf("error updating origin account balance: %w", err)
-	}
+		if err := tUseCase.R.UpdateBalance(ctx, transfer.AccountDestinationID, transfer.Amount); err != nil {
+			return fmt.Errorf("error updating destination account balance: %w", err)
+		}
 
-	err = tUseCase.R.UpdateBalance(ctx, transfer.AccountDestinationID, transfer.Amount)
+		return nil
+	})
 	if err != nil {
-		return TransferOutput{}, fmt.Errorf("error updating destination account balance: %w", err)
+		return TransferOutput{}, err
 	}
 
-	if err = tUseCase.R.CommitTX(ctx); err != nil {
-		return TransferOutput{}, fmt.Errorf("error committing transaction: %w", err)
+	// counted once committed, whatever the API the transfer came from and the result of the notification.
+	metrics.TransfersCreated.Inc()
+	metrics.TransferVolume.Add(float64(transfer.Amount))
+
+	err = tUseCase.B.NotifyTransferCreation(ctx, transfer)
+	if err != nil {
+		return TransferOutput{}, fmt.Errorf("notifying transfer creation in the broker: %w", err)
 	}
 
 	return TransferOutput{transfer}, nil
@@ -104,18 +130,20 @@ func (tUseCase TransferUC) Transfer(ctx context.Context, input TransferInput) (T
 // Returns domain.ErrInvalidParameter if the amount is less than or equal to zero.
 func ValidateTransferInput(i TransferInput) error {
 	if i.AccountOriginID == i.AccountDestinationID {
-		return fmt.Errorf("%w: the destination account must be different from the origin account", domain.ErrInvalidParameter)
+		return domain.NewError(domain.ErrInvalidParameter, domain.CodeSameAccountTransfer, "the destination account must be different from the origin account")
 	}
 
 	if i.Amount <= 0 {
-		return fmt.Errorf("%w: invalid transfer amount, the amount must be greater than 0", domain.ErrInvalidParameter)
+		return domain.NewFieldError("amount", domain.CodeMustBePositive, "invalid transfer amount, the amount must be greater than 0")
 	}
 
 	return nil
 }
 
-// validate validates existence of the accounts involved and balance sufficiency.
-// Returns domain.ErrNotFound if the origin or destination account not exists.
+// validate validates existence of the origin account and balance sufficiency.
+// The existence of the destination account is checked by CreateTransfer. Reading its balance would make
+// the concurrent credits of a hot account conflict, even when they go to different balance shards.
+// Returns domain.ErrNotFound if the origin account not exists.
 // Returns domain.ErrInvalidParameter if the origin accounts doesn't have enough funds to complete the transfer.
 func (tUseCase TransferUC) validate(ctx context.Context, transfer entities.Transfer) error {
 	originBalance, err := tUseCase.R.GetBalance(ctx, transfer.AccountOriginID)
@@ -123,14 +151,8 @@ func (tUseCase TransferUC) validate(ctx context.Context, transfer entities.Trans
 		return fmt.Errorf("getting origin account balance: %w", err)
 	}
 
-	// just checking if the destination account exists.
-	_, err = tUseCase.R.GetBalance(ctx, transfer.AccountDestinationID)
-	if err != nil {
-		return fmt.Errorf("getting destination account balance: %w", err)
-	}
-
 	if transfer.Amount > originBalance {
-		return fmt.Errorf("%w: insufficient funds", domain.ErrInvalidParameter)
+		return domain.NewError(domain.ErrInvalidParameter, domain.CodeInsufficientFunds, "insufficient funds")
 	}
 
 	return nil
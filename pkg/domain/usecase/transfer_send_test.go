@@ -11,7 +11,8 @@ import (
 	"github.com/higordasneves/e-corp/pkg/domain"
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
-	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
 	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
 )
 
@@ -19,8 +20,8 @@ func TestTransferUC_Transfer_Success(t *testing.T) {
 	t.Parallel()
 
 	// setup
-	r := postgres.NewRepository(NewDB(t))
-	uc := usecase.TransferUC{R: r}
+	r := memory.NewRepository()
+	uc := usecase.TransferUC{R: r, Tx: r, B: &mocks.TransferUCBrokerMock{}}
 
 	accOriginID := uuid.Must(uuid.NewV7())
 	accDestinationID := uuid.Must(uuid.NewV7())
@@ -88,8 +89,8 @@ func TestTransferUC_Transfer(t *testing.T) {
 	t.Parallel()
 
 	// setup
-	r := postgres.NewRepository(NewDB(t))
-	uc := usecase.TransferUC{R: r}
+	r := memory.NewRepository()
+	uc := usecase.TransferUC{R: r, Tx: r, B: &mocks.TransferUCBrokerMock{}}
 
 	accOriginID := uuid.Must(uuid.NewV7())
 	accDestinationID := uuid.Must(uuid.NewV7())
@@ -163,7 +164,7 @@ func TestTransferUC_Transfer(t *testing.T) {
 				Amount:               5,
 			},
 			wantErr:    domain.ErrNotFound,
-			wantErrMsg: "getting destination account balance",
+			wantErrMsg: "error creating transfer",
 		},
 		{
 			name: "not enough funds",
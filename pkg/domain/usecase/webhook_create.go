@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type CreateWebhookUCRepository interface {
+	GetBalance(ctx context.Context, id uuid.UUID) (int, error)
+	CreateWebhookSubscription(ctx context.Context, sub entities.WebhookSubscription) error
+}
+
+type CreateWebhookUC struct {
+	R CreateWebhookUCRepository
+}
+
+func NewCreateWebhookUC(r CreateWebhookUCRepository) CreateWebhookUC {
+	return CreateWebhookUC{R: r}
+}
+
+// CreateWebhookInput represents information necessary to subscribe an account to webhook events.
+type CreateWebhookInput struct {
+	AccountID  uuid.UUID
+	URL        string
+	EventTypes []entities.WebhookEventType
+}
+
+type CreateWebhookOutput struct {
+	// Subscription contains the secret used to sign the payloads. It is only returned on creation.
+	Subscription entities.WebhookSubscription
+}
+
+// CreateWebhook validates the input and creates a webhook subscription with a new signing secret.
+// Returns domain.ErrInvalidParameter if:
+// - the url is not an absolute http or https url;
+// - the url host is localhost or an address that is not public, as the internal services;
+// - no event type is provided or an event type is not supported.
+// Returns domain.ErrNotFound if the account not exists.
+func (uc CreateWebhookUC) CreateWebhook(ctx context.Context, input CreateWebhookInput) (CreateWebhookOutput, error) {
+	u, err := url.Parse(strings.TrimSpace(input.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return CreateWebhookOutput{}, domain.NewFieldError("url", domain.CodeInvalid, "must be an absolute http or https url")
+	}
+
+	if !publicWebhookHost(u.Hostname()) {
+		return CreateWebhookOutput{}, domain.NewFieldError("url", domain.CodeInvalid, "must be the url of a public host")
+	}
+
+	if len(input.EventTypes) == 0 {
+		return CreateWebhookOutput{}, domain.NewFieldError("event_types", domain.CodeRequired, "required field")
+	}
+
+	eventTypes := make([]entities.WebhookEventType, 0, len(input.EventTypes))
+	for _, e := range input.EventTypes {
+		if !slices.Contains(entities.WebhookEventTypes, e) {
+			return CreateWebhookOutput{}, domain.NewFieldError("event_types", domain.CodeUnsupported, fmt.Sprintf("unsupported event type %s", e))
+		}
+		if !slices.Contains(eventTypes, e) {
+			eventTypes = append(eventTypes, e)
+		}
+	}
+
+	// Just checking if the account exists. the repository returns domain.ErrNotFound if not exits.
+	if _, err = uc.R.GetBalance(ctx, input.AccountID); err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("getting balance: %w", err)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return CreateWebhookOutput{}, err
+	}
+
+	sub := entities.WebhookSubscription{
+		ID:         uuid.Must(uuid.NewV7()),
+		AccountID:  input.AccountID,
+		URL:        u.String(),
+		EventTypes: eventTypes,
+		Secret:     secret,
+		CreatedAt:  time.Now().Truncate(time.Second),
+	}
+
+	err = uc.R.CreateWebhookSubscription(ctx, sub)
+	if err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("creating webhook subscription in the database: %w", err)
+	}
+
+	return CreateWebhookOutput{Subscription: sub}, nil
+}
+
+// publicWebhookHost reports whether the host may be public. The names are resolved when the webhooks are sent,
+// which is when the sender checks their addresses.
+func publicWebhookHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return PublicWebhookAddr(addr)
+	}
+
+	return true
+}
+
+// nonPublicPrefixes are the ranges of global unicast addresses that don't reach the internet.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// PublicWebhookAddr reports whether the webhooks may be sent to the address. The loopback, link-local and
+// private addresses are refused, so a subscription can't reach the services of the internal network.
+func PublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newWebhookSecret generates a random key used to sign webhook payloads.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating webhook secret: %w", err)
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
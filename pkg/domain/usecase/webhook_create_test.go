@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestCreateWebhookUC_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+	uc := usecase.NewCreateWebhookUC(r)
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	tests := []struct {
+		name        string
+		input       usecase.CreateWebhookInput
+		wantErr     error
+		errContains string
+	}{
+		{
+			name: "success",
+			input: usecase.CreateWebhookInput{
+				AccountID:  account.ID,
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived, entities.WebhookEventTransferReceived},
+			},
+		},
+		{
+			name: "invalid url",
+			input: usecase.CreateWebhookInput{
+				AccountID:  account.ID,
+				URL:        "ftp://partner.example.com",
+				EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+			},
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(url)",
+		},
+		{
+			name: "loopback url",
+			input: usecase.CreateWebhookInput{
+				AccountID:  account.ID,
+				URL:        "http://127.0.0.1:8080/hooks",
+				EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+			},
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(url): must be the url of a public host",
+		},
+		{
+			name: "metadata service url",
+			input: usecase.CreateWebhookInput{
+				AccountID:  account.ID,
+				URL:        "http://169.254.169.254/latest/meta-data",
+				EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+			},
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(url): must be the url of a public host",
+		},
+		{
+			name: "private url",
+			input: usecase.CreateWebhookInput{
+				AccountID:  account.ID,
+				URL:        "https://[fd00::1]/hooks",
+				EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+			},
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(url): must be the url of a public host",
+		},
+		{
+			name: "localhost url",
+			input: usecase.CreateWebhookInput{
+				AccountID:  account.ID,
+				URL:        "http://LocalHost./hooks",
+				EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+			},
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(url): must be the url of a public host",
+		},
+		{
+			name: "without event types",
+			input: usecase.CreateWebhookInput{
+				AccountID: account.ID,
+				URL:       "https://partner.example.com/hooks",
+			},
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "(event_types): required field",
+		},
+		{
+			name: "unsupported event type",
+			input: usecase.CreateWebhookInput{
+				AccountID:  account.ID,
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []entities.WebhookEventType{"account.deleted"},
+			},
+			wantErr:     domain.ErrInvalidParameter,
+			errContains: "unsupported event type account.deleted",
+		},
+		{
+			name: "account not found",
+			input: usecase.CreateWebhookInput{
+				AccountID:  uuid.Must(uuid.NewV7()),
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			got, err := uc.CreateWebhook(ctx, tt.input)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []entities.WebhookEventType{entities.WebhookEventTransferReceived}, got.Subscription.EventTypes)
+			assert.Contains(t, got.Subscription.Secret, "whsec_")
+
+			stored, err := r.GetWebhookSubscription(ctx, got.Subscription.ID)
+			require.NoError(t, err)
+			assert.Equal(t, got.Subscription, stored)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+type DeleteWebhookUCRepository interface {
+	DeleteWebhookSubscription(ctx context.Context, accountID, id uuid.UUID) error
+}
+
+type DeleteWebhookUC struct {
+	R DeleteWebhookUCRepository
+}
+
+func NewDeleteWebhookUC(r DeleteWebhookUCRepository) DeleteWebhookUC {
+	return DeleteWebhookUC{R: r}
+}
+
+type DeleteWebhookInput struct {
+	AccountID      uuid.UUID
+	SubscriptionID uuid.UUID
+}
+
+// DeleteWebhook deletes a webhook subscription of the account and its deliveries log.
+// The repository returns domain.ErrNotFound if the account doesn't have the subscription.
+func (uc DeleteWebhookUC) DeleteWebhook(ctx context.Context, input DeleteWebhookInput) error {
+	err := uc.R.DeleteWebhookSubscription(ctx, input.AccountID, input.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("deleting webhook subscription: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+//go:generate moq -stub -pkg mocks -out mocks/webhook_deliver.go . WebhookSender
+
+const (
+	// maxWebhookBackoff is the maximum delay between two attempts of a delivery.
+	maxWebhookBackoff = 6 * time.Hour
+	// minWebhookLease is the minimum time the claimed deliveries are skipped by the other workers.
+	minWebhookLease = time.Minute
+)
+
+type DeliverWebhooksUCRepository interface {
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (entities.WebhookSubscription, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, d entities.WebhookDelivery) error
+}
+
+// WebhookSender sends a delivery payload to the subscription url.
+type WebhookSender interface {
+	// Send returns the HTTP status code of the response and an error if the delivery was not accepted.
+	Send(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error)
+}
+
+type DeliverWebhooksUC struct {
+	R           DeliverWebhooksUCRepository
+	S           WebhookSender
+	maxAttempts int
+	backoff     time.Duration
+	timeout     time.Duration
+}
+
+func NewDeliverWebhooksUC(r DeliverWebhooksUCRepository, s WebhookSender, cfg *config.WebhookConfig) DeliverWebhooksUC {
+	return DeliverWebhooksUC{R: r, S: s, maxAttempts: cfg.MaxAttempts, backoff: cfg.Backoff, timeout: cfg.Timeout}
+}
+
+type DeliverWebhooksInput struct {
+	// Limit is the maximum number of deliveries attempted.
+	Limit int
+}
+
+type DeliverWebhooksOutput struct {
+	Succeeded int
+	Retrying  int
+	Failed    int
+}
+
+// DeliverWebhooks attempts the pending deliveries whose next attempt is due.
+// A failed attempt is rescheduled with a jittered exponential backoff until the maximum number of attempts
+// is reached, when the delivery is marked as failed.
+//
+// The deliveries are claimed for long enough to attempt all of them, and then sent without holding a transaction.
+// The result of each attempt is recorded on its own, so a failure to record one doesn't send the others again.
+// The deliveries whose attempt can't be recorded are claimed again when the lease ends, and the error is returned
+// with the output of the others.
+func (uc DeliverWebhooksUC) DeliverWebhooks(ctx context.Context, input DeliverWebhooksInput) (DeliverWebhooksOutput, error) {
+	now := time.Now()
+	lease := max(time.Duration(input.Limit)*uc.timeout, minWebhookLease)
+
+	deliveries, err := uc.R.ClaimDueWebhookDeliveries(ctx, now, now.Add(lease), input.Limit)
+	if err != nil {
+		return DeliverWebhooksOutput{}, fmt.Errorf("claiming due webhook deliveries: %w", err)
+	}
+
+	var (
+		output DeliverWebhooksOutput
+		errs   []error
+	)
+	for _, d := range deliveries {
+		sub, err := uc.R.GetWebhookSubscription(ctx, d.SubscriptionID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("getting webhook subscription of delivery %s: %w", d.ID, err))
+			continue
+		}
+
+		d = uc.attempt(ctx, sub, d)
+		if err = uc.R.UpdateWebhookDelivery(ctx, d); err != nil {
+			errs = append(errs, fmt.Errorf("updating webhook delivery: %w", err))
+			continue
+		}
+
+		switch d.Status {
+		case entities.WebhookDeliverySucceeded:
+			output.Succeeded++
+		case entities.WebhookDeliveryFailed:
+			output.Failed++
+		default:
+			output.Retrying++
+		}
+	}
+
+	return output, errors.Join(errs...)
+}
+
+// attempt sends the delivery and returns it updated with the result of the attempt.
+func (uc DeliverWebhooksUC) attempt(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) entities.WebhookDelivery {
+	status, err := uc.S.Send(ctx, sub, d)
+	now := time.Now()
+
+	d.Attempts++
+	d.ResponseStatus = status
+	if err == nil {
+		d.Status = entities.WebhookDeliverySucceeded
+		d.LastError = ""
+		d.DeliveredAt = &now
+		return d
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= uc.maxAttempts {
+		d.Status = entities.WebhookDeliveryFailed
+		return d
+	}
+
+	d.NextAttemptAt = now.Add(uc.retryDelay(d.Attempts))
+
+	return d
+}
+
+// retryDelay doubles the backoff on every attempt and adds up to 20% of jitter,
+// so the retries of many deliveries to the same receiver are spread over time.
+func (uc DeliverWebhooksUC) retryDelay(attempts int) time.Duration {
+	delay := uc.backoff
+	for i := 1; i < attempts && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxWebhookBackoff)
+
+	return delay + time.Duration(rand.Int64N(int64(delay)/5+1)) //nolint:gosec
+}
@@ -0,0 +1,341 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	thelp "github.com/higordasneves/e-corp/utils/testhelpers"
+)
+
+func TestDeliverWebhooksUC_DeliverWebhooks(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+
+	accounts := []entities.Account{
+		{
+			ID:        uuid.Must(uuid.NewV7()),
+			Name:      "Elliot",
+			Document:  "33344455567",
+			Secret:    "password",
+			Balance:   100,
+			CreatedAt: time.Now().Truncate(time.Second),
+		},
+		{
+			ID:        uuid.Must(uuid.NewV7()),
+			Name:      "Mr.Robot",
+			Document:  "33344455568",
+			Secret:    "password",
+			CreatedAt: time.Now().Truncate(time.Second),
+		},
+	}
+	for _, acc := range accounts {
+		require.NoError(t, r.CreateAccount(ctx, acc))
+	}
+
+	sub, err := usecase.NewCreateWebhookUC(r).CreateWebhook(ctx, usecase.CreateWebhookInput{
+		AccountID:  accounts[1].ID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+	})
+	require.NoError(t, err)
+
+	transfer := entities.Transfer{
+		ID:                   uuid.Must(uuid.NewV7()),
+		AccountOriginID:      accounts[0].ID,
+		AccountDestinationID: accounts[1].ID,
+		Amount:               10,
+		CreatedAt:            time.Now().Truncate(time.Second),
+	}
+	dispatched, err := usecase.NewDispatchWebhooksUC(r).DispatchTransfer(ctx, usecase.DispatchTransferInput{
+		EventID:  transfer.ID.String(),
+		Transfer: transfer,
+	})
+	require.NoError(t, err)
+	require.Len(t, dispatched.Deliveries, 1)
+	assert.Equal(t, sub.Subscription.ID, dispatched.Deliveries[0].SubscriptionID)
+
+	// the event is redelivered by the broker.
+	redispatched, err := usecase.NewDispatchWebhooksUC(r).DispatchTransfer(ctx, usecase.DispatchTransferInput{
+		EventID:  transfer.ID.String(),
+		Transfer: transfer,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, redispatched.Deliveries)
+
+	// the receiver is unavailable on the first attempt.
+	sender := &mocks.WebhookSenderMock{
+		SendFunc: func(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error) {
+			return 503, errors.New("webhook not accepted by the receiver: status code 503")
+		},
+	}
+	uc := usecase.NewDeliverWebhooksUC(r, sender, &config.WebhookConfig{MaxAttempts: 2, Backoff: time.Minute})
+
+	// execute: first attempt
+	got, err := uc.DeliverWebhooks(ctx, usecase.DeliverWebhooksInput{Limit: 10})
+	require.NoError(t, err)
+
+	// assert: the delivery is rescheduled
+	assert.Equal(t, usecase.DeliverWebhooksOutput{Retrying: 1}, got)
+	d, err := r.GetWebhookDelivery(ctx, dispatched.Deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliveryPending, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, 503, d.ResponseStatus)
+	assert.WithinRange(t, d.NextAttemptAt, time.Now().Add(time.Minute-time.Second), time.Now().Add(time.Minute+13*time.Second))
+
+	// execute: the retry is not due yet
+	got, err = uc.DeliverWebhooks(ctx, usecase.DeliverWebhooksInput{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, usecase.DeliverWebhooksOutput{}, got)
+
+	// execute: replay, the receiver is back
+	_, err = usecase.NewReplayWebhookDeliveryUC(r).ReplayWebhookDelivery(ctx, usecase.ReplayWebhookDeliveryInput{
+		AccountID:      accounts[1].ID,
+		SubscriptionID: sub.Subscription.ID,
+		DeliveryID:     d.ID,
+	})
+	require.NoError(t, err)
+	sender.SendFunc = func(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error) {
+		return 200, nil
+	}
+
+	got, err = uc.DeliverWebhooks(ctx, usecase.DeliverWebhooksInput{Limit: 10})
+	require.NoError(t, err)
+
+	// assert
+	assert.Equal(t, usecase.DeliverWebhooksOutput{Succeeded: 1}, got)
+	d, err = r.GetWebhookDelivery(ctx, d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliverySucceeded, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.NotNil(t, d.DeliveredAt)
+	assert.Empty(t, d.LastError)
+}
+
+func TestDeliverWebhooksUC_DeliverWebhooks_MaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	sub, err := usecase.NewCreateWebhookUC(r).CreateWebhook(ctx, usecase.CreateWebhookInput{
+		AccountID:  account.ID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferSent},
+	})
+	require.NoError(t, err)
+
+	delivery := entities.WebhookDelivery{
+		ID:             uuid.Must(uuid.NewV7()),
+		SubscriptionID: sub.Subscription.ID,
+		EventID:        uuid.Must(uuid.NewV7()).String(),
+		EventType:      entities.WebhookEventTransferSent,
+		Payload:        []byte(`{}`),
+		Status:         entities.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now(),
+		CreatedAt:      time.Now().Truncate(time.Second),
+	}
+	_, err = r.CreateWebhookDelivery(ctx, delivery)
+	require.NoError(t, err)
+
+	sender := &mocks.WebhookSenderMock{
+		SendFunc: func(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error) {
+			return 0, errors.New("connection refused")
+		},
+	}
+	uc := usecase.NewDeliverWebhooksUC(r, sender, &config.WebhookConfig{MaxAttempts: 1, Backoff: time.Minute})
+
+	// execute
+	got, err := uc.DeliverWebhooks(ctx, usecase.DeliverWebhooksInput{Limit: 10})
+	require.NoError(t, err)
+
+	// assert
+	assert.Equal(t, usecase.DeliverWebhooksOutput{Failed: 1}, got)
+	d, err := r.GetWebhookDelivery(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliveryFailed, d.Status)
+	assert.Equal(t, "connection refused", d.LastError)
+}
+
+// failingDeliveryRepository fails to record the attempts of a delivery.
+type failingDeliveryRepository struct {
+	memory.Repository
+	failID uuid.UUID
+}
+
+func (r failingDeliveryRepository) UpdateWebhookDelivery(ctx context.Context, d entities.WebhookDelivery) error {
+	if d.ID == r.failID {
+		return errors.New("connection reset")
+	}
+
+	return r.Repository.UpdateWebhookDelivery(ctx, d)
+}
+
+func TestDeliverWebhooksUC_DeliverWebhooks_UpdateFailure(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	sub, err := usecase.NewCreateWebhookUC(r).CreateWebhook(ctx, usecase.CreateWebhookInput{
+		AccountID:  account.ID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferSent},
+	})
+	require.NoError(t, err)
+
+	deliveries := make([]entities.WebhookDelivery, 2)
+	for i := range deliveries {
+		deliveries[i] = entities.WebhookDelivery{
+			ID:             uuid.Must(uuid.NewV7()),
+			SubscriptionID: sub.Subscription.ID,
+			EventID:        uuid.Must(uuid.NewV7()).String(),
+			EventType:      entities.WebhookEventTransferSent,
+			Payload:        []byte(`{}`),
+			Status:         entities.WebhookDeliveryPending,
+			NextAttemptAt:  time.Now().Add(time.Duration(i-2) * time.Second),
+			CreatedAt:      time.Now().Truncate(time.Second),
+		}
+		_, err = r.CreateWebhookDelivery(ctx, deliveries[i])
+		require.NoError(t, err)
+	}
+
+	sender := &mocks.WebhookSenderMock{
+		SendFunc: func(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error) {
+			return 200, nil
+		},
+	}
+	uc := usecase.NewDeliverWebhooksUC(failingDeliveryRepository{Repository: r, failID: deliveries[0].ID}, sender,
+		&config.WebhookConfig{MaxAttempts: 2, Backoff: time.Minute, Timeout: time.Second})
+
+	// execute
+	got, err := uc.DeliverWebhooks(ctx, usecase.DeliverWebhooksInput{Limit: 10})
+
+	// assert: the other delivery is recorded
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, usecase.DeliverWebhooksOutput{Succeeded: 1}, got)
+	assert.Len(t, sender.SendCalls(), 2)
+
+	d, err := r.GetWebhookDelivery(ctx, deliveries[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliverySucceeded, d.Status)
+
+	// assert: the delivery that wasn't recorded is kept pending until the end of its lease
+	d, err = r.GetWebhookDelivery(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliveryPending, d.Status)
+	assert.True(t, d.NextAttemptAt.After(time.Now()))
+
+	got, err = uc.DeliverWebhooks(ctx, usecase.DeliverWebhooksInput{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, usecase.DeliverWebhooksOutput{}, got)
+}
+
+// attemptedDeliveryRepository records a successful attempt of the deliveries right after they are read,
+// like a worker delivering them concurrently.
+type attemptedDeliveryRepository struct {
+	memory.Repository
+}
+
+func (r attemptedDeliveryRepository) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (entities.WebhookDelivery, error) {
+	d, err := r.Repository.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		return d, err
+	}
+
+	attempted := d
+	deliveredAt := time.Now().Truncate(time.Second)
+	attempted.Status = entities.WebhookDeliverySucceeded
+	attempted.Attempts++
+	attempted.DeliveredAt = &deliveredAt
+
+	return d, r.Repository.UpdateWebhookDelivery(ctx, attempted)
+}
+
+func TestReplayWebhookDeliveryUC_ReplayWebhookDelivery_Attempted(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := thelp.NewCtx(t)
+	r := memory.NewRepository()
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	sub, err := usecase.NewCreateWebhookUC(r).CreateWebhook(ctx, usecase.CreateWebhookInput{
+		AccountID:  account.ID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferSent},
+	})
+	require.NoError(t, err)
+
+	delivery := entities.WebhookDelivery{
+		ID:             uuid.Must(uuid.NewV7()),
+		SubscriptionID: sub.Subscription.ID,
+		EventID:        uuid.Must(uuid.NewV7()).String(),
+		EventType:      entities.WebhookEventTransferSent,
+		Payload:        []byte(`{}`),
+		Status:         entities.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now(),
+		CreatedAt:      time.Now().Truncate(time.Second),
+	}
+	_, err = r.CreateWebhookDelivery(ctx, delivery)
+	require.NoError(t, err)
+
+	uc := usecase.NewReplayWebhookDeliveryUC(attemptedDeliveryRepository{Repository: r})
+
+	// execute
+	_, err = uc.ReplayWebhookDelivery(ctx, usecase.ReplayWebhookDeliveryInput{
+		AccountID:      account.ID,
+		SubscriptionID: sub.Subscription.ID,
+		DeliveryID:     delivery.ID,
+	})
+
+	// assert: the attempt recorded meanwhile is kept
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	d, err := r.GetWebhookDelivery(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliverySucceeded, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type ListWebhookDeliveriesUCRepository interface {
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (entities.WebhookSubscription, error)
+	ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, pageSize int) ([]entities.WebhookDelivery, error)
+}
+
+type ListWebhookDeliveriesUC struct {
+	R ListWebhookDeliveriesUCRepository
+}
+
+func NewListWebhookDeliveriesUC(r ListWebhookDeliveriesUCRepository) ListWebhookDeliveriesUC {
+	return ListWebhookDeliveriesUC{R: r}
+}
+
+type ListWebhookDeliveriesInput struct {
+	AccountID      uuid.UUID
+	SubscriptionID uuid.UUID
+	// PageSize is the limit (quantity) of the most recent deliveries listed.
+	PageSize int
+}
+
+type ListWebhookDeliveriesOutput struct {
+	Deliveries []entities.WebhookDelivery
+}
+
+// ListWebhookDeliveries lists the most recent deliveries of a webhook subscription in desc order.
+// Returns domain.ErrNotFound if the account doesn't have the subscription.
+func (uc ListWebhookDeliveriesUC) ListWebhookDeliveries(ctx context.Context, input ListWebhookDeliveriesInput) (ListWebhookDeliveriesOutput, error) {
+	sub, err := uc.R.GetWebhookSubscription(ctx, input.SubscriptionID)
+	if err != nil {
+		return ListWebhookDeliveriesOutput{}, fmt.Errorf("getting webhook subscription: %w", err)
+	}
+
+	if sub.AccountID != input.AccountID {
+		return ListWebhookDeliveriesOutput{}, domain.NewError(domain.ErrNotFound, domain.CodeWebhookNotFound, "webhook %s not exists", input.SubscriptionID)
+	}
+
+	deliveries, err := uc.R.ListWebhookDeliveries(ctx, input.SubscriptionID, input.PageSize)
+	if err != nil {
+		return ListWebhookDeliveriesOutput{}, fmt.Errorf("listing webhook deliveries: %w", err)
+	}
+
+	return ListWebhookDeliveriesOutput{Deliveries: deliveries}, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type ReplayWebhookDeliveryUCRepository interface {
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (entities.WebhookSubscription, error)
+	GetWebhookDelivery(ctx context.Context, id uuid.UUID) (entities.WebhookDelivery, error)
+	// ReplayWebhookDelivery schedules a new attempt of the delivery unless it changed since d was read.
+	// Returns domain.ErrConflict if it changed.
+	ReplayWebhookDelivery(ctx context.Context, d entities.WebhookDelivery, nextAttemptAt time.Time) error
+}
+
+type ReplayWebhookDeliveryUC struct {
+	R ReplayWebhookDeliveryUCRepository
+}
+
+func NewReplayWebhookDeliveryUC(r ReplayWebhookDeliveryUCRepository) ReplayWebhookDeliveryUC {
+	return ReplayWebhookDeliveryUC{R: r}
+}
+
+type ReplayWebhookDeliveryInput struct {
+	AccountID      uuid.UUID
+	SubscriptionID uuid.UUID
+	DeliveryID     uuid.UUID
+}
+
+type ReplayWebhookDeliveryOutput struct {
+	Delivery entities.WebhookDelivery
+}
+
+// ReplayWebhookDelivery schedules a new delivery of the event, regardless of the previous attempts.
+// The attempts counter is restarted, so the delivery is retried as a new one.
+// Returns domain.ErrNotFound if the account doesn't have the subscription or the delivery doesn't belong to it.
+// Returns domain.ErrConflict if the delivery is attempted meanwhile, so the attempt isn't overwritten.
+func (uc ReplayWebhookDeliveryUC) ReplayWebhookDelivery(ctx context.Context, input ReplayWebhookDeliveryInput) (ReplayWebhookDeliveryOutput, error) {
+	sub, err := uc.R.GetWebhookSubscription(ctx, input.SubscriptionID)
+	if err != nil {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("getting webhook subscription: %w", err)
+	}
+
+	if sub.AccountID != input.AccountID {
+		return ReplayWebhookDeliveryOutput{}, domain.NewError(domain.ErrNotFound, domain.CodeWebhookNotFound, "webhook %s not exists", input.SubscriptionID)
+	}
+
+	delivery, err := uc.R.GetWebhookDelivery(ctx, input.DeliveryID)
+	if err != nil {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("getting webhook delivery: %w", err)
+	}
+
+	if delivery.SubscriptionID != sub.ID {
+		return ReplayWebhookDeliveryOutput{}, domain.NewError(domain.ErrNotFound, domain.CodeWebhookDeliveryNotFound, "webhook delivery %s not exists", input.DeliveryID)
+	}
+
+	now := time.Now()
+	err = uc.R.ReplayWebhookDelivery(ctx, delivery, now)
+	if err != nil {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("replaying webhook delivery: %w", err)
+	}
+
+	delivery.Status = entities.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+
+	return ReplayWebhookDeliveryOutput{Delivery: delivery}, nil
+}
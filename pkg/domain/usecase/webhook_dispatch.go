@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type DispatchWebhooksUCRepository interface {
+	ListWebhookSubscriptionsByEvent(ctx context.Context, accountID uuid.UUID, eventType entities.WebhookEventType) ([]entities.WebhookSubscription, error)
+	// CreateWebhookDelivery reports false if the event was already scheduled to the subscription.
+	CreateWebhookDelivery(ctx context.Context, d entities.WebhookDelivery) (bool, error)
+}
+
+type DispatchWebhooksUC struct {
+	R DispatchWebhooksUCRepository
+}
+
+func NewDispatchWebhooksUC(r DispatchWebhooksUCRepository) DispatchWebhooksUC {
+	return DispatchWebhooksUC{R: r}
+}
+
+// WebhookEvent is the payload sent to the webhook subscriptions.
+type WebhookEvent struct {
+	// ID identifies the event. Retries and replays of a delivery keep the same id.
+	ID        string                    `json:"id"`
+	Type      entities.WebhookEventType `json:"type"`
+	CreatedAt time.Time                 `json:"created_at"`
+	Data      any                       `json:"data"`
+}
+
+type DispatchTransferInput struct {
+	// EventID identifies the transfer creation event.
+	EventID  string
+	Transfer entities.Transfer
+}
+
+type DispatchTransferOutput struct {
+	// Deliveries are the deliveries scheduled, without the ones of a redelivered event.
+	Deliveries []entities.WebhookDelivery
+}
+
+// DispatchTransfer schedules the delivery of the transfer to the webhooks subscribed by the accounts involved.
+// The origin account is notified with a transfer.sent event and the destination account with a transfer.received event.
+// The event is scheduled once to each subscription, even if it's dispatched again.
+func (uc DispatchWebhooksUC) DispatchTransfer(ctx context.Context, input DispatchTransferInput) (DispatchTransferOutput, error) {
+	targets := []struct {
+		accountID uuid.UUID
+		eventType entities.WebhookEventType
+	}{
+		{input.Transfer.AccountOriginID, entities.WebhookEventTransferSent},
+		{input.Transfer.AccountDestinationID, entities.WebhookEventTransferReceived},
+	}
+
+	now := time.Now()
+
+	var deliveries []entities.WebhookDelivery
+	for _, target := range targets {
+		subs, err := uc.R.ListWebhookSubscriptionsByEvent(ctx, target.accountID, target.eventType)
+		if err != nil {
+			return DispatchTransferOutput{}, fmt.Errorf("listing webhook subscriptions: %w", err)
+		}
+
+		if len(subs) == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(WebhookEvent{
+			ID:        input.EventID,
+			Type:      target.eventType,
+			CreatedAt: input.Transfer.CreatedAt,
+			Data:      input.Transfer,
+		})
+		if err != nil {
+			return DispatchTransferOutput{}, fmt.Errorf("marshaling webhook event: %w", err)
+		}
+
+		for _, sub := range subs {
+			d := entities.WebhookDelivery{
+				ID:             uuid.Must(uuid.NewV7()),
+				SubscriptionID: sub.ID,
+				EventID:        input.EventID,
+				EventType:      target.eventType,
+				Payload:        payload,
+				Status:         entities.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now.Truncate(time.Second),
+			}
+
+			inserted, err := uc.R.CreateWebhookDelivery(ctx, d)
+			if err != nil {
+				return DispatchTransferOutput{}, fmt.Errorf("creating webhook delivery: %w", err)
+			}
+
+			if inserted {
+				deliveries = append(deliveries, d)
+			}
+		}
+	}
+
+	return DispatchTransferOutput{Deliveries: deliveries}, nil
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+type ListWebhooksUCRepository interface {
+	ListWebhookSubscriptions(ctx context.Context, accountID uuid.UUID) ([]entities.WebhookSubscription, error)
+}
+
+type ListWebhooksUC struct {
+	R ListWebhooksUCRepository
+}
+
+func NewListWebhooksUC(r ListWebhooksUCRepository) ListWebhooksUC {
+	return ListWebhooksUC{R: r}
+}
+
+type ListWebhooksInput struct {
+	AccountID uuid.UUID
+}
+
+type ListWebhooksOutput struct {
+	Subscriptions []entities.WebhookSubscription
+}
+
+// ListWebhooks lists the webhook subscriptions of the account in desc order.
+func (uc ListWebhooksUC) ListWebhooks(ctx context.Context, input ListWebhooksInput) (ListWebhooksOutput, error) {
+	subs, err := uc.R.ListWebhookSubscriptions(ctx, input.AccountID)
+	if err != nil {
+		return ListWebhooksOutput{}, fmt.Errorf("listing webhook subscriptions: %w", err)
+	}
+
+	return ListWebhooksOutput{Subscriptions: subs}, nil
+}
@@ -9,10 +9,19 @@ import (
 )
 
 type Config struct {
-	Auth AuthConfig
-	DB   DatabaseConfig
-	HTTP HTTP
-	MQ   RabbitMQConfig
+	Auth    AuthConfig
+	DB      DatabaseConfig
+	HTTP    HTTP
+	GRPC    GRPCConfig
+	MQ      RabbitMQConfig
+	Webhook WebhookConfig
+	Metrics MetricsConfig
+	Tracing TracingConfig
+	Health  HealthConfig
+	Stream  StreamConfig
+	Import  ImportConfig
+
+	Notification NotificationConfig
 }
 
 type AuthConfig struct {
@@ -28,14 +37,130 @@ type DatabaseConfig struct {
 	Password string `env:"DB_PASSWORD" env-default:"123456"`
 	Port     string `env:"DB_PORT" env-default:"5432"`
 	SSLMode  string `env:"DB_SSL_MODE" env-default:"disable"`
+	// AutoMigrate applies the pending migrations when the API starts.
+	// Disable it to run the migrations with the migrate command, e.g. as a deploy step.
+	AutoMigrate bool `env:"DB_AUTO_MIGRATE" env-default:"true"`
+	// TxMaxAttempts is the number of times a transaction is run when it fails on a serialization failure or a deadlock.
+	TxMaxAttempts int `env:"DB_TX_MAX_ATTEMPTS" env-default:"5"`
+	// TxRetryBaseDelay and TxRetryMaxDelay bound the jittered exponential backoff between the attempts.
+	TxRetryBaseDelay time.Duration `env:"DB_TX_RETRY_BASE_DELAY" env-default:"10ms"`
+	TxRetryMaxDelay  time.Duration `env:"DB_TX_RETRY_MAX_DELAY" env-default:"500ms"`
+	// ReplicaDSNs are the connection strings of the read replicas.
+	// The selects outside transactions run on them, the writes and transactions always run on the primary.
+	// Their user needs the pg_monitor role, to check the replicas are streaming from the primary.
+	ReplicaDSNs []string `env:"DB_REPLICA_DSNS" env-separator:","`
+	// ReplicaMaxLag is the replication lag from which a replica stops taking reads until it catches up.
+	ReplicaMaxLag time.Duration `env:"DB_REPLICA_MAX_LAG" env-default:"5s"`
+	// ReplicaLagCheckInterval is the interval between the checks of the lag of the replicas.
+	ReplicaLagCheckInterval time.Duration `env:"DB_REPLICA_LAG_CHECK_INTERVAL" env-default:"1s"`
+	// PartitionMonthsAhead is the number of months after the current one whose transfer partitions are created
+	// in advance by the maintenance job, which runs on every PartitionCheckInterval.
+	PartitionMonthsAhead   int           `env:"DB_PARTITION_MONTHS_AHEAD" env-default:"3"`
+	PartitionCheckInterval time.Duration `env:"DB_PARTITION_CHECK_INTERVAL" env-default:"6h"`
 }
 
 type HTTP struct {
 	Address string `env:"HTTP_ADDR" env-default:"0.0.0.0"`
 	Port    string `env:"HTTP_PORT" env-default:"8080"`
+
+	ReadTimeout       time.Duration `env:"HTTP_READ_TIMEOUT" env-default:"30s"`
+	ReadHeaderTimeout time.Duration `env:"HTTP_READ_HEADER_TIMEOUT" env-default:"10s"`
+	WriteTimeout      time.Duration `env:"HTTP_WRITE_TIMEOUT" env-default:"30s"`
+	IdleTimeout       time.Duration `env:"HTTP_IDLE_TIMEOUT" env-default:"120s"`
+
+	// MaxBodyBytes is the maximum size of the request bodies. Zero disables the limit.
+	MaxBodyBytes int64 `env:"HTTP_MAX_BODY_BYTES" env-default:"1048576"`
+
+	// TLSCertFile and TLSKeyFile enable HTTPS when both are set.
+	TLSCertFile string `env:"HTTP_TLS_CERT_FILE"`
+	TLSKeyFile  string `env:"HTTP_TLS_KEY_FILE"`
+	// HSTSMaxAge is the max-age of the Strict-Transport-Security header, sent only over TLS.
+	HSTSMaxAge time.Duration `env:"HTTP_HSTS_MAX_AGE" env-default:"8760h"`
+
+	// CORSAllowedOrigins lists the origins allowed to call the API from a browser. Empty disables CORS.
+	CORSAllowedOrigins []string      `env:"HTTP_CORS_ALLOWED_ORIGINS" env-separator:","`
+	CORSAllowedMethods []string      `env:"HTTP_CORS_ALLOWED_METHODS" env-separator:"," env-default:"GET,POST,PUT,PATCH,DELETE"`
+	CORSAllowedHeaders []string      `env:"HTTP_CORS_ALLOWED_HEADERS" env-separator:"," env-default:"Authorization,Content-Type,If-Match,X-Request-Id"`
+	CORSMaxAge         time.Duration `env:"HTTP_CORS_MAX_AGE" env-default:"10m"`
+
+	// IPRateLimit is the rate of requests per second allowed for each client IP, with bursts of IPRateBurst.
+	// Zero disables the limit.
+	IPRateLimit float64 `env:"HTTP_IP_RATE_LIMIT" env-default:"50"`
+	IPRateBurst int     `env:"HTTP_IP_RATE_BURST" env-default:"100"`
+	// AccountRateLimit is the rate of requests per second allowed for each authenticated account,
+	// with bursts of AccountRateBurst. Zero disables the limit.
+	AccountRateLimit float64 `env:"HTTP_ACCOUNT_RATE_LIMIT" env-default:"10"`
+	AccountRateBurst int     `env:"HTTP_ACCOUNT_RATE_BURST" env-default:"20"`
+	// TrustProxyHeaders identifies the clients by the X-Forwarded-For header set by the reverse proxy.
+	// Must only be enabled behind a proxy, otherwise clients can spoof their IP.
+	TrustProxyHeaders bool `env:"HTTP_TRUST_PROXY_HEADERS" env-default:"false"`
+	// ValidateResponses validates the responses against the OpenAPI spec and logs the mismatches.
+	// The responses are buffered to be validated, so it's meant for development and tests.
+	ValidateResponses bool `env:"HTTP_VALIDATE_RESPONSES" env-default:"false"`
+}
+
+// TLS reports whether the server must serve HTTPS.
+func (h HTTP) TLS() bool {
+	return h.TLSCertFile != "" && h.TLSKeyFile != ""
+}
+
+type GRPCConfig struct {
+	// Address and Port of the gRPC server. It runs along with the HTTP server on its own port,
+	// with the TLS certificate and the rate limits of the HTTP server.
+	Address string `env:"GRPC_ADDR" env-default:"0.0.0.0"`
+	Port    string `env:"GRPC_PORT" env-default:"50051"`
+}
+
+type MetricsConfig struct {
+	// Address and Port of the server exposing the /metrics endpoint.
+	// Each binary runs its own server, so processes on the same host need different ports.
+	Address string `env:"METRICS_ADDR" env-default:"0.0.0.0"`
+	Port    string `env:"METRICS_PORT" env-default:"9090"`
+}
+
+type HealthConfig struct {
+	// Timeout is the maximum duration of the readiness checks.
+	Timeout time.Duration `env:"HEALTH_TIMEOUT" env-default:"2s"`
+	// ShutdownDelay is how long the readiness fails before the HTTP server stops,
+	// giving the load balancers time to remove the instance.
+	ShutdownDelay time.Duration `env:"HEALTH_SHUTDOWN_DELAY" env-default:"5s"`
+}
+
+type StreamConfig struct {
+	// Heartbeat is the interval of the comments sent on idle event streams, so proxies don't close them.
+	Heartbeat time.Duration `env:"STREAM_HEARTBEAT" env-default:"15s"`
+	// BufferSize is the number of events buffered per stream. Streams that fall further behind are closed
+	// and the clients resume from the last event received.
+	BufferSize int `env:"STREAM_BUFFER_SIZE" env-default:"32"`
+}
+
+type ImportConfig struct {
+	// Workers is the number of rows of an account import validated at the same time, each hashing a secret.
+	// Zero uses the number of CPUs.
+	Workers int `env:"IMPORT_WORKERS" env-default:"0"`
+	// MaxRows is the maximum number of rows of an account import. Zero disables the limit.
+	MaxRows int `env:"IMPORT_MAX_ROWS" env-default:"10000"`
+	// MaxBodyBytes is the maximum size of the files imported through the API, instead of HTTP_MAX_BODY_BYTES.
+	// Zero disables the limit.
+	MaxBodyBytes int64 `env:"IMPORT_MAX_BODY_BYTES" env-default:"4194304"`
+}
+
+type TracingConfig struct {
+	// Exporter selects where the spans are sent: otlp, stdout or none.
+	// With none the spans are still created, so the trace ids reach the logs and the propagated headers.
+	Exporter string `env:"TRACING_EXPORTER" env-default:"none"`
+	// OTLPEndpoint is the address of the OTLP gRPC collector.
+	OTLPEndpoint string `env:"TRACING_OTLP_ENDPOINT" env-default:"localhost:4317"`
+	// OTLPInsecure disables the TLS of the connection with the collector.
+	OTLPInsecure bool `env:"TRACING_OTLP_INSECURE" env-default:"true"`
+	// SampleRatio is the fraction of the traces sampled when the parent span doesn't decide it.
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" env-default:"1"`
 }
 
 type RabbitMQConfig struct {
+	// Driver selects the broker implementation, rabbitmq or memory.
+	// The memory broker doesn't deliver messages to other processes, so the API runs the consumers along with it.
+	Driver   string `env:"BROKER_DRIVER" env-default:"rabbitmq"`
 	Host     string `env:"RABBITMQ_HOST" env-default:"localhost"`
 	User     string `env:"RABBITMQ_USER" env-default:"guest"`
 	Password string `env:"RABBITMQ_PASSWORD" env-default:"guest"`
@@ -43,6 +168,55 @@ type RabbitMQConfig struct {
 	Exchange string `env:"RABBITMQ_EXCHANGE" env-default:"ecorp"`
 	Queue    string `env:"RABBITMQ_QUEUE" env-default:"ecorp.stream.accountCreation"`
 	Bind     string `env:"RABBITMQ_BIND" env-default:"ecorp.accountCreation"`
+	// TransferBind is the routing key of the transfer creation events.
+	TransferBind string `env:"RABBITMQ_TRANSFER_BIND" env-default:"ecorp.transferCreation"`
+	// WebhookQueue is the queue consumed by the webhook delivery worker.
+	WebhookQueue string `env:"RABBITMQ_WEBHOOK_QUEUE" env-default:"ecorp.stream.webhooks"`
+	// NotificationQueue is the queue consumed by the notification service.
+	NotificationQueue string `env:"RABBITMQ_NOTIFICATION_QUEUE" env-default:"ecorp.stream.notifications"`
+	// RetryDelay is the delay before a message nacked with requeue is delivered again.
+	RetryDelay time.Duration `env:"RABBITMQ_RETRY_DELAY" env-default:"10s"`
+	// MaxRetries is the number of times a message nacked with requeue is delivered again
+	// before it is moved to the dead-letter queue.
+	MaxRetries int `env:"RABBITMQ_MAX_RETRIES" env-default:"5"`
+}
+
+type WebhookConfig struct {
+	// Timeout is the maximum duration of a delivery request.
+	Timeout time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+	// PollInterval is the interval between the checks for pending deliveries.
+	PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" env-default:"1s"`
+	// MaxAttempts is the number of attempts before a delivery is marked as failed.
+	MaxAttempts int `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+	// Backoff is the delay before the first retry. It doubles on every failed attempt.
+	Backoff time.Duration `env:"WEBHOOK_BACKOFF" env-default:"30s"`
+}
+
+type NotificationConfig struct {
+	// Driver selects how the notifications are sent: log writes them to a file, live sends them through the providers.
+	Driver string `env:"NOTIFICATION_DRIVER" env-default:"log"`
+	// LogFile is the file where the log driver appends the notifications. If empty, they are written to the logger.
+	LogFile string `env:"NOTIFICATION_LOG_FILE"`
+	// Timeout is the maximum duration of a request to a provider.
+	Timeout time.Duration `env:"NOTIFICATION_TIMEOUT" env-default:"10s"`
+	// DefaultLocale is the locale of the accounts without notification preferences.
+	DefaultLocale string `env:"NOTIFICATION_DEFAULT_LOCALE" env-default:"pt-BR"`
+	// LargeTransferAmount is the default amount from which an outgoing transfer is notified.
+	LargeTransferAmount int `env:"NOTIFICATION_LARGE_TRANSFER_AMOUNT" env-default:"100000"`
+
+	SMTPHost     string `env:"SMTP_HOST" env-default:"localhost"`
+	SMTPPort     string `env:"SMTP_PORT" env-default:"587"`
+	SMTPUser     string `env:"SMTP_USER"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	SMTPFrom     string `env:"SMTP_FROM" env-default:"no-reply@ecorp.com"`
+
+	// SMSURL is the endpoint of the SMS provider API.
+	SMSURL   string `env:"SMS_URL"`
+	SMSToken string `env:"SMS_TOKEN"`
+
+	// PushURL is the endpoint of the push provider API. The devices are targeted by the account id.
+	PushURL   string `env:"PUSH_URL"`
+	PushToken string `env:"PUSH_TOKEN"`
 }
 
 // LoadEnv loads environment variables into a DatabaseConfig struct.
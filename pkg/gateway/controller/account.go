@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
 )
 
@@ -15,8 +16,11 @@ type AccountController struct {
 
 type AccountUseCase interface {
 	CreateAccount(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error)
+	ImportAccounts(ctx context.Context, input usecase.ImportAccountsInput) (usecase.ImportAccountsOutput, error)
 	GetBalance(ctx context.Context, id uuid.UUID) (int, error)
 	ListAccounts(ctx context.Context, input usecase.ListAccountsInput) (usecase.ListAccountsOutput, error)
+	GetAccount(ctx context.Context, id uuid.UUID) (entities.Account, error)
+	UpdateAccount(ctx context.Context, input usecase.UpdateAccountInput) (usecase.UpdateAccountOutput, error)
 }
 
 func NewAccountController(accUseCase AccountUseCase) AccountController {
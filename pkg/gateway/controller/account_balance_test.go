@@ -67,11 +67,11 @@ func TestAccountController_GetBalance(t *testing.T) {
 			fields: fields{
 				accUseCase: &mocks.AccountUseCaseMock{
 					GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
-						return 0, domain.ErrNotFound
+						return 0, domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account not exists")
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrNotFound),
+			want:         `{"type":"urn:ecorp:problem:account_not_found","title":"Not Found","status":404,"detail":"account not exists","code":"account_not_found"}`,
 			expectedCode: 404,
 		},
 	}
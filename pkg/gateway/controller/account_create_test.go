@@ -3,7 +3,6 @@ package controller_test
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -60,17 +59,17 @@ func TestAccountController_CreateAccount(t *testing.T) {
 			expectedCode: http.StatusCreated,
 		},
 		{
-			name:        "when account already exists should return error and status code 400",
+			name:        "when account already exists should return error and status code 409",
 			requestBody: bytes.NewReader([]byte(`{"name":"Elliot", "document":"44455566678", "secret":"12345678"}`)),
 			fields: fields{
 				accUseCase: &mocks.AccountUseCaseMock{
 					CreateAccountFunc: func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error) {
-						return usecase.CreateAccountOutput{}, domain.ErrInvalidParameter
+						return usecase.CreateAccountOutput{}, domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account with document 44455566678 already exists")
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
-			expectedCode: http.StatusBadRequest,
+			want:         `{"type":"urn:ecorp:problem:account_already_exists","title":"Conflict","status":409,"detail":"account with document 44455566678 already exists","code":"account_already_exists"}`,
+			expectedCode: http.StatusConflict,
 		},
 		{
 			name:        "invalid cpf length should return error and status code 400",
@@ -78,11 +77,11 @@ func TestAccountController_CreateAccount(t *testing.T) {
 			fields: fields{
 				accUseCase: &mocks.AccountUseCaseMock{
 					CreateAccountFunc: func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error) {
-						return usecase.CreateAccountOutput{}, domain.ErrInvalidParameter
+						return usecase.CreateAccountOutput{}, domain.NewFieldError("document", domain.CodeDocumentInvalid, vos.ErrDocumentLen.Error())
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"document","code":"document_invalid","message":"the document must have 11 or 14 characters"}]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -91,11 +90,11 @@ func TestAccountController_CreateAccount(t *testing.T) {
 			fields: fields{
 				accUseCase: &mocks.AccountUseCaseMock{
 					CreateAccountFunc: func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error) {
-						return usecase.CreateAccountOutput{}, domain.ErrInvalidParameter
+						return usecase.CreateAccountOutput{}, domain.NewFieldError("document", domain.CodeDocumentInvalid, vos.ErrDocumentFormat.Error())
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"document","code":"document_invalid","message":"the document must contain only numbers"}]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -104,11 +103,11 @@ func TestAccountController_CreateAccount(t *testing.T) {
 			fields: fields{
 				accUseCase: &mocks.AccountUseCaseMock{
 					CreateAccountFunc: func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error) {
-						return usecase.CreateAccountOutput{}, domain.ErrInvalidParameter
+						return usecase.CreateAccountOutput{}, domain.NewFieldError("secret", domain.CodeSecretTooShort, vos.ErrSmallSecret.Error())
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"secret","code":"secret_too_short","message":"the password must be at least 8 characters long"}]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -117,11 +116,11 @@ func TestAccountController_CreateAccount(t *testing.T) {
 			fields: fields{
 				accUseCase: &mocks.AccountUseCaseMock{
 					CreateAccountFunc: func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error) {
-						return usecase.CreateAccountOutput{}, domain.ErrInvalidParameter
+						return usecase.CreateAccountOutput{}, domain.NewFieldError("name", domain.CodeRequired, "required field")
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"name","code":"required","message":"required field"}]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 	}
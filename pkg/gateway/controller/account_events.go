@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+//go:generate moq -stub -pkg mocks -out mocks/account_events_uc.go . AccountEventsUseCase EventSubscriber
+
+const (
+	// EventTransferSent and EventTransferReceived are the names of the stream events of the transfers
+	// sent and received by the account.
+	EventTransferSent     = "transfer.sent"
+	EventTransferReceived = "transfer.received"
+	// EventBalanceUpdated is the name of the stream event with the current balance of the account.
+	EventBalanceUpdated = "balance.updated"
+
+	// streamReplayPageSize is the page size of the events replayed from the Last-Event-ID.
+	streamReplayPageSize = 100
+	// streamRetry is the reconnection delay advised to the clients.
+	streamRetry = 3 * time.Second
+)
+
+type AccountEventsUseCase interface {
+	ListAccountEvents(ctx context.Context, input usecase.ListAccountEventsInput) (usecase.ListAccountEventsOutput, error)
+	GetBalance(ctx context.Context, id uuid.UUID) (int, error)
+}
+
+// EventSubscriber delivers the events of an account as they are committed.
+// The channel is closed when the subscriber must resume from the event store.
+type EventSubscriber interface {
+	Subscribe(accountID uuid.UUID) (<-chan entities.AccountEvent, func())
+}
+
+type AccountEventsController struct {
+	eventsUseCase AccountEventsUseCase
+	subscriber    EventSubscriber
+	heartbeat     time.Duration
+}
+
+func NewAccountEventsController(eventsUseCase AccountEventsUseCase, subscriber EventSubscriber, heartbeat time.Duration) AccountEventsController {
+	return AccountEventsController{
+		eventsUseCase: eventsUseCase,
+		subscriber:    subscriber,
+		heartbeat:     heartbeat,
+	}
+}
+
+// StreamAccountEvents streams the activity of the account as server-sent events.
+// The transfers are sent as transfer.sent or transfer.received events with their sequence in the stream of the account
+// as id, each followed by a balance.updated event with the balance of the account when the event was appended.
+// The stream starts with the current balance.
+// Returns not found error if the account not exists.
+func (c AccountEventsController) StreamAccountEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID, err := uuid.FromString(fmt.Sprint(ctx.Value("subject")))
+	if err != nil {
+		HandleError(ctx, w, fmt.Errorf("unexpected error when parsing the account id: %w", err))
+		return
+	}
+
+	var lastSequence int64
+	if h := r.Header.Get("Last-Event-ID"); h != "" {
+		lastSequence, err = strconv.ParseInt(h, 10, 64)
+		if err != nil || lastSequence < 0 {
+			HandleError(ctx, w, domain.NewFieldError("Last-Event-ID", domain.CodeInvalid, "invalid event id"))
+			return
+		}
+	}
+
+	// subscribing before the replay, so the events published meanwhile are not lost.
+	events, unsubscribe := c.subscriber.Subscribe(accountID)
+	defer unsubscribe()
+
+	var replay []entities.AccountEvent
+	if lastSequence > 0 {
+		replay, err = c.listEvents(ctx, accountID, lastSequence)
+		if err != nil {
+			HandleError(ctx, w, err)
+			return
+		}
+	}
+
+	// the last event replayed carries the current balance, as the balance only changes with the transfers.
+	var balance int
+	if len(replay) > 0 {
+		balance = replay[len(replay)-1].Balance
+	} else {
+		balance, err = c.eventsUseCase.GetBalance(ctx, accountID)
+		if err != nil {
+			HandleError(ctx, w, err)
+			return
+		}
+	}
+
+	// the stream outlives the write timeout of the server.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logger.Error(ctx, "disabling the write deadline", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	s := eventStream{ctx: ctx, w: w, rc: rc}
+	s.retry(streamRetry)
+
+	for _, e := range replay {
+		s.transfer(e)
+		lastSequence = e.Sequence
+	}
+	s.event("", EventBalanceUpdated, GetBalanceResponse{Balance: balance})
+
+	if err := s.flush(); err != nil {
+		return
+	}
+
+	var heartbeat <-chan time.Time
+	if c.heartbeat > 0 {
+		ticker := time.NewTicker(c.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat:
+			s.comment("heartbeat")
+		case e, ok := <-events:
+			if !ok {
+				// the client reconnects and resumes from the last event received.
+				return
+			}
+			// the events already replayed are skipped, the events of the account are delivered in the order
+			// of their sequence.
+			if e.Sequence <= lastSequence {
+				continue
+			}
+			lastSequence = e.Sequence
+
+			s.transfer(e)
+			s.event("", EventBalanceUpdated, GetBalanceResponse{Balance: e.Balance})
+		}
+
+		if err := s.flush(); err != nil {
+			return
+		}
+	}
+}
+
+// listEvents lists all the events of the account after the event at the position afterSequence.
+func (c AccountEventsController) listEvents(ctx context.Context, accountID uuid.UUID, afterSequence int64) ([]entities.AccountEvent, error) {
+	var events []entities.AccountEvent
+	for {
+		output, err := c.eventsUseCase.ListAccountEvents(ctx, usecase.ListAccountEventsInput{
+			AccountID:     accountID,
+			AfterSequence: afterSequence,
+			PageSize:      streamReplayPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, output.Events...)
+		if len(output.Events) < streamReplayPageSize {
+			return events, nil
+		}
+		afterSequence = output.Events[len(output.Events)-1].Sequence
+	}
+}
+
+// eventStream writes server-sent events. The first write error is kept and returned by flush,
+// as it means the client is gone.
+type eventStream struct {
+	ctx context.Context
+	w   http.ResponseWriter
+	rc  *http.ResponseController
+	err error
+}
+
+func (s *eventStream) write(format string, args ...any) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = fmt.Fprintf(s.w, format, args...)
+}
+
+func (s *eventStream) retry(d time.Duration) {
+	s.write("retry: %d\n\n", d.Milliseconds())
+}
+
+func (s *eventStream) comment(text string) {
+	s.write(": %s\n\n", text)
+}
+
+// event writes an event with the data encoded as JSON. The id is omitted if empty,
+// so the client keeps the id of the last event it can resume from.
+func (s *eventStream) event(id, name string, data any) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		logger.Error(s.ctx, "marshalling event data", zap.String("event", name), zap.Error(err))
+		return
+	}
+
+	if id != "" {
+		s.write("id: %s\n", id)
+	}
+	s.write("event: %s\ndata: %s\n\n", name, b)
+}
+
+// transfer writes the transfer event of the account. Events with invalid payloads are logged and skipped.
+func (s *eventStream) transfer(e entities.AccountEvent) {
+	var t entities.Transfer
+	if err := json.Unmarshal(e.Event.Payload, &t); err != nil {
+		logger.Error(s.ctx, "unmarshalling transfer event", zap.Stringer("event_id", e.Event.ID), zap.Error(err))
+		return
+	}
+
+	name := EventTransferReceived
+	if t.AccountOriginID == e.AccountID {
+		name = EventTransferSent
+	}
+
+	s.event(strconv.FormatInt(e.Sequence, 10), name, TransferEventResponse(t))
+}
+
+func (s *eventStream) flush() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.rc.Flush()
+}
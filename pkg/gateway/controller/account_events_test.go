@@ -0,0 +1,249 @@
+package controller_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/server"
+)
+
+var (
+	streamAccountID = uuid.FromStringOrNil("0457c690-f884-4d57-810c-85cf09a50d8b")
+	streamOtherID   = uuid.FromStringOrNil("9751fe39-976f-4b3d-9611-d6c8c6370b0f")
+)
+
+func transferEvent(t *testing.T, id string, sequence int64, origin, destination uuid.UUID, amount, balance int) entities.AccountEvent {
+	t.Helper()
+
+	payload, err := json.Marshal(entities.Transfer{
+		ID:                   uuid.FromStringOrNil(id),
+		AccountOriginID:      origin,
+		AccountDestinationID: destination,
+		Amount:               amount,
+		CreatedAt:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	return entities.AccountEvent{
+		AccountID: streamAccountID,
+		Sequence:  sequence,
+		Balance:   balance,
+		Event: entities.Event{
+			ID:            uuid.FromStringOrNil(id),
+			Sequence:      sequence + 1000,
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   uuid.FromStringOrNil(id),
+			Payload:       payload,
+		},
+	}
+}
+
+func streamToken(t *testing.T) string {
+	t.Helper()
+
+	now := time.Now()
+	token, err := controller.NewSessionToken(usecase.LoginOutput{
+		AccountID: streamAccountID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(time.Hour),
+	}, "test_secret_key")
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestAccountEventsController_StreamAccountEvents(t *testing.T) {
+	t.Parallel()
+
+	// the ids don't follow the order the events were committed in.
+	replayed := transferEvent(t, "01890a5d-ac97-7c4e-9e5c-9b2f4d6c8a10", 42, streamOtherID, streamAccountID, 300, 1300)
+	live := transferEvent(t, "01890a5d-ac96-774b-bcce-b302099a8057", 43, streamAccountID, streamOtherID, 100, 1200)
+
+	tests := []struct {
+		name         string
+		lastEventID  string
+		useCase      *mocks.AccountEventsUseCaseMock
+		live         []entities.AccountEvent
+		want         string
+		expectedCode int
+	}{
+		{
+			name: "without last event id, streams the balance and the live events",
+			useCase: &mocks.AccountEventsUseCaseMock{
+				GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+					return 1000, nil
+				},
+			},
+			live: []entities.AccountEvent{live},
+			want: "retry: 3000\n\n" +
+				"event: balance.updated\ndata: {\"balance\":1000}\n\n" +
+				"id: 43\nevent: transfer.sent\n" +
+				`data: {"id":"01890a5d-ac96-774b-bcce-b302099a8057","account_origin_id":"0457c690-f884-4d57-810c-85cf09a50d8b","account_destination_id":"9751fe39-976f-4b3d-9611-d6c8c6370b0f","amount":100,"created_at":"2024-01-01T00:00:00Z"}` + "\n\n" +
+				"event: balance.updated\ndata: {\"balance\":1200}\n\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			// the balance isn't read, it's the one of the last event replayed.
+			name:        "with last event id, replays the missed events with their balance and skips them when live",
+			lastEventID: "41",
+			useCase: &mocks.AccountEventsUseCaseMock{
+				ListAccountEventsFunc: func(ctx context.Context, input usecase.ListAccountEventsInput) (usecase.ListAccountEventsOutput, error) {
+					if input.AfterSequence != 41 {
+						return usecase.ListAccountEventsOutput{}, nil
+					}
+					return usecase.ListAccountEventsOutput{Events: []entities.AccountEvent{replayed}}, nil
+				},
+			},
+			live: []entities.AccountEvent{replayed, live},
+			want: "retry: 3000\n\n" +
+				"id: 42\nevent: transfer.received\n" +
+				`data: {"id":"01890a5d-ac97-7c4e-9e5c-9b2f4d6c8a10","account_origin_id":"9751fe39-976f-4b3d-9611-d6c8c6370b0f","account_destination_id":"0457c690-f884-4d57-810c-85cf09a50d8b","amount":300,"created_at":"2024-01-01T00:00:00Z"}` + "\n\n" +
+				"event: balance.updated\ndata: {\"balance\":1300}\n\n" +
+				"id: 43\nevent: transfer.sent\n" +
+				`data: {"id":"01890a5d-ac96-774b-bcce-b302099a8057","account_origin_id":"0457c690-f884-4d57-810c-85cf09a50d8b","account_destination_id":"9751fe39-976f-4b3d-9611-d6c8c6370b0f","amount":100,"created_at":"2024-01-01T00:00:00Z"}` + "\n\n" +
+				"event: balance.updated\ndata: {\"balance\":1200}\n\n",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid last event id",
+			lastEventID:  "invalid",
+			useCase:      &mocks.AccountEventsUseCaseMock{},
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"Last-Event-ID","code":"invalid","message":"must be an integer"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "account not found",
+			useCase: &mocks.AccountEventsUseCaseMock{
+				GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+					return 0, domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account not exists")
+				},
+			},
+			want:         `{"type":"urn:ecorp:problem:account_not_found","title":"Not Found","status":404,"detail":"account not exists","code":"account_not_found"}`,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			events := make(chan entities.AccountEvent, len(tt.live))
+			for _, e := range tt.live {
+				events <- e
+			}
+			close(events)
+
+			subscriber := &mocks.EventSubscriberMock{
+				SubscribeFunc: func(accountID uuid.UUID) (<-chan entities.AccountEvent, func()) {
+					return events, func() {}
+				},
+			}
+			api := controller.API{
+				AccountEventsController: controller.NewAccountEventsController(tt.useCase, subscriber, time.Minute),
+			}
+			handler := server.HTTPHandler(zaptest.NewLogger(t), api, config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/me/events", nil)
+			req.Header.Set("Authorization", "Bearer "+streamToken(t))
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "text/event-stream", response.Header().Get("Content-Type"))
+				assert.Equal(t, tt.want, response.Body.String())
+				return
+			}
+			assert.Equal(t, tt.want, strings.TrimSpace(response.Body.String()))
+		})
+	}
+}
+
+func TestAccountEventsController_StreamAccountEvents_Live(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	events := make(chan entities.AccountEvent)
+	unsubscribed := make(chan struct{})
+	subscriber := &mocks.EventSubscriberMock{
+		SubscribeFunc: func(accountID uuid.UUID) (<-chan entities.AccountEvent, func()) {
+			return events, func() { close(unsubscribed) }
+		},
+	}
+	useCase := &mocks.AccountEventsUseCaseMock{
+		GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+			return 700, nil
+		},
+	}
+	api := controller.API{
+		AccountEventsController: controller.NewAccountEventsController(useCase, subscriber, 10*time.Millisecond),
+	}
+	cfg := config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}}
+	srv := httptest.NewServer(server.HTTPHandler(zaptest.NewLogger(t), api, cfg))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/accounts/me/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+streamToken(t))
+
+	// execute
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	lines := bufio.NewScanner(resp.Body)
+	readUntil := func(want string) {
+		t.Helper()
+		for lines.Scan() {
+			if lines.Text() == want {
+				return
+			}
+		}
+		t.Fatalf("stream ended before %q: %v", want, lines.Err())
+	}
+
+	// assert
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	// the events are flushed as they happen, while the stream is still open.
+	readUntil(`data: {"balance":700}`)
+	readUntil(": heartbeat")
+
+	events <- transferEvent(t, "01890a5d-ac97-7c4e-9e5c-9b2f4d6c8a10", 1, streamOtherID, streamAccountID, 100, 800)
+	readUntil("event: transfer.received")
+	readUntil(`data: {"balance":800}`)
+
+	// the subscription is canceled when the client goes away.
+	cancel()
+	select {
+	case <-unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not canceled")
+	}
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// GetAccount returns the account of the session, with its version and balance in the ETag header.
+func (accController AccountController) GetAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+
+	acc, err := accController.accUseCase.GetAccount(ctx, accountID)
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("ETag", accountETag(acc))
+	SendResponse(ctx, w, http.StatusOK, accountResponse(acc))
+}
+
+// accountETag returns the strong entity tag of the account. The balance changes without making a new version
+// of the account, so the tag carries both and changes with the representation, but If-Match compares the version.
+func accountETag(acc entities.Account) string {
+	return strconv.Quote(strconv.Itoa(acc.Version) + "-" + strconv.Itoa(acc.Balance))
+}
+
+func accountResponse(acc entities.Account) Account {
+	return Account{
+		ID:        acc.ID,
+		Name:      acc.Name,
+		Document:  acc.Document.String(),
+		Balance:   acc.Balance,
+		CreatedAt: acc.CreatedAt,
+	}
+}
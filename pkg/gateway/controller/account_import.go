@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"mime"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// ImportAccounts creates the accounts of a CSV or JSON Lines file and reports the rows that failed.
+// The accounts whose creation wasn't notified are reported as such and logged, since they are created anyway.
+func (accController AccountController) ImportAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// the media type was checked against the spec, which doesn't accept parameters other than the charset.
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	rows, err := requests.ReadAccountImport(r.Body, mediaType)
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	ucOutput, err := accController.accUseCase.ImportAccounts(ctx, usecase.ImportAccountsInput{Rows: rows})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	response := ImportAccountsResponse{
+		Accounts: make([]ImportedAccount, 0, len(ucOutput.Accounts)),
+		Failures: make([]ImportAccountFailure, 0, len(ucOutput.Failures)),
+	}
+	for _, imported := range ucOutput.Accounts {
+		if imported.NotifyErr != nil {
+			logger.Error(ctx, "notifying imported account", zap.Int("row", imported.Row), zap.Error(imported.NotifyErr))
+		}
+		response.Accounts = append(response.Accounts, ImportedAccount{
+			Row:      imported.Row,
+			ID:       imported.Account.ID,
+			Document: imported.Account.Document.String(),
+			Notified: imported.NotifyErr == nil,
+		})
+	}
+	for _, failure := range ucOutput.Failures {
+		p := Problem(ctx, failure.Err)
+		response.Failures = append(response.Failures, ImportAccountFailure{
+			Row:    failure.Row,
+			Code:   p.Code,
+			Detail: p.Detail,
+			Errors: p.Errors,
+		})
+	}
+
+	SendResponse(ctx, w, http.StatusOK, response)
+}
@@ -0,0 +1,148 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/server"
+)
+
+func TestAccountController_ImportAccounts(t *testing.T) {
+	t.Parallel()
+
+	// the first row is created and the second fails, whatever the rows are.
+	// The creation of the accounts named Angela isn't notified.
+	importAccounts := func(ctx context.Context, input usecase.ImportAccountsInput) (usecase.ImportAccountsOutput, error) {
+		var notifyErr error
+		if input.Rows[0].Name == "Angela" {
+			notifyErr = errors.New("broker unavailable")
+		}
+
+		return usecase.ImportAccountsOutput{
+			Accounts: []usecase.ImportedAccount{{
+				Row: 1,
+				Account: entities.Account{
+					ID:       uuid.FromStringOrNil("5f2d4920-89c3-4ed5-af8e-1d411588746d"),
+					Name:     input.Rows[0].Name,
+					Document: vos.Document(input.Rows[0].Document),
+				},
+				NotifyErr: notifyErr,
+			}},
+			Failures: []usecase.ImportAccountFailure{{
+				Row: 2,
+				Err: domain.NewFieldError("secret", domain.CodeSecretTooShort, vos.ErrSmallSecret.Error()),
+			}},
+		}, nil
+	}
+
+	tests := []struct {
+		name         string
+		contentType  string
+		requestBody  string
+		wantRows     []usecase.CreateAccountInput
+		want         string
+		expectedCode int
+	}{
+		{
+			name:        "csv with the columns in any order",
+			contentType: "text/csv; charset=utf-8",
+			requestBody: "document, Name, secret\n44455566678, Elliot, 12345678\n44455566679,Darlene,123\n",
+			wantRows: []usecase.CreateAccountInput{
+				{Name: "Elliot", Document: "44455566678", Secret: "12345678"},
+				{Name: "Darlene", Document: "44455566679", Secret: "123"},
+			},
+			want:         `{"accounts":[{"row":1,"id":"5f2d4920-89c3-4ed5-af8e-1d411588746d","document":"44455566678","notified":true}],"failures":[{"row":2,"code":"validation_failed","errors":[{"field":"secret","code":"secret_too_short","message":"the password must be at least 8 characters long"}]}]}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "json lines",
+			contentType: "application/x-ndjson",
+			requestBody: `{"name":"Elliot","document":"44455566678","secret":"12345678"}` + "\n\n" + `{"name":"Darlene","document":"44455566679","secret":"123"}` + "\n",
+			wantRows: []usecase.CreateAccountInput{
+				{Name: "Elliot", Document: "44455566678", Secret: "12345678"},
+				{Name: "Darlene", Document: "44455566679", Secret: "123"},
+			},
+			want:         `{"accounts":[{"row":1,"id":"5f2d4920-89c3-4ed5-af8e-1d411588746d","document":"44455566678","notified":true}],"failures":[{"row":2,"code":"validation_failed","errors":[{"field":"secret","code":"secret_too_short","message":"the password must be at least 8 characters long"}]}]}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "accounts created without notification",
+			contentType: "text/csv",
+			requestBody: "name,document,secret\nAngela,44455566678,12345678\nDarlene,44455566679,123\n",
+			wantRows: []usecase.CreateAccountInput{
+				{Name: "Angela", Document: "44455566678", Secret: "12345678"},
+				{Name: "Darlene", Document: "44455566679", Secret: "123"},
+			},
+			want:         `{"accounts":[{"row":1,"id":"5f2d4920-89c3-4ed5-af8e-1d411588746d","document":"44455566678","notified":false}],"failures":[{"row":2,"code":"validation_failed","errors":[{"field":"secret","code":"secret_too_short","message":"the password must be at least 8 characters long"}]}]}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "csv with unknown column",
+			contentType:  "text/csv",
+			requestBody:  "name,document,secret,email\nElliot,44455566678,12345678,elliot@ecorp.com\n",
+			want:         `{"type":"urn:ecorp:problem:invalid_request_body","title":"Bad Request","status":400,"detail":"invalid request body: unknown column \"email\"","code":"invalid_request_body"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "csv without rows",
+			contentType:  "text/csv",
+			requestBody:  "name,document,secret\n",
+			want:         `{"type":"urn:ecorp:problem:invalid_request_body","title":"Bad Request","status":400,"detail":"invalid request body: no accounts to import","code":"invalid_request_body"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "json lines with unknown field",
+			contentType:  "application/x-ndjson",
+			requestBody:  `{"name":"Elliot","document":"44455566678","secret":"12345678"}` + "\n" + `{"name":"Darlene","email":"darlene@ecorp.com"}`,
+			want:         `{"type":"urn:ecorp:problem:invalid_request_body","title":"Bad Request","status":400,"detail":"invalid request body: row 2: json: unknown field \"email\"","code":"invalid_request_body"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			accUseCase := &mocks.AccountUseCaseMock{ImportAccountsFunc: importAccounts}
+			api := controller.API{
+				AccountController: controller.NewAccountController(accUseCase),
+			}
+			handler := server.HTTPHandler(zaptest.NewLogger(t), api, config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/accounts/import", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Authorization", "Bearer "+streamToken(t))
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, strings.TrimSpace(tt.want), strings.TrimSpace(response.Body.String()))
+			assert.Equal(t, tt.expectedCode, response.Code)
+
+			if tt.wantRows != nil {
+				calls := accUseCase.ImportAccountsCalls()
+				if assert.Len(t, calls, 1) {
+					assert.Equal(t, tt.wantRows, calls[0].Input.Rows)
+				}
+			}
+		})
+	}
+}
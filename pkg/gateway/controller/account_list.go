@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/gofrs/uuid/v5"
 
@@ -15,34 +14,7 @@ import (
 	"github.com/higordasneves/e-corp/utils/pagination"
 )
 
-type ListAccountsResponse struct {
-	Accounts []ListAccountsResponseItem `json:"accounts"`
-	NextPage string                     `json:"next_page"`
-}
-
-type ListAccountsResponseItem struct {
-	ID       uuid.UUID `json:"id"`
-	Name     string    `json:"name"`
-	Document string    `json:"document"`
-	// Balance represents the balance of the account.
-	Balance   int       `json:"balance"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
 // ListAccounts Lists accounts by filtering the IDs provided in the input.
-// @Summary List Accounts
-// @Description Lists accounts by filtering the IDs provided in the input.
-// @Description It returns bad request error if the provided list of ids is invalid.
-// @Tags Accounts
-// @Param ids query string true "Account IDs"
-// @Param page_size query string true "Page Size"
-// @Param page_token query string true "Page Token"
-// @Accept json
-// @Produce json
-// @Success 200 {object} GetBalanceResponse "Account Balance"
-// @Failure 400 {object} ErrorResponse "invalid parameter"
-// @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /api/v1/accounts [GET]
 func (accController AccountController) ListAccounts(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -50,7 +22,8 @@ func (accController AccountController) ListAccounts(w http.ResponseWriter, r *ht
 	if t := r.URL.Query().Get("page_token"); t != "" {
 		err := pagination.Extract(t, &ucInput)
 		if err != nil {
-			HandleError(ctx, w, fmt.Errorf("%w: invalid page token", domain.ErrInvalidParameter))
+			HandleError(ctx, w, domain.NewFieldError("page_token", domain.CodeInvalidPageToken, "invalid page token"))
+			return
 		}
 	} else {
 		pageSize := r.URL.Query().Get("page_size")
@@ -59,7 +32,7 @@ func (accController AccountController) ListAccounts(w http.ResponseWriter, r *ht
 		}
 		i, err := strconv.Atoi(pageSize)
 		if err != nil {
-			HandleError(ctx, w, fmt.Errorf("%w: converting page size to int", domain.ErrInvalidParameter))
+			HandleError(ctx, w, domain.NewFieldError("page_size", domain.CodeInvalid, "must be an integer"))
 			return
 		}
 
@@ -68,7 +41,7 @@ func (accController AccountController) ListAccounts(w http.ResponseWriter, r *ht
 		for _, id := range idsString {
 			accountID, err := uuid.FromString(id)
 			if err != nil {
-				HandleError(ctx, w, fmt.Errorf("%w: invalid account id", domain.ErrInvalidParameter))
+				HandleError(ctx, w, domain.NewFieldError("ids", domain.CodeInvalid, fmt.Sprintf("invalid account id %q", id)))
 				return
 			}
 			accountIDs = append(accountIDs, accountID)
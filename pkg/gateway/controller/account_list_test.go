@@ -3,7 +3,6 @@ package controller_test
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -137,7 +136,7 @@ func TestAccountController_ListAccounts_Failure(t *testing.T) {
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, controller.ErrUnexpected),
+			want:         `{"type":"urn:ecorp:problem:internal_error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"internal_error"}`,
 			expectedCode: 500,
 		},
 	}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+)
+
+// UpdateAccount updates the profile of the account of the session.
+// The If-Match header conditions the update to the version of the account, returned in the ETag header.
+func (accController AccountController) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	version, err := requests.IfMatchVersion(r)
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	var req UpdateAccountRequest
+	if err := requests.ReadRequestBody(r, &req); err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+
+	ucOutput, err := accController.accUseCase.UpdateAccount(ctx, usecase.UpdateAccountInput{
+		ID:      accountID,
+		Name:    req.Name,
+		Version: version,
+	})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("ETag", accountETag(ucOutput.Account))
+	SendResponse(ctx, w, http.StatusOK, accountResponse(ucOutput.Account))
+}
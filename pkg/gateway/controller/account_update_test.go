@@ -0,0 +1,146 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/server"
+)
+
+func TestAccountController_GetAndUpdateAccount(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.FromStringOrNil("0457c690-f884-4d57-810c-85cf09a50d8b")
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the account is at version 3, with a balance of 1500.
+	accUseCase := &mocks.AccountUseCaseMock{
+		GetAccountFunc: func(ctx context.Context, id uuid.UUID) (entities.Account, error) {
+			return entities.Account{ID: id, Name: "Elliot", Document: "33344455566", Balance: 1500, CreatedAt: createdAt, Version: 3}, nil
+		},
+		UpdateAccountFunc: func(ctx context.Context, input usecase.UpdateAccountInput) (usecase.UpdateAccountOutput, error) {
+			if input.Version != 0 && input.Version != 3 {
+				return usecase.UpdateAccountOutput{}, domain.NewError(domain.ErrConflict, domain.CodeAccountVersionConflict, "account is at version 3, not %d", input.Version)
+			}
+			return usecase.UpdateAccountOutput{
+				Account: entities.Account{ID: input.ID, Name: input.Name, Document: "33344455566", Balance: 1500, CreatedAt: createdAt, Version: 4},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		ifMatch      string
+		want         string
+		wantETag     string
+		expectedCode int
+	}{
+		{
+			name:         "get account",
+			method:       http.MethodGet,
+			want:         `{"id":"0457c690-f884-4d57-810c-85cf09a50d8b","name":"Elliot","document":"33344455566","balance":1500,"created_at":"2024-01-01T00:00:00Z"}`,
+			wantETag:     `"3-1500"`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "update account at the version",
+			method:       http.MethodPatch,
+			body:         `{"name":"Elliot Alderson"}`,
+			ifMatch:      `"3-1500"`,
+			want:         `{"id":"0457c690-f884-4d57-810c-85cf09a50d8b","name":"Elliot Alderson","document":"33344455566","balance":1500,"created_at":"2024-01-01T00:00:00Z"}`,
+			wantETag:     `"4-1500"`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "update account after its balance changed",
+			method:       http.MethodPatch,
+			body:         `{"name":"Elliot Alderson"}`,
+			ifMatch:      `"3-900"`,
+			want:         `{"id":"0457c690-f884-4d57-810c-85cf09a50d8b","name":"Elliot Alderson","document":"33344455566","balance":1500,"created_at":"2024-01-01T00:00:00Z"}`,
+			wantETag:     `"4-1500"`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "update account at any version",
+			method:       http.MethodPatch,
+			body:         `{"name":"Elliot Alderson"}`,
+			ifMatch:      "*",
+			want:         `{"id":"0457c690-f884-4d57-810c-85cf09a50d8b","name":"Elliot Alderson","document":"33344455566","balance":1500,"created_at":"2024-01-01T00:00:00Z"}`,
+			wantETag:     `"4-1500"`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "update account at an outdated version",
+			method:       http.MethodPatch,
+			body:         `{"name":"Elliot Alderson"}`,
+			ifMatch:      `"2-1500"`,
+			want:         `{"type":"urn:ecorp:problem:account_version_conflict","title":"Conflict","status":409,"detail":"account is at version 3, not 2","code":"account_version_conflict"}`,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "update account with a weak etag",
+			method:       http.MethodPatch,
+			body:         `{"name":"Elliot Alderson"}`,
+			ifMatch:      `W/"3-1500"`,
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"If-Match","code":"invalid","message":"must be an ETag of the resource"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			api := controller.API{
+				AccountController: controller.NewAccountController(accUseCase),
+			}
+
+			now := time.Now()
+			claims := &jwt.StandardClaims{
+				Issuer:    "login",
+				Subject:   accountID.String(),
+				IssuedAt:  now.UTC().Unix(),
+				ExpiresAt: now.UTC().Add(time.Hour).Unix(),
+			}
+
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+			tokenString, err := token.SignedString([]byte("test_secret_key"))
+			require.NoError(t, err)
+
+			handler := server.HTTPHandler(zaptest.NewLogger(t), api, config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+			req := httptest.NewRequest(tt.method, "/api/v1/accounts/me", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, strings.TrimSpace(tt.want), strings.TrimSpace(response.Body.String()))
+			assert.Equal(t, tt.wantETag, response.Header().Get("ETag"))
+		})
+	}
+}
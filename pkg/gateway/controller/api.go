@@ -1,34 +1,68 @@
 package controller
 
 import (
+	"github.com/jackc/pgx/v5"
+
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
 	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
 	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
 )
 
+// The request and response types are generated from the OpenAPI spec, the source of truth of the HTTP API.
+//go:generate oapi-codegen -config oapi-codegen.yaml ../../../api/openapi/openapi.yaml
+
 type API struct {
 	AuthController
 	AccountController
+	AccountEventsController
 	TransferController
+	WebhookController
+	NotificationController
 }
 
-func NewApi(r postgres.Repository, broker rabbitmq.Publisher, cfg config.Config) API {
+// UseCases groups the use cases served by the APIs, so the HTTP and gRPC servers share the same instances.
+type UseCases struct {
+	Auth          AuthUseCase
+	Accounts      AccountUseCase
+	AccountEvents AccountEventsUseCase
+	Transfers     TransferUseCase
+	Webhooks      WebhookUseCase
+	Notifications NotificationUseCase
+}
+
+func NewUseCases(r postgres.Repository, conn dbpool.Conn, broker rabbitmq.Publisher, cfg config.Config) UseCases {
 	createAccUseCase := usecase.NewCreateAccountUC(r, broker)
 	getAccUseCase := usecase.NewGetAccountBalanceUC(r)
 	listAccUseCase := usecase.NewListAccountsUC(r)
 	accountsUCs := struct {
 		usecase.CreateAccountUC
+		usecase.ImportAccountsUC
 		usecase.GetAccountBalanceUC
 		usecase.ListAccountsUC
+		usecase.GetAccountUC
+		usecase.UpdateAccountUC
 	}{
 		createAccUseCase,
+		usecase.NewImportAccountsUC(r, broker, &cfg.Import),
 		getAccUseCase,
 		listAccUseCase,
+		usecase.NewGetAccountUC(r),
+		usecase.NewUpdateAccountUC(r),
+	}
+
+	accountEventsUCs := struct {
+		usecase.ListAccountEventsUC
+		usecase.GetAccountBalanceUC
+	}{
+		usecase.NewListAccountEventsUC(r),
+		getAccUseCase,
 	}
-	accController := NewAccountController(accountsUCs)
 
-	tUseCase := usecase.NewTransferUC(r)
+	// transfers read the balances they change, so they run serializable to not overdraw the accounts.
+	transferTx := dbpool.NewTxRunner(conn, cfg.DB, dbpool.TxOptions{Name: "transfer", IsoLevel: pgx.Serializable})
+	tUseCase := usecase.NewTransferUC(r, transferTx, broker)
 	listTransfersUC := usecase.NewListAccountTransfersUC(r)
 	transfersUCs := struct {
 		usecase.TransferUC
@@ -37,14 +71,46 @@ func NewApi(r postgres.Repository, broker rabbitmq.Publisher, cfg config.Config)
 		tUseCase,
 		listTransfersUC,
 	}
-	tController := NewTransferController(transfersUCs)
 
-	authUseCase := usecase.NewAuthUC(r, &cfg.Auth)
-	authController := NewAuthController(authUseCase, cfg.Auth.SecretKey)
+	webhooksUCs := struct {
+		usecase.CreateWebhookUC
+		usecase.ListWebhooksUC
+		usecase.DeleteWebhookUC
+		usecase.ListWebhookDeliveriesUC
+		usecase.ReplayWebhookDeliveryUC
+	}{
+		usecase.NewCreateWebhookUC(r),
+		usecase.NewListWebhooksUC(r),
+		usecase.NewDeleteWebhookUC(r),
+		usecase.NewListWebhookDeliveriesUC(r),
+		usecase.NewReplayWebhookDeliveryUC(r),
+	}
+
+	notificationsUCs := struct {
+		usecase.GetNotificationPreferencesUC
+		usecase.UpdateNotificationPreferencesUC
+	}{
+		usecase.NewGetNotificationPreferencesUC(r, &cfg.Notification),
+		usecase.NewUpdateNotificationPreferencesUC(r),
+	}
+
+	return UseCases{
+		Auth:          usecase.NewAuthUC(r, &cfg.Auth),
+		Accounts:      accountsUCs,
+		AccountEvents: accountEventsUCs,
+		Transfers:     transfersUCs,
+		Webhooks:      webhooksUCs,
+		Notifications: notificationsUCs,
+	}
+}
 
+func NewApi(uc UseCases, subscriber EventSubscriber, cfg config.Config) API {
 	return API{
-		AuthController:     authController,
-		AccountController:  accController,
-		TransferController: tController,
+		AuthController:          NewAuthController(uc.Auth, cfg.Auth.SecretKey),
+		AccountController:       NewAccountController(uc.Accounts),
+		AccountEventsController: NewAccountEventsController(uc.AccountEvents, subscriber, cfg.Stream.Heartbeat),
+		TransferController:      NewTransferController(uc.Transfers),
+		WebhookController:       NewWebhookController(uc.Webhooks),
+		NotificationController:  NewNotificationController(uc.Notifications),
 	}
 }
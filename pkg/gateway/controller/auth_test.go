@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -56,16 +55,16 @@ func TestAuthController_Login(t *testing.T) {
 			expectedCode: http.StatusOK,
 		},
 		{
-			name:        "when account not found should return error and status code 400",
+			name:        "when account not found should return error and status code 404",
 			requestBody: bytes.NewReader([]byte(`{"document": "44455566690", "secret": "12345678"}`)),
 			fields: fields{
 				authUC: &mocks.AuthUseCaseMock{
 					LoginFunc: func(ctx context.Context, input usecase.LoginInput) (usecase.LoginOutput, error) {
-						return usecase.LoginOutput{}, domain.ErrNotFound
+						return usecase.LoginOutput{}, domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account 44455566678 not exists")
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrNotFound),
+			want:         `{"type":"urn:ecorp:problem:account_not_found","title":"Not Found","status":404,"detail":"account 44455566678 not exists","code":"account_not_found"}`,
 			expectedCode: http.StatusNotFound,
 		},
 		{
@@ -74,11 +73,11 @@ func TestAuthController_Login(t *testing.T) {
 			fields: fields{
 				authUC: &mocks.AuthUseCaseMock{
 					LoginFunc: func(ctx context.Context, input usecase.LoginInput) (usecase.LoginOutput, error) {
-						return usecase.LoginOutput{}, domain.ErrInvalidParameter
+						return usecase.LoginOutput{}, domain.NewError(domain.ErrInvalidParameter, domain.CodeInvalidCredentials, "invalid credentials")
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:invalid_credentials","title":"Bad Request","status":400,"detail":"invalid credentials","code":"invalid_credentials"}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -91,7 +90,7 @@ func TestAuthController_Login(t *testing.T) {
 					},
 				},
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:invalid_parameter","title":"Bad Request","status":400,"code":"invalid_parameter"}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -105,7 +104,7 @@ func TestAuthController_Login(t *testing.T) {
 				},
 			},
 
-			want:         fmt.Sprintf(`{"error":"%s"}`, controller.ErrUnexpected),
+			want:         `{"type":"urn:ecorp:problem:internal_error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"internal_error"}`,
 			expectedCode: http.StatusInternalServerError,
 		},
 	}
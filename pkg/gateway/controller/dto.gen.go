@@ -0,0 +1,334 @@
+// Package controller provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package controller
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Account A banking account.
+type Account struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Document string    `json:"document"`
+
+	// Balance The balance of the account.
+	Balance   int       `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateAccountRequest defines model for CreateAccountRequest.
+type CreateAccountRequest struct {
+	// Name The name of the customer.
+	Name string `json:"name"`
+
+	// Document The document number of the customer, with 11 or 14 digits.
+	Document string `json:"document"`
+
+	// Secret The password. Must have at least 8 digits.
+	Secret string `json:"secret"`
+}
+
+// CreateAccountResponse A banking account.
+type CreateAccountResponse = Account
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	// URL The absolute http or https url that receives the events.
+	// Its host must be public, so localhost and the loopback, link-local and private addresses are refused.
+	URL string `json:"url"`
+
+	// EventTypes The events delivered to the webhook: transfer.sent and transfer.received.
+	EventTypes []string `json:"event_types"`
+}
+
+// CreateWebhookResponse defines model for CreateWebhookResponse.
+type CreateWebhookResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+
+	// Secret The key used to sign the payloads. It is only returned on creation.
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetAccountResponse A banking account.
+type GetAccountResponse = Account
+
+// GetBalanceResponse defines model for GetBalanceResponse.
+type GetBalanceResponse struct {
+	// Balance The balance of the account.
+	Balance int `json:"balance"`
+}
+
+// ImportAccountFailure Describes why the account of a row was not created.
+type ImportAccountFailure struct {
+	// Row The position of the row in the file, from 1, without the header.
+	Row    int            `json:"row"`
+	Code   domain.Code    `json:"code"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []ProblemField `json:"errors,omitempty"`
+}
+
+// ImportAccountsResponse defines model for ImportAccountsResponse.
+type ImportAccountsResponse struct {
+	// Accounts The accounts created, in the order of their rows.
+	Accounts []ImportedAccount `json:"accounts"`
+
+	// Failures The rows whose account was not created.
+	Failures []ImportAccountFailure `json:"failures"`
+}
+
+// ImportedAccount defines model for ImportedAccount.
+type ImportedAccount struct {
+	// Row The position of the row in the file, from 1, without the header.
+	Row      int       `json:"row"`
+	ID       uuid.UUID `json:"id"`
+	Document string    `json:"document"`
+
+	// Notified Whether the creation of the account was notified. The account is created even if the notification
+	// fails, in which case the consumers of the account events don't receive it.
+	Notified bool `json:"notified"`
+}
+
+// ListAccountsResponse defines model for ListAccountsResponse.
+type ListAccountsResponse struct {
+	Accounts []ListAccountsResponseItem `json:"accounts"`
+
+	// NextPage The token of the next page, empty on the last page.
+	NextPage string `json:"next_page"`
+}
+
+// ListAccountsResponseItem A banking account.
+type ListAccountsResponseItem = Account
+
+// ListTransfersResponse defines model for ListTransfersResponse.
+type ListTransfersResponse struct {
+	Transfers []ListTransfersResponseItem `json:"transfers"`
+}
+
+// ListTransfersResponseItem A banking transfer.
+type ListTransfersResponseItem = Transfer
+
+// ListWebhookDeliveriesResponse defines model for ListWebhookDeliveriesResponse.
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// ListWebhooksResponse defines model for ListWebhooksResponse.
+type ListWebhooksResponse struct {
+	Webhooks []ListWebhooksResponseItem `json:"webhooks"`
+}
+
+// ListWebhooksResponseItem defines model for ListWebhooksResponseItem.
+type ListWebhooksResponseItem struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Document vos.Document `json:"document"`
+	Secret   string       `json:"secret"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	// Token The session token used to authenticate the account.
+	Token string `json:"token"`
+}
+
+// NotificationPreferencesRequest defines model for NotificationPreferencesRequest.
+type NotificationPreferencesRequest struct {
+	// Locale The language of the notifications: pt-BR or en.
+	Locale string `json:"locale,omitempty"`
+
+	// Channels The channels the account is notified on: email, sms and push.
+	Channels []string `json:"channels,omitempty"`
+
+	// Events The events the account is notified of: account.created, transfer.received and transfer.large_sent.
+	Events []string `json:"events,omitempty"`
+
+	// Email Required by the email channel.
+	Email string `json:"email,omitempty"`
+
+	// Phone Required by the sms channel, in the E.164 format.
+	Phone string `json:"phone,omitempty"`
+
+	// LargeTransferAmount The amount from which an outgoing transfer is notified.
+	LargeTransferAmount int `json:"large_transfer_amount,omitempty"`
+}
+
+// NotificationPreferencesResponse defines model for NotificationPreferencesResponse.
+type NotificationPreferencesResponse struct {
+	Locale              string   `json:"locale"`
+	Channels            []string `json:"channels"`
+	Events              []string `json:"events"`
+	Email               string   `json:"email"`
+	Phone               string   `json:"phone"`
+	LargeTransferAmount int      `json:"large_transfer_amount"`
+
+	// UpdatedAt Omitted while the account uses the default preferences.
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ProblemField Describes an invalid field of the request.
+type ProblemField struct {
+	Field   string      `json:"field"`
+	Code    domain.Code `json:"code"`
+	Message string      `json:"message"`
+}
+
+// ProblemResponse The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type ProblemResponse struct {
+	Type   string         `json:"type"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Code   domain.Code    `json:"code"`
+	Errors []ProblemField `json:"errors,omitempty"`
+}
+
+// Transfer A banking transfer.
+type Transfer struct {
+	ID                   uuid.UUID `json:"id"`
+	AccountOriginID      uuid.UUID `json:"account_origin_id"`
+	AccountDestinationID uuid.UUID `json:"account_destination_id"`
+	Amount               int       `json:"amount"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// TransferEventResponse A banking transfer.
+type TransferEventResponse = Transfer
+
+// TransferRequest defines model for TransferRequest.
+type TransferRequest struct {
+	AccountDestinationID uuid.UUID `json:"destination_id"`
+
+	// Amount The amount of the transfer. It must be positive.
+	Amount int `json:"amount"`
+}
+
+// TransferResponse A banking transfer.
+type TransferResponse = Transfer
+
+// UpdateAccountRequest defines model for UpdateAccountRequest.
+type UpdateAccountRequest struct {
+	// Name The name of the customer.
+	Name string `json:"name"`
+}
+
+// UpdateAccountResponse A banking account.
+type UpdateAccountResponse = Account
+
+// WebhookDeliveryResponse An attempt to deliver an event to a webhook.
+type WebhookDeliveryResponse struct {
+	ID uuid.UUID `json:"id"`
+
+	// EventID The id of the event, sent in the X-Ecorp-Event-Id header.
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+
+	// Status The status of the delivery: pending, succeeded or failed.
+	Status   string `json:"status"`
+	Attempts int    `json:"attempts"`
+
+	// ResponseStatus The HTTP status code returned by the receiver on the last attempt.
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `json:"last_error"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// AccountID defines model for AccountID.
+type AccountID = uuid.UUID
+
+// WebhookID defines model for WebhookID.
+type WebhookID = uuid.UUID
+
+// BadRequest The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type BadRequest = ProblemResponse
+
+// Conflict The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type Conflict = ProblemResponse
+
+// InternalError The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type InternalError = ProblemResponse
+
+// NotFound The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type NotFound = ProblemResponse
+
+// RequestBodyTooLarge The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type RequestBodyTooLarge = ProblemResponse
+
+// TooManyRequests The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type TooManyRequests = ProblemResponse
+
+// Unauthorized The body of the error responses, following RFC 7807.
+// Code is stable and must be used by clients to identify the error.
+type Unauthorized = ProblemResponse
+
+// ListAccountsParams defines parameters for ListAccounts.
+type ListAccountsParams struct {
+	// Ids Comma separated account ids.
+	Ids      string `form:"ids,omitempty" json:"ids,omitempty"`
+	PageSize int    `form:"page_size,omitempty" json:"page_size,omitempty"`
+
+	// PageToken The next_page of the previous page.
+	PageToken string `form:"page_token,omitempty" json:"page_token,omitempty"`
+}
+
+// UpdateAccountParams defines parameters for UpdateAccount.
+type UpdateAccountParams struct {
+	// IfMatch The ETag of the account the changes were made on.
+	IfMatch string `json:"If-Match,omitempty"`
+}
+
+// StreamAccountEventsParams defines parameters for StreamAccountEvents.
+type StreamAccountEventsParams struct {
+	// LastEventID ID of the last event received.
+	LastEventID int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	PageSize int `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// CreateAccountJSONRequestBody defines body for CreateAccount for application/json ContentType.
+type CreateAccountJSONRequestBody = CreateAccountRequest
+
+// UpdateAccountJSONRequestBody defines body for UpdateAccount for application/json ContentType.
+type UpdateAccountJSONRequestBody = UpdateAccountRequest
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginRequest
+
+// UpdateNotificationPreferencesJSONRequestBody defines body for UpdateNotificationPreferences for application/json ContentType.
+type UpdateNotificationPreferencesJSONRequestBody = NotificationPreferencesRequest
+
+// TransferJSONRequestBody defines body for Transfer for application/json ContentType.
+type TransferJSONRequestBody = TransferRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookRequest
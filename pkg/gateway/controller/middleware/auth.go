@@ -2,11 +2,9 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
-	"github.com/dgrijalva/jwt-go"
 	"go.uber.org/zap"
 
 	"github.com/higordasneves/e-corp/pkg/domain"
@@ -26,26 +24,15 @@ func Authenticate(secretKey string) func(next http.Handler) http.Handler {
 				return
 			}
 
-			tokenString := header[1]
-			token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, func(token *jwt.Token) (interface{}, error) {
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-				}
-
-				return []byte(secretKey), nil
-			})
+			subject, err := controller.ParseSessionToken(header[1], secretKey)
 			if err != nil {
-				controller.HandleError(r.Context(), w, domain.ErrUnauthorized)
-				return
-			}
-
-			claims, ok := token.Claims.(*jwt.StandardClaims)
-			if !(ok && token.Valid) {
-				controller.HandleError(r.Context(), w, domain.ErrUnauthorized)
+				controller.HandleError(r.Context(), w, err)
 				return
 			}
 
-			ctxWithValue := context.WithValue(r.Context(), "subject", claims.Subject)
+			ctxWithValue := context.WithValue(r.Context(), "subject", subject)
+			ctxWithValue = logger.With(ctxWithValue, zap.String("account_id", subject))
+			setAccountID(ctxWithValue, subject)
 
 			next.ServeHTTP(w, r.WithContext(ctxWithValue))
 		})
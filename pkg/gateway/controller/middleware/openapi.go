@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/api/openapi"
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// the JSON Lines bodies of the account imports are validated as strings, as kin-openapi does with the CSV ones.
+// They are decoded by the handler, which reports the malformed lines.
+func init() {
+	openapi3filter.RegisterBodyDecoder(requests.MediaTypeJSONL, openapi3filter.FileBodyDecoder)
+}
+
+// OpenAPI validates the requests against the OpenAPI spec before they reach the handlers.
+// Invalid requests are answered with the same problem responses returned by the handlers:
+// missing fields are required errors, values of the wrong type are invalid errors and
+// malformed bodies or unknown fields are invalid request body errors.
+// The authentication is left to the Authenticate middleware, which must run before the validation,
+// so the requests without a session are answered with 401 whatever their content.
+// The requests to paths missing from the spec are served as usual, so the router answers them.
+//
+// When validateResponses is set, the responses are also validated and the mismatches are logged.
+// The responses are sent unchanged, since the check is meant to catch handlers that diverge
+// from the spec, not to fail the requests.
+func OpenAPI(spec openapi.Spec, validateResponses bool) func(next http.Handler) http.Handler {
+	opts := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		MultiError:         true,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			// the router only fails to find the route of the request, which is left to the chi router.
+			route, pathParams, err := spec.Router.FindRoute(r)
+			var routeErr *routers.RouteError
+			if errors.As(err, &routeErr) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if err != nil {
+				controller.HandleError(ctx, w, err)
+				return
+			}
+
+			// the API only accepts JSON, so the bodies sent without a content type are validated as JSON.
+			if r.Body != nil && r.Body != http.NoBody && r.Header.Get("Content-Type") == "" {
+				r.Header.Set("Content-Type", "application/json")
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    opts,
+			}
+			if err = openapi3filter.ValidateRequest(ctx, input); err != nil {
+				controller.HandleError(ctx, w, requestValidationError(err))
+				return
+			}
+
+			if !validateResponses || isEventStream(route.Operation) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			err = openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 status,
+				Header:                 w.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true, MultiError: true},
+			})
+			if err != nil {
+				logger.Error(ctx, "response doesn't match the OpenAPI spec",
+					zap.String("operation", route.Operation.OperationID),
+					zap.Int("status", status),
+					zap.Error(err),
+				)
+			}
+
+			w.WriteHeader(status)
+			_, _ = w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+// requestValidationError maps the validation errors of the request to a domain error.
+func requestValidationError(err error) error {
+	var fields []domain.FieldError
+	for _, err := range flattenErrors(err) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return err
+		}
+
+		var reqErr *openapi3filter.RequestError
+		if !errors.As(err, &reqErr) {
+			return invalidRequestBody(err)
+		}
+
+		if p := reqErr.Parameter; p != nil {
+			fields = append(fields, parameterError(p, reqErr))
+			continue
+		}
+
+		if errors.Is(reqErr, openapi3filter.ErrInvalidRequired) || reqErr.Err == nil {
+			return invalidRequestBody(err)
+		}
+
+		for _, err := range flattenErrors(reqErr.Err) {
+			var schemaErr *openapi3.SchemaError
+			if !errors.As(err, &schemaErr) {
+				return invalidRequestBody(err)
+			}
+
+			switch schemaErr.SchemaField {
+			case "required":
+				fields = append(fields, domain.FieldError{
+					Field: schemaField(schemaErr), Code: domain.CodeRequired, Message: "required field",
+				})
+			case "properties", "additionalProperties":
+				// unknown fields are rejected as the strict decoding of the handlers does.
+				return invalidRequestBody(err)
+			default:
+				fields = append(fields, domain.FieldError{
+					Field: schemaField(schemaErr), Code: domain.CodeInvalid, Message: schemaErr.Reason,
+				})
+			}
+		}
+	}
+
+	return domain.NewValidationError(fields...).WithCause(err)
+}
+
+// parameterError describes the invalid parameter.
+func parameterError(p *openapi3.Parameter, err *openapi3filter.RequestError) domain.FieldError {
+	if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+		return domain.FieldError{Field: p.Name, Code: domain.CodeRequired, Message: "required field"}
+	}
+
+	msg := "invalid value"
+	if p.Schema != nil && p.Schema.Value != nil && p.Schema.Value.Type.Is(openapi3.TypeInteger) {
+		msg = "must be an integer"
+	}
+
+	return domain.FieldError{Field: p.Name, Code: domain.CodeInvalid, Message: msg}
+}
+
+// schemaField returns the path of the invalid field, with the names of the nested fields joined by dots.
+func schemaField(err *openapi3.SchemaError) string {
+	return strings.Join(err.JSONPointer(), ".")
+}
+
+func invalidRequestBody(err error) error {
+	return domain.NewError(domain.ErrInvalidParameter, domain.CodeInvalidRequestBody, "invalid request body").WithCause(err)
+}
+
+// flattenErrors returns the errors of the openapi3.MultiError tree, or err itself.
+// Only the tree is flattened: the errors that wrap a MultiError are kept, so their context isn't lost.
+func flattenErrors(err error) []error {
+	me, ok := err.(openapi3.MultiError) //nolint:errorlint // wrapped trees belong to the wrapping error.
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, err := range me {
+		errs = append(errs, flattenErrors(err)...)
+	}
+
+	return errs
+}
+
+// isEventStream reports whether the operation streams its response, which can't be buffered for the validation.
+func isEventStream(op *openapi3.Operation) bool {
+	for _, resp := range op.Responses.Map() {
+		if resp.Value != nil && resp.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// responseRecorder buffers the response, so it can be validated before being sent.
+// The headers are written to the underlying writer, which only sends them on WriteHeader.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	return r.body.Write(b)
+}
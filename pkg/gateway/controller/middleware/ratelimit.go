@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+)
+
+// limiterTTL is how long the bucket of an idle client is kept. An evicted bucket is recreated full,
+// which is equivalent to keeping it, since a bucket refills completely within the TTL.
+const limiterTTL = 10 * time.Minute
+
+// Limiter keeps a token bucket per key. It's shared by the HTTP and the gRPC servers.
+type Limiter struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewLimiter returns a Limiter of the given rate per second and burst for each key.
+func NewLimiter(limit float64, burst int) *Limiter {
+	// a bucket smaller than one token never allows a request.
+	burst = max(burst, 1)
+
+	return &Limiter{
+		limit:     rate.Limit(limit),
+		burst:     burst,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Reserve takes a token of the key bucket.
+// Returns false and the delay until a token is available if the bucket is empty.
+func (l *Limiter) Reserve(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > limiterTTL {
+		for k, b := range l.buckets {
+			if now.Sub(b.lastSeen) > limiterTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	r := b.limiter.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay
+	}
+
+	return true, 0
+}
+
+// RateLimitIP limits the requests of each client IP with a token bucket of the given rate per second and burst.
+// With trustProxy the client IP is read from the X-Forwarded-For header.
+// A zero rate disables the limit.
+func RateLimitIP(limit float64, burst int, trustProxy bool) func(next http.Handler) http.Handler {
+	return rateLimit(limit, burst, func(r *http.Request) string {
+		return clientIP(r, trustProxy)
+	})
+}
+
+// RateLimitAccount limits the requests of each account with a token bucket of the given rate per second and burst.
+// It must run after Authenticate. A zero rate disables the limit.
+func RateLimitAccount(limit float64, burst int) func(next http.Handler) http.Handler {
+	return rateLimit(limit, burst, func(r *http.Request) string {
+		return fmt.Sprint(r.Context().Value("subject"))
+	})
+}
+
+func rateLimit(limit float64, burst int, key func(r *http.Request) string) func(next http.Handler) http.Handler {
+	if limit <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	l := NewLimiter(limit, burst)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, delay := l.Reserve(key(r), time.Now()); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+				controller.SendProblem(r.Context(), w,
+					controller.NewProblem(http.StatusTooManyRequests, domain.CodeRateLimited, "too many requests"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the IP of the client. With trustProxy, it is the last address of the
+// X-Forwarded-For header, which is the one appended by the proxy and can't be spoofed.
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if ip := ForwardedIP(r.Header.Values("X-Forwarded-For")); ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// ForwardedIP returns the last address of the X-Forwarded-For values, the one appended by the proxy,
+// or an empty string if there is none.
+func ForwardedIP(xff []string) string {
+	if len(xff) == 0 {
+		return ""
+	}
+
+	addrs := strings.Split(xff[len(xff)-1], ",")
+
+	return strings.TrimSpace(addrs[len(addrs)-1])
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gofrs/uuid/v5"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/tracing"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// requestIDRegexp restricts the accepted request ids, so clients can't inject arbitrary content in the logs.
+var requestIDRegexp = regexp.MustCompile(`^[\w\-.:]{1,128}$`)
+
+// RequestID accepts the X-Request-ID header of the request or generates a new id if it is missing or invalid.
+// The id is associated with the request context and echoed in the response.
+// The context logger receives the request id, the method, the route pattern and the trace ids, if any.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(apictx.RequestIDHeader)
+		if !requestIDRegexp.MatchString(id) {
+			id = uuid.Must(uuid.NewV7()).String()
+		}
+
+		w.Header().Set(apictx.RequestIDHeader, id)
+
+		ctx := apictx.WithRequestID(r.Context(), id)
+		fields := []zap.Field{
+			zap.String("request_id", id),
+			zap.String("method", r.Method),
+			zap.Stringer("route", routePattern{chi.RouteContext(ctx)}),
+		}
+		ctx = logger.With(ctx, append(fields, tracing.LogFields(ctx)...)...)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// routePattern is evaluated when the log is written, since the pattern is only complete after the routing.
+type routePattern struct {
+	rctx *chi.Context
+}
+
+func (p routePattern) String() string {
+	if p.rctx == nil {
+		return ""
+	}
+
+	return p.rctx.RoutePattern()
+}
+
+// accessEntry holds the values of the access log set by inner middlewares.
+type accessEntry struct {
+	accountID string
+}
+
+// accessEntryKey is the context key used to associate the access entry.
+type accessEntryKey struct{}
+
+// setAccountID records the authenticated account in the access log of the request.
+func setAccountID(ctx context.Context, accountID string) {
+	if e, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+		e.accountID = accountID
+	}
+}
+
+// AccessLog writes one log per request with the response status, the number of bytes written and the latency.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		entry := &accessEntry{}
+		ctx := context.WithValue(r.Context(), accessEntryKey{}, entry)
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		fields := []zap.Field{
+			zap.String("path", r.URL.Path),
+			zap.Int("status", status),
+			zap.Int("bytes", ww.BytesWritten()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.String("user_agent", r.UserAgent()),
+		}
+		if entry.accountID != "" {
+			fields = append(fields, zap.String("account_id", entry.accountID))
+		}
+
+		logger.Info(ctx, "http request", fields...)
+	})
+}
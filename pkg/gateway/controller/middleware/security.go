@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/cors"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/utils/apictx"
+)
+
+// SecurityHeaders sets the headers that keep browsers from sniffing, framing or leaking the API responses.
+// The Strict-Transport-Security header is only sent when the server is serving HTTPS.
+func SecurityHeaders(cfg config.HTTP) func(next http.Handler) http.Handler {
+	hsts := ""
+	if cfg.TLS() && cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge/time.Second)) + "; includeSubDomains"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("X-Frame-Options", "DENY")
+			h.Set("Referrer-Policy", "no-referrer")
+			h.Set("Content-Security-Policy", "frame-ancestors 'none'")
+			if hsts != "" {
+				h.Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// BodyLimit limits the size of the request bodies. Reading past the limit fails with an *http.MaxBytesError.
+// A zero limit disables it.
+func BodyLimit(limit int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CORS allows the configured origins to call the API from a browser.
+// Without allowed origins the CORS headers are not sent, so browsers only allow same-origin requests.
+func CORS(cfg config.HTTP) func(next http.Handler) http.Handler {
+	if len(cfg.CORSAllowedOrigins) == 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return cors.Handler(cors.Options{
+		AllowedOrigins:   cfg.CORSAllowedOrigins,
+		AllowedMethods:   cfg.CORSAllowedMethods,
+		AllowedHeaders:   cfg.CORSAllowedHeaders,
+		ExposedHeaders:   []string{apictx.RequestIDHeader, "Retry-After", "ETag"},
+		AllowCredentials: false,
+		MaxAge:           int(cfg.CORSMaxAge / time.Second),
+	})
+}
@@ -0,0 +1,213 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/gofrs/uuid/v5"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"sync"
+)
+
+// Ensure, that AccountEventsUseCaseMock does implement controller.AccountEventsUseCase.
+// If this is not the case, regenerate this file with moq.
+var _ controller.AccountEventsUseCase = &AccountEventsUseCaseMock{}
+
+// AccountEventsUseCaseMock is a mock implementation of controller.AccountEventsUseCase.
+//
+//	func TestSomethingThatUsesAccountEventsUseCase(t *testing.T) {
+//
+//		// make and configure a mocked controller.AccountEventsUseCase
+//		mockedAccountEventsUseCase := &AccountEventsUseCaseMock{
+//			GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+//				panic("mock out the GetBalance method")
+//			},
+//			ListAccountEventsFunc: func(ctx context.Context, input usecase.ListAccountEventsInput) (usecase.ListAccountEventsOutput, error) {
+//				panic("mock out the ListAccountEvents method")
+//			},
+//		}
+//
+//		// use mockedAccountEventsUseCase in code that requires controller.AccountEventsUseCase
+//		// and then make assertions.
+//
+//	}
+type AccountEventsUseCaseMock struct {
+	// GetBalanceFunc mocks the GetBalance method.
+	GetBalanceFunc func(ctx context.Context, id uuid.UUID) (int, error)
+
+	// ListAccountEventsFunc mocks the ListAccountEvents method.
+	ListAccountEventsFunc func(ctx context.Context, input usecase.ListAccountEventsInput) (usecase.ListAccountEventsOutput, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetBalance holds details about calls to the GetBalance method.
+		GetBalance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
+		// ListAccountEvents holds details about calls to the ListAccountEvents method.
+		ListAccountEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.ListAccountEventsInput
+		}
+	}
+	lockGetBalance        sync.RWMutex
+	lockListAccountEvents sync.RWMutex
+}
+
+// GetBalance calls GetBalanceFunc.
+func (mock *AccountEventsUseCaseMock) GetBalance(ctx context.Context, id uuid.UUID) (int, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetBalance.Lock()
+	mock.calls.GetBalance = append(mock.calls.GetBalance, callInfo)
+	mock.lockGetBalance.Unlock()
+	if mock.GetBalanceFunc == nil {
+		var (
+			nOut   int
+			errOut error
+		)
+		return nOut, errOut
+	}
+	return mock.GetBalanceFunc(ctx, id)
+}
+
+// GetBalanceCalls gets all the calls that were made to GetBalance.
+// Check the length with:
+//
+//	len(mockedAccountEventsUseCase.GetBalanceCalls())
+func (mock *AccountEventsUseCaseMock) GetBalanceCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetBalance.RLock()
+	calls = mock.calls.GetBalance
+	mock.lockGetBalance.RUnlock()
+	return calls
+}
+
+// ListAccountEvents calls ListAccountEventsFunc.
+func (mock *AccountEventsUseCaseMock) ListAccountEvents(ctx context.Context, input usecase.ListAccountEventsInput) (usecase.ListAccountEventsOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.ListAccountEventsInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockListAccountEvents.Lock()
+	mock.calls.ListAccountEvents = append(mock.calls.ListAccountEvents, callInfo)
+	mock.lockListAccountEvents.Unlock()
+	if mock.ListAccountEventsFunc == nil {
+		var (
+			listAccountEventsOutputOut usecase.ListAccountEventsOutput
+			errOut                     error
+		)
+		return listAccountEventsOutputOut, errOut
+	}
+	return mock.ListAccountEventsFunc(ctx, input)
+}
+
+// ListAccountEventsCalls gets all the calls that were made to ListAccountEvents.
+// Check the length with:
+//
+//	len(mockedAccountEventsUseCase.ListAccountEventsCalls())
+func (mock *AccountEventsUseCaseMock) ListAccountEventsCalls() []struct {
+	Ctx   context.Context
+	Input usecase.ListAccountEventsInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.ListAccountEventsInput
+	}
+	mock.lockListAccountEvents.RLock()
+	calls = mock.calls.ListAccountEvents
+	mock.lockListAccountEvents.RUnlock()
+	return calls
+}
+
+// Ensure, that EventSubscriberMock does implement controller.EventSubscriber.
+// If this is not the case, regenerate this file with moq.
+var _ controller.EventSubscriber = &EventSubscriberMock{}
+
+// EventSubscriberMock is a mock implementation of controller.EventSubscriber.
+//
+//	func TestSomethingThatUsesEventSubscriber(t *testing.T) {
+//
+//		// make and configure a mocked controller.EventSubscriber
+//		mockedEventSubscriber := &EventSubscriberMock{
+//			SubscribeFunc: func(accountID uuid.UUID) (<-chan entities.AccountEvent, func()) {
+//				panic("mock out the Subscribe method")
+//			},
+//		}
+//
+//		// use mockedEventSubscriber in code that requires controller.EventSubscriber
+//		// and then make assertions.
+//
+//	}
+type EventSubscriberMock struct {
+	// SubscribeFunc mocks the Subscribe method.
+	SubscribeFunc func(accountID uuid.UUID) (<-chan entities.AccountEvent, func())
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Subscribe holds details about calls to the Subscribe method.
+		Subscribe []struct {
+			// AccountID is the accountID argument value.
+			AccountID uuid.UUID
+		}
+	}
+	lockSubscribe sync.RWMutex
+}
+
+// Subscribe calls SubscribeFunc.
+func (mock *EventSubscriberMock) Subscribe(accountID uuid.UUID) (<-chan entities.AccountEvent, func()) {
+	callInfo := struct {
+		AccountID uuid.UUID
+	}{
+		AccountID: accountID,
+	}
+	mock.lockSubscribe.Lock()
+	mock.calls.Subscribe = append(mock.calls.Subscribe, callInfo)
+	mock.lockSubscribe.Unlock()
+	if mock.SubscribeFunc == nil {
+		var (
+			accountEventChOut <-chan entities.AccountEvent
+			fnOut             func()
+		)
+		return accountEventChOut, fnOut
+	}
+	return mock.SubscribeFunc(accountID)
+}
+
+// SubscribeCalls gets all the calls that were made to Subscribe.
+// Check the length with:
+//
+//	len(mockedEventSubscriber.SubscribeCalls())
+func (mock *EventSubscriberMock) SubscribeCalls() []struct {
+	AccountID uuid.UUID
+} {
+	var calls []struct {
+		AccountID uuid.UUID
+	}
+	mock.lockSubscribe.RLock()
+	calls = mock.calls.Subscribe
+	mock.lockSubscribe.RUnlock()
+	return calls
+}
@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 	"github.com/gofrs/uuid/v5"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
 	"github.com/higordasneves/e-corp/pkg/gateway/controller"
 	"sync"
@@ -24,12 +25,21 @@ var _ controller.AccountUseCase = &AccountUseCaseMock{}
 //			CreateAccountFunc: func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error) {
 //				panic("mock out the CreateAccount method")
 //			},
+//			GetAccountFunc: func(ctx context.Context, id uuid.UUID) (entities.Account, error) {
+//				panic("mock out the GetAccount method")
+//			},
 //			GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
 //				panic("mock out the GetBalance method")
 //			},
+//			ImportAccountsFunc: func(ctx context.Context, input usecase.ImportAccountsInput) (usecase.ImportAccountsOutput, error) {
+//				panic("mock out the ImportAccounts method")
+//			},
 //			ListAccountsFunc: func(ctx context.Context, input usecase.ListAccountsInput) (usecase.ListAccountsOutput, error) {
 //				panic("mock out the ListAccounts method")
 //			},
+//			UpdateAccountFunc: func(ctx context.Context, input usecase.UpdateAccountInput) (usecase.UpdateAccountOutput, error) {
+//				panic("mock out the UpdateAccount method")
+//			},
 //		}
 //
 //		// use mockedAccountUseCase in code that requires controller.AccountUseCase
@@ -40,12 +50,21 @@ type AccountUseCaseMock struct {
 	// CreateAccountFunc mocks the CreateAccount method.
 	CreateAccountFunc func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error)
 
+	// GetAccountFunc mocks the GetAccount method.
+	GetAccountFunc func(ctx context.Context, id uuid.UUID) (entities.Account, error)
+
 	// GetBalanceFunc mocks the GetBalance method.
 	GetBalanceFunc func(ctx context.Context, id uuid.UUID) (int, error)
 
+	// ImportAccountsFunc mocks the ImportAccounts method.
+	ImportAccountsFunc func(ctx context.Context, input usecase.ImportAccountsInput) (usecase.ImportAccountsOutput, error)
+
 	// ListAccountsFunc mocks the ListAccounts method.
 	ListAccountsFunc func(ctx context.Context, input usecase.ListAccountsInput) (usecase.ListAccountsOutput, error)
 
+	// UpdateAccountFunc mocks the UpdateAccount method.
+	UpdateAccountFunc func(ctx context.Context, input usecase.UpdateAccountInput) (usecase.UpdateAccountOutput, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateAccount holds details about calls to the CreateAccount method.
@@ -55,6 +74,13 @@ type AccountUseCaseMock struct {
 			// Input is the input argument value.
 			Input usecase.CreateAccountInput
 		}
+		// GetAccount holds details about calls to the GetAccount method.
+		GetAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
 		// GetBalance holds details about calls to the GetBalance method.
 		GetBalance []struct {
 			// Ctx is the ctx argument value.
@@ -62,6 +88,13 @@ type AccountUseCaseMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// ImportAccounts holds details about calls to the ImportAccounts method.
+		ImportAccounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.ImportAccountsInput
+		}
 		// ListAccounts holds details about calls to the ListAccounts method.
 		ListAccounts []struct {
 			// Ctx is the ctx argument value.
@@ -69,10 +102,20 @@ type AccountUseCaseMock struct {
 			// Input is the input argument value.
 			Input usecase.ListAccountsInput
 		}
+		// UpdateAccount holds details about calls to the UpdateAccount method.
+		UpdateAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.UpdateAccountInput
+		}
 	}
-	lockCreateAccount sync.RWMutex
-	lockGetBalance    sync.RWMutex
-	lockListAccounts  sync.RWMutex
+	lockCreateAccount  sync.RWMutex
+	lockGetAccount     sync.RWMutex
+	lockGetBalance     sync.RWMutex
+	lockImportAccounts sync.RWMutex
+	lockListAccounts   sync.RWMutex
+	lockUpdateAccount  sync.RWMutex
 }
 
 // CreateAccount calls CreateAccountFunc.
@@ -115,6 +158,46 @@ func (mock *AccountUseCaseMock) CreateAccountCalls() []struct {
 	return calls
 }
 
+// GetAccount calls GetAccountFunc.
+func (mock *AccountUseCaseMock) GetAccount(ctx context.Context, id uuid.UUID) (entities.Account, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetAccount.Lock()
+	mock.calls.GetAccount = append(mock.calls.GetAccount, callInfo)
+	mock.lockGetAccount.Unlock()
+	if mock.GetAccountFunc == nil {
+		var (
+			accountOut entities.Account
+			errOut     error
+		)
+		return accountOut, errOut
+	}
+	return mock.GetAccountFunc(ctx, id)
+}
+
+// GetAccountCalls gets all the calls that were made to GetAccount.
+// Check the length with:
+//
+//	len(mockedAccountUseCase.GetAccountCalls())
+func (mock *AccountUseCaseMock) GetAccountCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockGetAccount.RLock()
+	calls = mock.calls.GetAccount
+	mock.lockGetAccount.RUnlock()
+	return calls
+}
+
 // GetBalance calls GetBalanceFunc.
 func (mock *AccountUseCaseMock) GetBalance(ctx context.Context, id uuid.UUID) (int, error) {
 	callInfo := struct {
@@ -155,6 +238,46 @@ func (mock *AccountUseCaseMock) GetBalanceCalls() []struct {
 	return calls
 }
 
+// ImportAccounts calls ImportAccountsFunc.
+func (mock *AccountUseCaseMock) ImportAccounts(ctx context.Context, input usecase.ImportAccountsInput) (usecase.ImportAccountsOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.ImportAccountsInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockImportAccounts.Lock()
+	mock.calls.ImportAccounts = append(mock.calls.ImportAccounts, callInfo)
+	mock.lockImportAccounts.Unlock()
+	if mock.ImportAccountsFunc == nil {
+		var (
+			importAccountsOutputOut usecase.ImportAccountsOutput
+			errOut                  error
+		)
+		return importAccountsOutputOut, errOut
+	}
+	return mock.ImportAccountsFunc(ctx, input)
+}
+
+// ImportAccountsCalls gets all the calls that were made to ImportAccounts.
+// Check the length with:
+//
+//	len(mockedAccountUseCase.ImportAccountsCalls())
+func (mock *AccountUseCaseMock) ImportAccountsCalls() []struct {
+	Ctx   context.Context
+	Input usecase.ImportAccountsInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.ImportAccountsInput
+	}
+	mock.lockImportAccounts.RLock()
+	calls = mock.calls.ImportAccounts
+	mock.lockImportAccounts.RUnlock()
+	return calls
+}
+
 // ListAccounts calls ListAccountsFunc.
 func (mock *AccountUseCaseMock) ListAccounts(ctx context.Context, input usecase.ListAccountsInput) (usecase.ListAccountsOutput, error) {
 	callInfo := struct {
@@ -194,3 +317,43 @@ func (mock *AccountUseCaseMock) ListAccountsCalls() []struct {
 	mock.lockListAccounts.RUnlock()
 	return calls
 }
+
+// UpdateAccount calls UpdateAccountFunc.
+func (mock *AccountUseCaseMock) UpdateAccount(ctx context.Context, input usecase.UpdateAccountInput) (usecase.UpdateAccountOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.UpdateAccountInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockUpdateAccount.Lock()
+	mock.calls.UpdateAccount = append(mock.calls.UpdateAccount, callInfo)
+	mock.lockUpdateAccount.Unlock()
+	if mock.UpdateAccountFunc == nil {
+		var (
+			updateAccountOutputOut usecase.UpdateAccountOutput
+			errOut                 error
+		)
+		return updateAccountOutputOut, errOut
+	}
+	return mock.UpdateAccountFunc(ctx, input)
+}
+
+// UpdateAccountCalls gets all the calls that were made to UpdateAccount.
+// Check the length with:
+//
+//	len(mockedAccountUseCase.UpdateAccountCalls())
+func (mock *AccountUseCaseMock) UpdateAccountCalls() []struct {
+	Ctx   context.Context
+	Input usecase.UpdateAccountInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.UpdateAccountInput
+	}
+	mock.lockUpdateAccount.RLock()
+	calls = mock.calls.UpdateAccount
+	mock.lockUpdateAccount.RUnlock()
+	return calls
+}
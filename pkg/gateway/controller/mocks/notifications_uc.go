@@ -0,0 +1,142 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/gofrs/uuid/v5"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"sync"
+)
+
+// Ensure, that NotificationUseCaseMock does implement controller.NotificationUseCase.
+// If this is not the case, regenerate this file with moq.
+var _ controller.NotificationUseCase = &NotificationUseCaseMock{}
+
+// NotificationUseCaseMock is a mock implementation of controller.NotificationUseCase.
+//
+//	func TestSomethingThatUsesNotificationUseCase(t *testing.T) {
+//
+//		// make and configure a mocked controller.NotificationUseCase
+//		mockedNotificationUseCase := &NotificationUseCaseMock{
+//			GetNotificationPreferencesFunc: func(ctx context.Context, accountID uuid.UUID) (usecase.GetNotificationPreferencesOutput, error) {
+//				panic("mock out the GetNotificationPreferences method")
+//			},
+//			UpdateNotificationPreferencesFunc: func(ctx context.Context, input usecase.UpdateNotificationPreferencesInput) (usecase.UpdateNotificationPreferencesOutput, error) {
+//				panic("mock out the UpdateNotificationPreferences method")
+//			},
+//		}
+//
+//		// use mockedNotificationUseCase in code that requires controller.NotificationUseCase
+//		// and then make assertions.
+//
+//	}
+type NotificationUseCaseMock struct {
+	// GetNotificationPreferencesFunc mocks the GetNotificationPreferences method.
+	GetNotificationPreferencesFunc func(ctx context.Context, accountID uuid.UUID) (usecase.GetNotificationPreferencesOutput, error)
+
+	// UpdateNotificationPreferencesFunc mocks the UpdateNotificationPreferences method.
+	UpdateNotificationPreferencesFunc func(ctx context.Context, input usecase.UpdateNotificationPreferencesInput) (usecase.UpdateNotificationPreferencesOutput, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetNotificationPreferences holds details about calls to the GetNotificationPreferences method.
+		GetNotificationPreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccountID is the accountID argument value.
+			AccountID uuid.UUID
+		}
+		// UpdateNotificationPreferences holds details about calls to the UpdateNotificationPreferences method.
+		UpdateNotificationPreferences []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.UpdateNotificationPreferencesInput
+		}
+	}
+	lockGetNotificationPreferences    sync.RWMutex
+	lockUpdateNotificationPreferences sync.RWMutex
+}
+
+// GetNotificationPreferences calls GetNotificationPreferencesFunc.
+func (mock *NotificationUseCaseMock) GetNotificationPreferences(ctx context.Context, accountID uuid.UUID) (usecase.GetNotificationPreferencesOutput, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		AccountID uuid.UUID
+	}{
+		Ctx:       ctx,
+		AccountID: accountID,
+	}
+	mock.lockGetNotificationPreferences.Lock()
+	mock.calls.GetNotificationPreferences = append(mock.calls.GetNotificationPreferences, callInfo)
+	mock.lockGetNotificationPreferences.Unlock()
+	if mock.GetNotificationPreferencesFunc == nil {
+		var (
+			getNotificationPreferencesOutputOut usecase.GetNotificationPreferencesOutput
+			errOut                              error
+		)
+		return getNotificationPreferencesOutputOut, errOut
+	}
+	return mock.GetNotificationPreferencesFunc(ctx, accountID)
+}
+
+// GetNotificationPreferencesCalls gets all the calls that were made to GetNotificationPreferences.
+// Check the length with:
+//
+//	len(mockedNotificationUseCase.GetNotificationPreferencesCalls())
+func (mock *NotificationUseCaseMock) GetNotificationPreferencesCalls() []struct {
+	Ctx       context.Context
+	AccountID uuid.UUID
+} {
+	var calls []struct {
+		Ctx       context.Context
+		AccountID uuid.UUID
+	}
+	mock.lockGetNotificationPreferences.RLock()
+	calls = mock.calls.GetNotificationPreferences
+	mock.lockGetNotificationPreferences.RUnlock()
+	return calls
+}
+
+// UpdateNotificationPreferences calls UpdateNotificationPreferencesFunc.
+func (mock *NotificationUseCaseMock) UpdateNotificationPreferences(ctx context.Context, input usecase.UpdateNotificationPreferencesInput) (usecase.UpdateNotificationPreferencesOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.UpdateNotificationPreferencesInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockUpdateNotificationPreferences.Lock()
+	mock.calls.UpdateNotificationPreferences = append(mock.calls.UpdateNotificationPreferences, callInfo)
+	mock.lockUpdateNotificationPreferences.Unlock()
+	if mock.UpdateNotificationPreferencesFunc == nil {
+		var (
+			updateNotificationPreferencesOutputOut usecase.UpdateNotificationPreferencesOutput
+			errOut                                 error
+		)
+		return updateNotificationPreferencesOutputOut, errOut
+	}
+	return mock.UpdateNotificationPreferencesFunc(ctx, input)
+}
+
+// UpdateNotificationPreferencesCalls gets all the calls that were made to UpdateNotificationPreferences.
+// Check the length with:
+//
+//	len(mockedNotificationUseCase.UpdateNotificationPreferencesCalls())
+func (mock *NotificationUseCaseMock) UpdateNotificationPreferencesCalls() []struct {
+	Ctx   context.Context
+	Input usecase.UpdateNotificationPreferencesInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.UpdateNotificationPreferencesInput
+	}
+	mock.lockUpdateNotificationPreferences.RLock()
+	calls = mock.calls.UpdateNotificationPreferences
+	mock.lockUpdateNotificationPreferences.RUnlock()
+	return calls
+}
@@ -0,0 +1,302 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"sync"
+)
+
+// Ensure, that WebhookUseCaseMock does implement controller.WebhookUseCase.
+// If this is not the case, regenerate this file with moq.
+var _ controller.WebhookUseCase = &WebhookUseCaseMock{}
+
+// WebhookUseCaseMock is a mock implementation of controller.WebhookUseCase.
+//
+//	func TestSomethingThatUsesWebhookUseCase(t *testing.T) {
+//
+//		// make and configure a mocked controller.WebhookUseCase
+//		mockedWebhookUseCase := &WebhookUseCaseMock{
+//			CreateWebhookFunc: func(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error) {
+//				panic("mock out the CreateWebhook method")
+//			},
+//			DeleteWebhookFunc: func(ctx context.Context, input usecase.DeleteWebhookInput) error {
+//				panic("mock out the DeleteWebhook method")
+//			},
+//			ListWebhookDeliveriesFunc: func(ctx context.Context, input usecase.ListWebhookDeliveriesInput) (usecase.ListWebhookDeliveriesOutput, error) {
+//				panic("mock out the ListWebhookDeliveries method")
+//			},
+//			ListWebhooksFunc: func(ctx context.Context, input usecase.ListWebhooksInput) (usecase.ListWebhooksOutput, error) {
+//				panic("mock out the ListWebhooks method")
+//			},
+//			ReplayWebhookDeliveryFunc: func(ctx context.Context, input usecase.ReplayWebhookDeliveryInput) (usecase.ReplayWebhookDeliveryOutput, error) {
+//				panic("mock out the ReplayWebhookDelivery method")
+//			},
+//		}
+//
+//		// use mockedWebhookUseCase in code that requires controller.WebhookUseCase
+//		// and then make assertions.
+//
+//	}
+type WebhookUseCaseMock struct {
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error)
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, input usecase.DeleteWebhookInput) error
+
+	// ListWebhookDeliveriesFunc mocks the ListWebhookDeliveries method.
+	ListWebhookDeliveriesFunc func(ctx context.Context, input usecase.ListWebhookDeliveriesInput) (usecase.ListWebhookDeliveriesOutput, error)
+
+	// ListWebhooksFunc mocks the ListWebhooks method.
+	ListWebhooksFunc func(ctx context.Context, input usecase.ListWebhooksInput) (usecase.ListWebhooksOutput, error)
+
+	// ReplayWebhookDeliveryFunc mocks the ReplayWebhookDelivery method.
+	ReplayWebhookDeliveryFunc func(ctx context.Context, input usecase.ReplayWebhookDeliveryInput) (usecase.ReplayWebhookDeliveryOutput, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.CreateWebhookInput
+		}
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.DeleteWebhookInput
+		}
+		// ListWebhookDeliveries holds details about calls to the ListWebhookDeliveries method.
+		ListWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.ListWebhookDeliveriesInput
+		}
+		// ListWebhooks holds details about calls to the ListWebhooks method.
+		ListWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.ListWebhooksInput
+		}
+		// ReplayWebhookDelivery holds details about calls to the ReplayWebhookDelivery method.
+		ReplayWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Input is the input argument value.
+			Input usecase.ReplayWebhookDeliveryInput
+		}
+	}
+	lockCreateWebhook         sync.RWMutex
+	lockDeleteWebhook         sync.RWMutex
+	lockListWebhookDeliveries sync.RWMutex
+	lockListWebhooks          sync.RWMutex
+	lockReplayWebhookDelivery sync.RWMutex
+}
+
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *WebhookUseCaseMock) CreateWebhook(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.CreateWebhookInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	mock.lockCreateWebhook.Unlock()
+	if mock.CreateWebhookFunc == nil {
+		var (
+			createWebhookOutputOut usecase.CreateWebhookOutput
+			errOut                 error
+		)
+		return createWebhookOutputOut, errOut
+	}
+	return mock.CreateWebhookFunc(ctx, input)
+}
+
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
+// Check the length with:
+//
+//	len(mockedWebhookUseCase.CreateWebhookCalls())
+func (mock *WebhookUseCaseMock) CreateWebhookCalls() []struct {
+	Ctx   context.Context
+	Input usecase.CreateWebhookInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.CreateWebhookInput
+	}
+	mock.lockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	mock.lockCreateWebhook.RUnlock()
+	return calls
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *WebhookUseCaseMock) DeleteWebhook(ctx context.Context, input usecase.DeleteWebhookInput) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.DeleteWebhookInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	mock.lockDeleteWebhook.Unlock()
+	if mock.DeleteWebhookFunc == nil {
+		var (
+			errOut error
+		)
+		return errOut
+	}
+	return mock.DeleteWebhookFunc(ctx, input)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//
+//	len(mockedWebhookUseCase.DeleteWebhookCalls())
+func (mock *WebhookUseCaseMock) DeleteWebhookCalls() []struct {
+	Ctx   context.Context
+	Input usecase.DeleteWebhookInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.DeleteWebhookInput
+	}
+	mock.lockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	mock.lockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// ListWebhookDeliveries calls ListWebhookDeliveriesFunc.
+func (mock *WebhookUseCaseMock) ListWebhookDeliveries(ctx context.Context, input usecase.ListWebhookDeliveriesInput) (usecase.ListWebhookDeliveriesOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.ListWebhookDeliveriesInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockListWebhookDeliveries.Lock()
+	mock.calls.ListWebhookDeliveries = append(mock.calls.ListWebhookDeliveries, callInfo)
+	mock.lockListWebhookDeliveries.Unlock()
+	if mock.ListWebhookDeliveriesFunc == nil {
+		var (
+			listWebhookDeliveriesOutputOut usecase.ListWebhookDeliveriesOutput
+			errOut                         error
+		)
+		return listWebhookDeliveriesOutputOut, errOut
+	}
+	return mock.ListWebhookDeliveriesFunc(ctx, input)
+}
+
+// ListWebhookDeliveriesCalls gets all the calls that were made to ListWebhookDeliveries.
+// Check the length with:
+//
+//	len(mockedWebhookUseCase.ListWebhookDeliveriesCalls())
+func (mock *WebhookUseCaseMock) ListWebhookDeliveriesCalls() []struct {
+	Ctx   context.Context
+	Input usecase.ListWebhookDeliveriesInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.ListWebhookDeliveriesInput
+	}
+	mock.lockListWebhookDeliveries.RLock()
+	calls = mock.calls.ListWebhookDeliveries
+	mock.lockListWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// ListWebhooks calls ListWebhooksFunc.
+func (mock *WebhookUseCaseMock) ListWebhooks(ctx context.Context, input usecase.ListWebhooksInput) (usecase.ListWebhooksOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.ListWebhooksInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockListWebhooks.Lock()
+	mock.calls.ListWebhooks = append(mock.calls.ListWebhooks, callInfo)
+	mock.lockListWebhooks.Unlock()
+	if mock.ListWebhooksFunc == nil {
+		var (
+			listWebhooksOutputOut usecase.ListWebhooksOutput
+			errOut                error
+		)
+		return listWebhooksOutputOut, errOut
+	}
+	return mock.ListWebhooksFunc(ctx, input)
+}
+
+// ListWebhooksCalls gets all the calls that were made to ListWebhooks.
+// Check the length with:
+//
+//	len(mockedWebhookUseCase.ListWebhooksCalls())
+func (mock *WebhookUseCaseMock) ListWebhooksCalls() []struct {
+	Ctx   context.Context
+	Input usecase.ListWebhooksInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.ListWebhooksInput
+	}
+	mock.lockListWebhooks.RLock()
+	calls = mock.calls.ListWebhooks
+	mock.lockListWebhooks.RUnlock()
+	return calls
+}
+
+// ReplayWebhookDelivery calls ReplayWebhookDeliveryFunc.
+func (mock *WebhookUseCaseMock) ReplayWebhookDelivery(ctx context.Context, input usecase.ReplayWebhookDeliveryInput) (usecase.ReplayWebhookDeliveryOutput, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Input usecase.ReplayWebhookDeliveryInput
+	}{
+		Ctx:   ctx,
+		Input: input,
+	}
+	mock.lockReplayWebhookDelivery.Lock()
+	mock.calls.ReplayWebhookDelivery = append(mock.calls.ReplayWebhookDelivery, callInfo)
+	mock.lockReplayWebhookDelivery.Unlock()
+	if mock.ReplayWebhookDeliveryFunc == nil {
+		var (
+			replayWebhookDeliveryOutputOut usecase.ReplayWebhookDeliveryOutput
+			errOut                         error
+		)
+		return replayWebhookDeliveryOutputOut, errOut
+	}
+	return mock.ReplayWebhookDeliveryFunc(ctx, input)
+}
+
+// ReplayWebhookDeliveryCalls gets all the calls that were made to ReplayWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedWebhookUseCase.ReplayWebhookDeliveryCalls())
+func (mock *WebhookUseCaseMock) ReplayWebhookDeliveryCalls() []struct {
+	Ctx   context.Context
+	Input usecase.ReplayWebhookDeliveryInput
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Input usecase.ReplayWebhookDeliveryInput
+	}
+	mock.lockReplayWebhookDelivery.RLock()
+	calls = mock.calls.ReplayWebhookDelivery
+	mock.lockReplayWebhookDelivery.RUnlock()
+	return calls
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+)
+
+//go:generate moq -stub -pkg mocks -out mocks/notifications_uc.go . NotificationUseCase
+
+type NotificationUseCase interface {
+	GetNotificationPreferences(ctx context.Context, accountID uuid.UUID) (usecase.GetNotificationPreferencesOutput, error)
+	UpdateNotificationPreferences(ctx context.Context, input usecase.UpdateNotificationPreferencesInput) (usecase.UpdateNotificationPreferencesOutput, error)
+}
+
+type NotificationController struct {
+	notificationUseCase NotificationUseCase
+}
+
+func NewNotificationController(notificationUseCase NotificationUseCase) NotificationController {
+	return NotificationController{notificationUseCase: notificationUseCase}
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+)
+
+// GetNotificationPreferences returns the notification preferences of the account.
+func (nController NotificationController) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+
+	ucOutput, err := nController.notificationUseCase.GetNotificationPreferences(ctx, accountID)
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	SendResponse(ctx, w, http.StatusOK, notificationPreferencesResponse(ucOutput.Preferences))
+}
+
+// UpdateNotificationPreferences replaces the notification preferences of the account.
+func (nController NotificationController) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req NotificationPreferencesRequest
+	if err := requests.ReadRequestBody(r, &req); err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+
+	channels := make([]entities.NotificationChannel, 0, len(req.Channels))
+	for _, c := range req.Channels {
+		channels = append(channels, entities.NotificationChannel(c))
+	}
+
+	events := make([]entities.NotificationEvent, 0, len(req.Events))
+	for _, e := range req.Events {
+		events = append(events, entities.NotificationEvent(e))
+	}
+
+	ucOutput, err := nController.notificationUseCase.UpdateNotificationPreferences(ctx, usecase.UpdateNotificationPreferencesInput{
+		AccountID:           accountID,
+		Locale:              entities.Locale(req.Locale),
+		Channels:            channels,
+		Events:              events,
+		Email:               req.Email,
+		Phone:               req.Phone,
+		LargeTransferAmount: req.LargeTransferAmount,
+	})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	SendResponse(ctx, w, http.StatusOK, notificationPreferencesResponse(ucOutput.Preferences))
+}
+
+func notificationPreferencesResponse(p entities.NotificationPreferences) NotificationPreferencesResponse {
+	resp := NotificationPreferencesResponse{
+		Locale:              string(p.Locale),
+		Channels:            make([]string, 0, len(p.Channels)),
+		Events:              make([]string, 0, len(p.Events)),
+		Email:               p.Email,
+		Phone:               p.Phone,
+		LargeTransferAmount: p.LargeTransferAmount,
+	}
+
+	for _, c := range p.Channels {
+		resp.Channels = append(resp.Channels, string(c))
+	}
+
+	for _, e := range p.Events {
+		resp.Events = append(resp.Events, string(e))
+	}
+
+	if !p.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &p.UpdatedAt
+	}
+
+	return resp
+}
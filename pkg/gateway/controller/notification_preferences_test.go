@@ -0,0 +1,138 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/server"
+)
+
+func TestNotificationController_NotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		notificationUseCase controller.NotificationUseCase
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		requestBody  *bytes.Reader
+		fields       fields
+		want         string
+		expectedCode int
+	}{
+		{
+			name:        "default preferences",
+			method:      http.MethodGet,
+			requestBody: bytes.NewReader(nil),
+			fields: fields{
+				notificationUseCase: &mocks.NotificationUseCaseMock{
+					GetNotificationPreferencesFunc: func(ctx context.Context, accountID uuid.UUID) (usecase.GetNotificationPreferencesOutput, error) {
+						return usecase.GetNotificationPreferencesOutput{
+							Preferences: entities.NotificationPreferences{
+								AccountID:           accountID,
+								Locale:              entities.LocalePtBR,
+								Channels:            []entities.NotificationChannel{entities.NotificationChannelPush},
+								Events:              entities.NotificationEvents,
+								LargeTransferAmount: 100000,
+							},
+						}, nil
+					},
+				},
+			},
+			want:         `{"locale":"pt-BR","channels":["push"],"events":["account.created","transfer.received","transfer.large_sent"],"email":"","phone":"","large_transfer_amount":100000}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "update with success",
+			method:      http.MethodPut,
+			requestBody: bytes.NewReader([]byte(`{"locale":"en","channels":["email"],"events":["transfer.received"],"email":"elliot@ecorp.com","large_transfer_amount":5000}`)),
+			fields: fields{
+				notificationUseCase: &mocks.NotificationUseCaseMock{
+					UpdateNotificationPreferencesFunc: func(ctx context.Context, input usecase.UpdateNotificationPreferencesInput) (usecase.UpdateNotificationPreferencesOutput, error) {
+						return usecase.UpdateNotificationPreferencesOutput{
+							Preferences: entities.NotificationPreferences{
+								AccountID:           input.AccountID,
+								Locale:              input.Locale,
+								Channels:            input.Channels,
+								Events:              input.Events,
+								Email:               input.Email,
+								LargeTransferAmount: input.LargeTransferAmount,
+								UpdatedAt:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+							},
+						}, nil
+					},
+				},
+			},
+			want:         `{"locale":"en","channels":["email"],"events":["transfer.received"],"email":"elliot@ecorp.com","phone":"","large_transfer_amount":5000,"updated_at":"2024-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "invalid preferences should return an error and status code 400",
+			method:      http.MethodPut,
+			requestBody: bytes.NewReader([]byte(`{"locale":"es","large_transfer_amount":5000}`)),
+			fields: fields{
+				notificationUseCase: &mocks.NotificationUseCaseMock{
+					UpdateNotificationPreferencesFunc: func(ctx context.Context, input usecase.UpdateNotificationPreferencesInput) (usecase.UpdateNotificationPreferencesOutput, error) {
+						return usecase.UpdateNotificationPreferencesOutput{}, domain.NewFieldError("locale", domain.CodeUnsupported, "must be one of [pt-BR en]")
+					},
+				},
+			},
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"locale","code":"unsupported","message":"must be one of [pt-BR en]"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			api := controller.API{
+				NotificationController: controller.NewNotificationController(tt.fields.notificationUseCase),
+			}
+
+			now := time.Now()
+			claims := &jwt.StandardClaims{
+				Issuer:    "login",
+				Subject:   "0457c690-f884-4d57-810c-85cf09a50d8b",
+				IssuedAt:  now.UTC().Unix(),
+				ExpiresAt: now.UTC().Add(time.Hour).Unix(),
+			}
+
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+			tokenString, err := token.SignedString([]byte("test_secret_key"))
+			require.NoError(t, err)
+
+			handler := server.HTTPHandler(zaptest.NewLogger(t), api, config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+			req := httptest.NewRequest(tt.method, "/api/v1/notifications/preferences", tt.requestBody)
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, strings.TrimSpace(tt.want), strings.TrimSpace(response.Body.String()))
+		})
+	}
+}
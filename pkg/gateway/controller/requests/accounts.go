@@ -0,0 +1,129 @@
+package requests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+)
+
+// The media types of the account imports.
+const (
+	// MediaTypeCSV is a CSV file whose header names the columns name, document and secret, in any order.
+	MediaTypeCSV = "text/csv"
+	// MediaTypeJSONL has a JSON object with the fields name, document and secret per line.
+	MediaTypeJSONL = "application/x-ndjson"
+)
+
+// importColumns are the columns of the CSV account imports.
+var importColumns = []string{"name", "document", "secret"}
+
+// ReadAccountImport reads the rows of an account import in the media type.
+// Returns domain.ErrInvalidParameter if the media type is not supported or the content is malformed.
+func ReadAccountImport(r io.Reader, mediaType string) ([]usecase.CreateAccountInput, error) {
+	var (
+		rows []usecase.CreateAccountInput
+		err  error
+	)
+	switch mediaType {
+	case MediaTypeCSV:
+		rows, err = readAccountImportCSV(r)
+	case MediaTypeJSONL:
+		rows, err = readAccountImportJSONL(r)
+	default:
+		return nil, domain.NewError(domain.ErrInvalidParameter, domain.CodeUnsupported, "unsupported media type %q", mediaType)
+	}
+	if err != nil {
+		return nil, invalidImport(err)
+	}
+
+	if len(rows) == 0 {
+		return nil, invalidImport(errors.New("no accounts to import"))
+	}
+
+	return rows, nil
+}
+
+// invalidImport describes why the import is malformed, unlike the errors of the JSON bodies,
+// so the client can find the line to fix.
+func invalidImport(err error) error {
+	return domain.NewError(domain.ErrInvalidParameter, domain.CodeInvalidRequestBody, "invalid request body: %s", err).WithCause(err)
+}
+
+func readAccountImportCSV(r io.Reader) ([]usecase.CreateAccountInput, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing header")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	index := make(map[string]int, len(importColumns))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(importColumns, column) {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+		index[column] = i
+	}
+
+	for _, column := range importColumns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+
+	var rows []usecase.CreateAccountInput
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err // nolint:wrapcheck
+		}
+
+		rows = append(rows, usecase.CreateAccountInput{
+			Name:     record[index["name"]],
+			Document: record[index["document"]],
+			Secret:   record[index["secret"]],
+		})
+	}
+}
+
+func readAccountImportJSONL(r io.Reader) ([]usecase.CreateAccountInput, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var rows []usecase.CreateAccountInput
+	for {
+		var row struct {
+			Name     string `json:"name"`
+			Document string `json:"document"`
+			Secret   string `json:"secret"`
+		}
+		err := dec.Decode(&row)
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", len(rows)+1, err)
+		}
+
+		rows = append(rows, usecase.CreateAccountInput{
+			Name:     row.Name,
+			Document: row.Document,
+			Secret:   row.Secret,
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package requests
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+)
+
+// PathParam returns the value of the route parameter.
+// The router is expected to populate the parameters through http.Request.SetPathValue,
+// as chi and net/http.ServeMux do, so the handlers don't depend on a specific router.
+func PathParam(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
+
+// PathUUID parses the route parameter as an uuid.
+// Returns domain.ErrInvalidParameter if the parameter is not a valid uuid.
+func PathUUID(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.FromString(PathParam(r, name))
+	if err != nil {
+		return uuid.Nil, domain.NewFieldError(name, domain.CodeInvalid, "invalid id")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,34 @@
+package requests
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+)
+
+// IfMatchVersion parses the If-Match header as the version of a resource, sent by the API in its ETag.
+// The ETag may be followed by a dash and the state of the resource that doesn't make a new version,
+// as the balance of an account, which is not compared.
+// Returns zero if the header is missing or is "*", which match any version.
+// Returns domain.ErrInvalidParameter if the header is not a single strong ETag of a version.
+func IfMatchVersion(r *http.Request) (int, error) {
+	h := strings.TrimSpace(r.Header.Get("If-Match"))
+	if h == "" || h == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(h)
+	if err != nil || !strings.HasPrefix(h, `"`) {
+		return 0, domain.NewFieldError("If-Match", domain.CodeInvalid, "must be an ETag of the resource")
+	}
+
+	unquoted, _, _ = strings.Cut(unquoted, "-")
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, domain.NewFieldError("If-Match", domain.CodeInvalid, "must be an ETag of the resource")
+	}
+
+	return version, nil
+}
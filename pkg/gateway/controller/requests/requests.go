@@ -2,16 +2,35 @@ package requests
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/higordasneves/e-corp/pkg/domain"
 )
 
+// ReadRequestBody decodes the JSON body of the request into obj.
+// The decoding is strict: unknown fields and data after the JSON value are rejected.
+// The size of the body is limited by the BodyLimit middleware, in which case the returned
+// error wraps an *http.MaxBytesError.
 func ReadRequestBody(r *http.Request, obj interface{}) error {
-	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
-		return fmt.Errorf("%w: invalid request body: %w", domain.ErrInvalidParameter, err)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(obj); err != nil {
+		return invalidBody(err)
+	}
+
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("unexpected data after the JSON value")
+		}
+		return invalidBody(err)
 	}
 
 	return nil
 }
+
+func invalidBody(err error) error {
+	return domain.NewError(domain.ErrInvalidParameter, domain.CodeInvalidRequestBody, "invalid request body").WithCause(err)
+}
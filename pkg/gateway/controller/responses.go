@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"go.uber.org/zap"
@@ -12,9 +13,11 @@ import (
 	"github.com/higordasneves/e-corp/utils/logger"
 )
 
-type ErrorResponse struct {
-	Err string `json:"error"`
-}
+// ProblemContentType is the media type of the error responses (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypePrefix prefixes the code of the error to build the problem type URI.
+const ProblemTypePrefix = "urn:ecorp:problem:"
 
 var ErrUnexpected = errors.New("internal server error")
 
@@ -28,32 +31,69 @@ func SendResponse(ctx context.Context, w http.ResponseWriter, statusCode int, da
 	}
 }
 
+// HandleError maps the error to a problem response.
+// Errors that are not domain errors are logged and returned as internal server errors.
 func HandleError(ctx context.Context, w http.ResponseWriter, err error) {
-	var statusCode int
+	SendProblem(ctx, w, Problem(ctx, err))
+}
+
+// Problem builds the problem response of the error.
+func Problem(ctx context.Context, err error) ProblemResponse {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewProblem(http.StatusRequestEntityTooLarge, domain.CodeRequestBodyTooLarge,
+			fmt.Sprintf("request body larger than %d bytes", maxBytesErr.Limit))
+	}
 
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		logger.Error(ctx, "an unexpected error occurred", zap.Error(err))
+		return NewProblem(http.StatusInternalServerError, domain.CodeInternal, ErrUnexpected.Error())
+	}
+
+	p := NewProblem(StatusCode(domainErr), domainErr.Code, domainErr.Message)
+	for _, f := range domainErr.Fields {
+		p.Errors = append(p.Errors, ProblemField{Field: f.Field, Code: f.Code, Message: f.Message})
+	}
+
+	return p
+}
+
+// StatusCode returns the http status code of the kind of the domain error.
+func StatusCode(err error) int {
 	switch {
 	case errors.Is(err, domain.ErrUnauthorized):
-		statusCode = http.StatusUnauthorized
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
 	case errors.Is(err, domain.ErrInvalidParameter):
-		statusCode = http.StatusBadRequest
+		return http.StatusBadRequest
 	case errors.Is(err, domain.ErrNotFound):
-		statusCode = http.StatusNotFound
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
 	default:
-		statusCode = http.StatusInternalServerError
-		logger.Error(ctx, "an unexpected error occurred", zap.Error(err))
-		err = ErrUnexpected
+		return http.StatusInternalServerError
 	}
-
-	SendError(ctx, w, statusCode, err)
 }
 
-func SendError(ctx context.Context, w http.ResponseWriter, statusCode int, err error) {
-	jsonError := errorJSON(err)
-	SendResponse(ctx, w, statusCode, jsonError)
+// SendProblem sends the problem response with the problem+json content type.
+func SendProblem(ctx context.Context, w http.ResponseWriter, p ProblemResponse) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		logger.Error(ctx, "an unexpected encoding error occurred", zap.Error(err))
+	}
 }
 
-func errorJSON(err error) ErrorResponse {
-	return ErrorResponse{
-		Err: err.Error(),
+// NewProblem returns the problem response of the status code.
+func NewProblem(status int, code domain.Code, detail string) ProblemResponse {
+	return ProblemResponse{
+		Type:   ProblemTypePrefix + string(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
 	}
 }
@@ -0,0 +1,84 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+)
+
+func TestHandleError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		err          error
+		want         string
+		expectedCode int
+	}{
+		{
+			name:         "wrapped typed error",
+			err:          fmt.Errorf("getting origin account balance: %w", domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account not exists")),
+			want:         `{"type":"urn:ecorp:problem:account_not_found","title":"Not Found","status":404,"detail":"account not exists","code":"account_not_found"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "validation error with multiple fields",
+			err: domain.NewValidationError(
+				domain.FieldError{Field: "name", Code: domain.CodeRequired, Message: "required field"},
+				domain.FieldError{Field: "document", Code: domain.CodeDocumentInvalid, Message: "the document must contain only numbers"},
+			),
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"name","code":"required","message":"required field"},{"field":"document","code":"document_invalid","message":"the document must contain only numbers"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "conflict",
+			err:          domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account already exists"),
+			want:         `{"type":"urn:ecorp:problem:account_already_exists","title":"Conflict","status":409,"detail":"account already exists","code":"account_already_exists"}`,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "untyped forbidden error uses the default code",
+			err:          fmt.Errorf("checking ownership: %w", domain.ErrForbidden),
+			want:         `{"type":"urn:ecorp:problem:forbidden","title":"Forbidden","status":403,"code":"forbidden"}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "unauthorized",
+			err:          domain.ErrUnauthorized,
+			want:         `{"type":"urn:ecorp:problem:unauthorized","title":"Unauthorized","status":401,"code":"unauthorized"}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "unknown error should not leak the message",
+			err:          errors.New("connection refused"),
+			want:         `{"type":"urn:ecorp:problem:internal_error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"internal_error"}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			response := httptest.NewRecorder()
+
+			// execute
+			controller.HandleError(context.Background(), response, tt.err)
+
+			// assert
+			assert.Equal(t, tt.want, strings.TrimSpace(response.Body.String()))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, controller.ProblemContentType, response.Header().Get("Content-Type"))
+		})
+	}
+}
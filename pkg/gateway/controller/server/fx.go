@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net/http"
 	"time"
@@ -10,28 +11,37 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/health"
 )
 
 var Module = fx.Module("httpserver",
 	fx.Invoke(
 		fx.Annotate(
-			func(ctx context.Context, lc fx.Lifecycle, l *zap.Logger, api API, cfg config.Config) (*http.Server, error) {
-				handler := HTTPHandler(l, api, cfg)
+			func(ctx context.Context, lc fx.Lifecycle, l *zap.Logger, api API, hr *health.Registry, cfg config.Config) (*http.Server, error) {
+				handler := WithHealth(HTTPHandler(l, api, cfg), hr)
 
 				server := http.Server{
 					Addr:              cfg.HTTP.Address + ":" + cfg.HTTP.Port,
 					Handler:           handler,
-					ReadTimeout:       time.Second * 30,
-					ReadHeaderTimeout: time.Second * 30,
-					WriteTimeout:      time.Second * 30,
+					ReadTimeout:       cfg.HTTP.ReadTimeout,
+					ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+					WriteTimeout:      cfg.HTTP.WriteTimeout,
+					IdleTimeout:       cfg.HTTP.IdleTimeout,
+					TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
 				}
 
 				lc.Append(fx.Hook{
 					OnStart: func(ctx context.Context) error {
 						go func() {
-							l.Info("HTTP server listening", zap.String("address", server.Addr))
+							l.Info("HTTP server listening", zap.String("address", server.Addr), zap.Bool("tls", cfg.HTTP.TLS()))
 
-							if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+							var err error
+							if cfg.HTTP.TLS() {
+								err = server.ListenAndServeTLS(cfg.HTTP.TLSCertFile, cfg.HTTP.TLSKeyFile)
+							} else {
+								err = server.ListenAndServe()
+							}
+							if !errors.Is(err, http.ErrServerClosed) {
 								l.Error("listening HTTP", zap.Error(err))
 							}
 						}()
@@ -39,6 +49,15 @@ var Module = fx.Module("httpserver",
 						return nil
 					},
 					OnStop: func(ctx context.Context) error {
+						// fail the readiness first, so no new requests are routed to the instance while it drains.
+						hr.Shutdown()
+						l.Info("readiness failing, waiting before shutting down HTTP server", zap.Duration("delay", cfg.Health.ShutdownDelay))
+
+						select {
+						case <-time.After(cfg.Health.ShutdownDelay):
+						case <-ctx.Done():
+						}
+
 						return server.Shutdown(ctx)
 					},
 				})
@@ -4,12 +4,13 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
-	http_swagger "github.com/swaggo/http-swagger"
+	httpSwagger "github.com/swaggo/http-swagger/v2"
 	"go.uber.org/zap"
 
-	_ "github.com/higordasneves/e-corp/docs/swagger"
+	"github.com/higordasneves/e-corp/api/openapi"
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
 	"github.com/higordasneves/e-corp/pkg/gateway/controller/middleware"
+	"github.com/higordasneves/e-corp/pkg/gateway/health"
 )
 
 type API interface {
@@ -17,44 +18,145 @@ type API interface {
 
 	GetBalance(w http.ResponseWriter, r *http.Request)
 	CreateAccount(w http.ResponseWriter, r *http.Request)
+	ImportAccounts(w http.ResponseWriter, r *http.Request)
 	ListAccounts(w http.ResponseWriter, r *http.Request)
+	GetAccount(w http.ResponseWriter, r *http.Request)
+	UpdateAccount(w http.ResponseWriter, r *http.Request)
+	StreamAccountEvents(w http.ResponseWriter, r *http.Request)
 
 	ListTransfers(w http.ResponseWriter, r *http.Request)
 	Transfer(w http.ResponseWriter, r *http.Request)
+
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	ListWebhookDeliveries(w http.ResponseWriter, r *http.Request)
+	ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request)
+
+	GetNotificationPreferences(w http.ResponseWriter, r *http.Request)
+	UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request)
 }
 
+// specPath is the path of the OpenAPI spec, also loaded by the swagger UI.
+const specPath = "/api/v1/openapi.json"
+
 // HTTPHandler returns HTTP handler with all routes.
+// The requests are validated against the OpenAPI spec, which must describe all the routes.
 func HTTPHandler(l *zap.Logger, api API, cfg config.Config) http.Handler {
+	spec := openapi.MustLoad()
+
 	chiRouter := chi.NewRouter()
-	chiRouter.Use(middleware.LoggerToContext(l))
+	chiRouter.Use(
+		middleware.Tracing,
+		middleware.LoggerToContext(l),
+		middleware.RequestID,
+		middleware.AccessLog,
+		middleware.Metrics,
+		middleware.SecurityHeaders(cfg.HTTP),
+		middleware.CORS(cfg.HTTP),
+		middleware.RateLimitIP(cfg.HTTP.IPRateLimit, cfg.HTTP.IPRateBurst, cfg.HTTP.TrustProxyHeaders),
+	)
 
-	chiRouter.Get("/healthcheck", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
+	// the imported files have their own limit, so the body limit is set by route.
+	bodyLimit := middleware.BodyLimit(cfg.HTTP.MaxBodyBytes)
+
+	// the validation runs after the authentication of the routes that require it.
+	validate := middleware.OpenAPI(spec, cfg.HTTP.ValidateResponses)
+
+	// the limit is shared by the routes, so the account has a single bucket.
+	accountRateLimit := middleware.RateLimitAccount(cfg.HTTP.AccountRateLimit, cfg.HTTP.AccountRateBurst)
 
 	apiVersion := "/api/v1"
 
 	chiRouter.Route(apiVersion, func(r chi.Router) {
+		r.Get("/openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(spec.JSON)
+		})
 		r.Route("/docs", func(r chi.Router) {
-			r.Get("/swagger/*", http_swagger.Handler())
+			r.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL(specPath)))
 		})
 
 		// login
-		r.Post("/login", api.Login)
+		r.With(bodyLimit, validate).Post("/login", api.Login)
 
 		r.Route("/accounts", func(r chi.Router) {
-			r.Post("/", api.CreateAccount)
-			r.Get("/", api.ListAccounts)
-			r.Get("/{account_id}/balance", api.GetBalance)
+			r.Group(func(r chi.Router) {
+				r.Use(bodyLimit, validate)
+				r.Post("/", api.CreateAccount)
+				r.Get("/", api.ListAccounts)
+				r.Get("/{account_id}/balance", api.GetBalance)
+			})
+			// every row of an import hashes a secret, so the imports are authenticated and rate limited.
+			r.With(
+				middleware.BodyLimit(cfg.Import.MaxBodyBytes),
+				middleware.Authenticate(cfg.Auth.SecretKey),
+				accountRateLimit,
+				validate,
+			).Post("/import", api.ImportAccounts)
+			r.Group(func(r chi.Router) {
+				r.Use(
+					bodyLimit,
+					middleware.Authenticate(cfg.Auth.SecretKey),
+					accountRateLimit,
+					validate,
+				)
+				r.Get("/me", api.GetAccount)
+				r.Patch("/me", api.UpdateAccount)
+				r.Get("/me/events", api.StreamAccountEvents)
+			})
 		})
 
 		// transfers
 		r.Route("/transfers", func(r chi.Router) {
-			r.Use(middleware.Authenticate(cfg.Auth.SecretKey))
+			r.Use(
+				bodyLimit,
+				middleware.Authenticate(cfg.Auth.SecretKey),
+				accountRateLimit,
+				validate,
+			)
 			r.Post("/", api.Transfer)
 			r.Get("/", api.ListTransfers)
 		})
+
+		// webhooks
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(
+				bodyLimit,
+				middleware.Authenticate(cfg.Auth.SecretKey),
+				accountRateLimit,
+				validate,
+			)
+			r.Post("/", api.CreateWebhook)
+			r.Get("/", api.ListWebhooks)
+			r.Delete("/{webhook_id}", api.DeleteWebhook)
+			r.Get("/{webhook_id}/deliveries", api.ListWebhookDeliveries)
+			r.Post("/{webhook_id}/deliveries/{delivery_id}/replay", api.ReplayWebhookDelivery)
+		})
+
+		// notifications
+		r.Route("/notifications", func(r chi.Router) {
+			r.Use(
+				bodyLimit,
+				middleware.Authenticate(cfg.Auth.SecretKey),
+				accountRateLimit,
+				validate,
+			)
+			r.Get("/preferences", api.GetNotificationPreferences)
+			r.Put("/preferences", api.UpdateNotificationPreferences)
+		})
 	})
 
 	return chiRouter
 }
+
+// WithHealth serves the liveness and readiness probes at /livez and /readyz and the other requests with h.
+// The probes skip the API middlewares, so they don't flood the access logs, traces and metrics.
+func WithHealth(h http.Handler, hr *health.Registry) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /livez", hr.Livez)
+	mux.HandleFunc("GET /readyz", hr.Readyz)
+	mux.Handle("/", h)
+
+	return mux
+}
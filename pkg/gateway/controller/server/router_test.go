@@ -0,0 +1,832 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/go-chi/chi/v5"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/higordasneves/e-corp/api/openapi"
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/server"
+	"github.com/higordasneves/e-corp/pkg/gateway/health"
+	"github.com/higordasneves/e-corp/utils/apictx"
+)
+
+var (
+	accountID  = uuid.FromStringOrNil("0457c690-f884-4d57-810c-85cf09a50d8b")
+	webhookID  = uuid.FromStringOrNil("8b07e65f-7fed-4387-ba84-d2213527c6f1")
+	deliveryID = uuid.FromStringOrNil("6ca1469e-1def-445c-b6ad-1028689d72f2")
+)
+
+// expectID returns a not found error if the id received by the use case is not the expected one,
+// which makes the test fail if a route parameter or the session subject doesn't reach the use case.
+func expectID(want, got uuid.UUID) error {
+	if want != got {
+		return fmt.Errorf("%w: got id %s, want %s", domain.ErrNotFound, got, want)
+	}
+
+	return nil
+}
+
+func newAPI() controller.API {
+	accUseCase := &mocks.AccountUseCaseMock{
+		CreateAccountFunc: func(ctx context.Context, input usecase.CreateAccountInput) (usecase.CreateAccountOutput, error) {
+			return usecase.CreateAccountOutput{Account: entities.Account{ID: accountID, Name: input.Name}}, nil
+		},
+		ImportAccountsFunc: func(ctx context.Context, input usecase.ImportAccountsInput) (usecase.ImportAccountsOutput, error) {
+			if len(input.Rows) != 2 {
+				return usecase.ImportAccountsOutput{}, domain.ErrInvalidParameter
+			}
+			return usecase.ImportAccountsOutput{
+				Accounts: []usecase.ImportedAccount{{Row: 1, Account: entities.Account{ID: accountID, Document: "44455566678"}}},
+				Failures: []usecase.ImportAccountFailure{{Row: 2, Err: domain.NewFieldError("name", domain.CodeRequired, "required field")}},
+			}, nil
+		},
+		GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+			return 100, expectID(accountID, id)
+		},
+		ListAccountsFunc: func(ctx context.Context, input usecase.ListAccountsInput) (usecase.ListAccountsOutput, error) {
+			if len(input.IDs) != 1 {
+				return usecase.ListAccountsOutput{}, domain.ErrInvalidParameter
+			}
+			return usecase.ListAccountsOutput{}, expectID(accountID, input.IDs[0])
+		},
+		GetAccountFunc: func(ctx context.Context, id uuid.UUID) (entities.Account, error) {
+			return entities.Account{ID: id, Version: 1}, expectID(accountID, id)
+		},
+		UpdateAccountFunc: func(ctx context.Context, input usecase.UpdateAccountInput) (usecase.UpdateAccountOutput, error) {
+			return usecase.UpdateAccountOutput{Account: entities.Account{ID: input.ID, Name: input.Name, Version: 2}}, expectID(accountID, input.ID)
+		},
+	}
+
+	eventsUseCase := &mocks.AccountEventsUseCaseMock{
+		GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+			return 100, expectID(accountID, id)
+		},
+	}
+
+	// the channel is closed, so the stream ends after the initial balance.
+	subscriber := &mocks.EventSubscriberMock{
+		SubscribeFunc: func(id uuid.UUID) (<-chan entities.AccountEvent, func()) {
+			events := make(chan entities.AccountEvent)
+			close(events)
+			return events, func() {}
+		},
+	}
+
+	tUseCase := &mocks.TransferUseCaseMock{
+		ListAccountTransfersFunc: func(ctx context.Context, input usecase.ListAccountTransfersInput) (usecase.ListAccountTransfersOutput, error) {
+			return usecase.ListAccountTransfersOutput{}, expectID(accountID, input.AccountID)
+		},
+		TransferFunc: func(ctx context.Context, input usecase.TransferInput) (usecase.TransferOutput, error) {
+			return usecase.TransferOutput{}, expectID(accountID, input.AccountOriginID)
+		},
+	}
+
+	wUseCase := &mocks.WebhookUseCaseMock{
+		CreateWebhookFunc: func(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error) {
+			return usecase.CreateWebhookOutput{}, expectID(accountID, input.AccountID)
+		},
+		ListWebhooksFunc: func(ctx context.Context, input usecase.ListWebhooksInput) (usecase.ListWebhooksOutput, error) {
+			return usecase.ListWebhooksOutput{}, expectID(accountID, input.AccountID)
+		},
+		DeleteWebhookFunc: func(ctx context.Context, input usecase.DeleteWebhookInput) error {
+			if err := expectID(accountID, input.AccountID); err != nil {
+				return err
+			}
+			return expectID(webhookID, input.SubscriptionID)
+		},
+		ListWebhookDeliveriesFunc: func(ctx context.Context, input usecase.ListWebhookDeliveriesInput) (usecase.ListWebhookDeliveriesOutput, error) {
+			if err := expectID(accountID, input.AccountID); err != nil {
+				return usecase.ListWebhookDeliveriesOutput{}, err
+			}
+			return usecase.ListWebhookDeliveriesOutput{}, expectID(webhookID, input.SubscriptionID)
+		},
+		ReplayWebhookDeliveryFunc: func(ctx context.Context, input usecase.ReplayWebhookDeliveryInput) (usecase.ReplayWebhookDeliveryOutput, error) {
+			if err := expectID(accountID, input.AccountID); err != nil {
+				return usecase.ReplayWebhookDeliveryOutput{}, err
+			}
+			if err := expectID(webhookID, input.SubscriptionID); err != nil {
+				return usecase.ReplayWebhookDeliveryOutput{}, err
+			}
+			return usecase.ReplayWebhookDeliveryOutput{}, expectID(deliveryID, input.DeliveryID)
+		},
+	}
+
+	nUseCase := &mocks.NotificationUseCaseMock{
+		GetNotificationPreferencesFunc: func(ctx context.Context, id uuid.UUID) (usecase.GetNotificationPreferencesOutput, error) {
+			return usecase.GetNotificationPreferencesOutput{}, expectID(accountID, id)
+		},
+		UpdateNotificationPreferencesFunc: func(ctx context.Context, input usecase.UpdateNotificationPreferencesInput) (usecase.UpdateNotificationPreferencesOutput, error) {
+			return usecase.UpdateNotificationPreferencesOutput{}, expectID(accountID, input.AccountID)
+		},
+	}
+
+	authUseCase := &mocks.AuthUseCaseMock{
+		LoginFunc: func(ctx context.Context, input usecase.LoginInput) (usecase.LoginOutput, error) {
+			now := time.Now()
+			return usecase.LoginOutput{AccountID: accountID, IssuedAt: now, ExpiresAt: now.Add(time.Hour)}, nil
+		},
+	}
+
+	return controller.API{
+		AuthController:          controller.NewAuthController(authUseCase, "test_secret_key"),
+		AccountController:       controller.NewAccountController(accUseCase),
+		AccountEventsController: controller.NewAccountEventsController(eventsUseCase, subscriber, time.Minute),
+		TransferController:      controller.NewTransferController(tUseCase),
+		WebhookController:       controller.NewWebhookController(wUseCase),
+		NotificationController:  controller.NewNotificationController(nUseCase),
+	}
+}
+
+func sessionToken(t *testing.T) string {
+	t.Helper()
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{
+		Issuer:    "login",
+		Subject:   accountID.String(),
+		IssuedAt:  now.UTC().Unix(),
+		ExpiresAt: now.UTC().Add(time.Hour).Unix(),
+	})
+	tokenString, err := token.SignedString([]byte("test_secret_key"))
+	require.NoError(t, err)
+
+	return tokenString
+}
+
+func TestHTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	tokenString := sessionToken(t)
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		contentType  string
+		authorized   bool
+		expectedCode int
+	}{
+		{
+			name:         "swagger",
+			method:       http.MethodGet,
+			target:       "/api/v1/docs/swagger/index.html",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "openapi spec",
+			method:       http.MethodGet,
+			target:       "/api/v1/openapi.json",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "login",
+			method:       http.MethodPost,
+			target:       "/api/v1/login",
+			body:         `{"document":"44455566678","secret":"12345678"}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "create account",
+			method:       http.MethodPost,
+			target:       "/api/v1/accounts",
+			body:         `{"name":"Elliot","document":"44455566678","secret":"12345678"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "import accounts from csv",
+			method:       http.MethodPost,
+			target:       "/api/v1/accounts/import",
+			body:         "name,document,secret\nElliot,44455566678,12345678\n,44455566679,12345678\n",
+			contentType:  "text/csv",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "import accounts from json lines",
+			method:       http.MethodPost,
+			target:       "/api/v1/accounts/import",
+			body:         `{"name":"Elliot","document":"44455566678","secret":"12345678"}` + "\n" + `{"name":"","document":"44455566679","secret":"12345678"}`,
+			contentType:  "application/x-ndjson",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "import accounts with missing column",
+			method:       http.MethodPost,
+			target:       "/api/v1/accounts/import",
+			body:         "name,document\nElliot,44455566678\n",
+			contentType:  "text/csv",
+			authorized:   true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "import accounts from json",
+			method:       http.MethodPost,
+			target:       "/api/v1/accounts/import",
+			body:         `[{"name":"Elliot","document":"44455566678","secret":"12345678"}]`,
+			authorized:   true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "import accounts without session",
+			method:       http.MethodPost,
+			target:       "/api/v1/accounts/import",
+			body:         "name,document,secret\nElliot,44455566678,12345678\n",
+			contentType:  "text/csv",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "list accounts",
+			method:       http.MethodGet,
+			target:       "/api/v1/accounts?page_size=10&ids=" + accountID.String(),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "get balance",
+			method:       http.MethodGet,
+			target:       "/api/v1/accounts/" + accountID.String() + "/balance",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "get balance with invalid account id",
+			method:       http.MethodGet,
+			target:       "/api/v1/accounts/invalid/balance",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "get account",
+			method:       http.MethodGet,
+			target:       "/api/v1/accounts/me",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "get account without session",
+			method:       http.MethodGet,
+			target:       "/api/v1/accounts/me",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "update account",
+			method:       http.MethodPatch,
+			target:       "/api/v1/accounts/me",
+			body:         `{"name":"Elliot Alderson"}`,
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "stream account events",
+			method:       http.MethodGet,
+			target:       "/api/v1/accounts/me/events",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "stream account events without session",
+			method:       http.MethodGet,
+			target:       "/api/v1/accounts/me/events",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "list transfers",
+			method:       http.MethodGet,
+			target:       "/api/v1/transfers",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "list transfers without session",
+			method:       http.MethodGet,
+			target:       "/api/v1/transfers",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "transfer",
+			method:       http.MethodPost,
+			target:       "/api/v1/transfers",
+			body:         `{"destination_id":"9751fe39-976f-4b3d-9611-d6c8c6370b0f","amount":100}`,
+			authorized:   true,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "transfer without session",
+			method:       http.MethodPost,
+			target:       "/api/v1/transfers",
+			body:         `{}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "create webhook",
+			method:       http.MethodPost,
+			target:       "/api/v1/webhooks",
+			body:         `{"url":"https://example.com/hook","event_types":["transfer.created"]}`,
+			authorized:   true,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "list webhooks",
+			method:       http.MethodGet,
+			target:       "/api/v1/webhooks",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "delete webhook",
+			method:       http.MethodDelete,
+			target:       "/api/v1/webhooks/" + webhookID.String(),
+			authorized:   true,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "list webhook deliveries",
+			method:       http.MethodGet,
+			target:       "/api/v1/webhooks/" + webhookID.String() + "/deliveries",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "replay webhook delivery",
+			method:       http.MethodPost,
+			target:       "/api/v1/webhooks/" + webhookID.String() + "/deliveries/" + deliveryID.String() + "/replay",
+			authorized:   true,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "replay webhook delivery with invalid delivery id",
+			method:       http.MethodPost,
+			target:       "/api/v1/webhooks/" + webhookID.String() + "/deliveries/invalid/replay",
+			authorized:   true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "get notification preferences",
+			method:       http.MethodGet,
+			target:       "/api/v1/notifications/preferences",
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "update notification preferences",
+			method:       http.MethodPut,
+			target:       "/api/v1/notifications/preferences",
+			body:         `{"locale":"en","channels":["push"],"events":["transfer.received"],"large_transfer_amount":100}`,
+			authorized:   true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unknown route",
+			method:       http.MethodGet,
+			target:       "/api/v1/unknown",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			core, logs := observer.New(zap.InfoLevel)
+			cfg := config.Config{
+				Auth: config.AuthConfig{SecretKey: "test_secret_key"},
+				HTTP: config.HTTP{ValidateResponses: true},
+			}
+			handler := server.HTTPHandler(zap.New(core), newAPI(), cfg)
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.authorized {
+				req.Header.Set("Authorization", "Bearer "+tokenString)
+			}
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code, response.Body.String())
+			// the handlers must respond as described by the OpenAPI spec.
+			for _, e := range logs.FilterMessage("response doesn't match the OpenAPI spec").All() {
+				t.Errorf("%s: %v", e.Message, e.ContextMap())
+			}
+		})
+	}
+}
+
+func TestHTTPHandler_AccessLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		requestID     string
+		target        string
+		authorized    bool
+		wantRequestID string
+		wantFields    map[string]any
+	}{
+		{
+			name:          "request id is accepted from the request",
+			requestID:     "req-123",
+			target:        "/api/v1/accounts/" + accountID.String() + "/balance",
+			wantRequestID: "req-123",
+			wantFields: map[string]any{
+				"request_id": "req-123",
+				"method":     http.MethodGet,
+				"route":      "/api/v1/accounts/{account_id}/balance",
+				"status":     int64(http.StatusOK),
+				"bytes":      int64(len(`{"balance":100}` + "\n")),
+			},
+		},
+		{
+			name:       "authenticated account is logged",
+			target:     "/api/v1/transfers",
+			authorized: true,
+			wantFields: map[string]any{
+				"route":      "/api/v1/transfers",
+				"status":     int64(http.StatusOK),
+				"account_id": accountID.String(),
+			},
+		},
+		{
+			name:      "invalid request id is replaced",
+			requestID: "invalid id\n",
+			target:    "/api/v1/accounts/invalid/balance",
+			wantFields: map[string]any{
+				"status": int64(http.StatusBadRequest),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			core, logs := observer.New(zap.InfoLevel)
+			handler := server.HTTPHandler(zap.New(core), newAPI(), config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.requestID != "" {
+				req.Header.Set(apictx.RequestIDHeader, tt.requestID)
+			}
+			if tt.authorized {
+				req.Header.Set("Authorization", "Bearer "+sessionToken(t))
+			}
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			requestID := response.Header().Get(apictx.RequestIDHeader)
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, requestID)
+			} else {
+				assert.NotEmpty(t, requestID)
+				assert.NotEqual(t, tt.requestID, requestID)
+			}
+
+			entries := logs.FilterMessage("http request").All()
+			require.Len(t, entries, 1)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, requestID, fields["request_id"])
+			for k, v := range tt.wantFields {
+				assert.Equal(t, v, fields[k], k)
+			}
+		})
+	}
+}
+
+func TestWithHealth(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	core, logs := observer.New(zap.InfoLevel)
+	failing := health.Check{Name: "postgres", Probe: func(context.Context) error { return errors.New("connection refused") }}
+	handler := server.WithHealth(
+		server.HTTPHandler(zap.New(core), newAPI(), config.Config{}),
+		health.NewRegistry(time.Second, failing),
+	)
+
+	tests := []struct {
+		name         string
+		target       string
+		expectedCode int
+	}{
+		{name: "liveness doesn't probe the dependencies", target: "/livez", expectedCode: http.StatusOK},
+		{name: "readiness fails with a dependency down", target: "/readyz", expectedCode: http.StatusServiceUnavailable},
+		{name: "other requests reach the API", target: "/api/v1/accounts/" + accountID.String() + "/balance", expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		// execute
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		// assert
+		assert.Equal(t, tt.expectedCode, response.Code, tt.name)
+	}
+
+	// only the API request is logged.
+	assert.Len(t, logs.FilterMessage("http request").All(), 1)
+}
+
+func TestHTTPHandler_Hardening(t *testing.T) {
+	t.Parallel()
+
+	loginBody := `{"document":"44455566678","secret":"12345678"}`
+	importBody := "name,document,secret\nElliot,44455566678,12345678\nDarlene,44455566679,12345678\n"
+	csvHeaders := map[string]string{"Content-Type": "text/csv"}
+
+	type request struct {
+		method     string
+		target     string
+		body       string
+		headers    map[string]string
+		authorized bool
+	}
+
+	tests := []struct {
+		name            string
+		cfg             config.HTTP
+		importCfg       config.ImportConfig
+		requests        []request
+		expectedCodes   []int
+		expectedCode    domain.Code
+		expectedHeaders map[string]string
+	}{
+		{
+			name:          "body larger than the limit",
+			cfg:           config.HTTP{MaxBodyBytes: 16},
+			requests:      []request{{method: http.MethodPost, target: "/api/v1/login", body: loginBody}},
+			expectedCodes: []int{http.StatusRequestEntityTooLarge},
+			expectedCode:  domain.CodeRequestBodyTooLarge,
+		},
+		{
+			name:      "imported files have their own body limit",
+			cfg:       config.HTTP{MaxBodyBytes: 16},
+			importCfg: config.ImportConfig{MaxBodyBytes: 128},
+			requests: []request{
+				{method: http.MethodPost, target: "/api/v1/accounts/import", body: importBody, headers: csvHeaders, authorized: true},
+				{method: http.MethodPost, target: "/api/v1/accounts/import", body: importBody + importBody, headers: csvHeaders, authorized: true},
+			},
+			expectedCodes: []int{http.StatusOK, http.StatusRequestEntityTooLarge},
+			expectedCode:  domain.CodeRequestBodyTooLarge,
+		},
+		{
+			name:          "unknown fields are rejected",
+			requests:      []request{{method: http.MethodPost, target: "/api/v1/login", body: `{"document":"44455566678","secret":"12345678","admin":true}`}},
+			expectedCodes: []int{http.StatusBadRequest},
+			expectedCode:  domain.CodeInvalidRequestBody,
+		},
+		{
+			name:          "trailing data is rejected",
+			requests:      []request{{method: http.MethodPost, target: "/api/v1/login", body: loginBody + `{}`}},
+			expectedCodes: []int{http.StatusBadRequest},
+			expectedCode:  domain.CodeInvalidRequestBody,
+		},
+		{
+			name: "requests above the ip rate are limited",
+			cfg:  config.HTTP{IPRateLimit: 0.001, IPRateBurst: 2},
+			requests: []request{
+				{method: http.MethodGet, target: "/api/v1/accounts/" + accountID.String() + "/balance"},
+				{method: http.MethodGet, target: "/api/v1/accounts/" + accountID.String() + "/balance"},
+				{method: http.MethodGet, target: "/api/v1/accounts/" + accountID.String() + "/balance"},
+			},
+			expectedCodes:   []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedCode:    domain.CodeRateLimited,
+			expectedHeaders: map[string]string{"Retry-After": "1000"},
+		},
+		{
+			name: "proxy header identifies the client when trusted",
+			cfg:  config.HTTP{IPRateLimit: 0.001, IPRateBurst: 1, TrustProxyHeaders: true},
+			requests: []request{
+				{method: http.MethodGet, target: "/api/v1/accounts/" + accountID.String() + "/balance", headers: map[string]string{"X-Forwarded-For": "10.0.0.1"}},
+				{method: http.MethodGet, target: "/api/v1/accounts/" + accountID.String() + "/balance", headers: map[string]string{"X-Forwarded-For": "10.0.0.2"}},
+			},
+			expectedCodes: []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			name: "requests above the account rate are limited across routes",
+			cfg:  config.HTTP{AccountRateLimit: 0.001, AccountRateBurst: 1},
+			requests: []request{
+				{method: http.MethodGet, target: "/api/v1/transfers", authorized: true},
+				{method: http.MethodGet, target: "/api/v1/webhooks", authorized: true},
+			},
+			expectedCodes: []int{http.StatusOK, http.StatusTooManyRequests},
+			expectedCode:  domain.CodeRateLimited,
+		},
+		{
+			name: "cors preflight of an allowed origin",
+			cfg: config.HTTP{
+				CORSAllowedOrigins: []string{"https://app.ecorp.com"},
+				CORSAllowedMethods: []string{http.MethodGet, http.MethodPost},
+				CORSAllowedHeaders: []string{"Authorization", "Content-Type"},
+			},
+			requests: []request{{method: http.MethodOptions, target: "/api/v1/transfers", headers: map[string]string{
+				"Origin":                        "https://app.ecorp.com",
+				"Access-Control-Request-Method": http.MethodPost,
+			}}},
+			expectedCodes:   []int{http.StatusOK},
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": "https://app.ecorp.com"},
+		},
+		{
+			name: "security headers",
+			cfg:  config.HTTP{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem", HSTSMaxAge: time.Hour},
+			requests: []request{
+				{method: http.MethodGet, target: "/api/v1/accounts/" + accountID.String() + "/balance"},
+			},
+			expectedCodes: []int{http.StatusOK},
+			expectedHeaders: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"Strict-Transport-Security": "max-age=3600; includeSubDomains",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			cfg := config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}, HTTP: tt.cfg, Import: tt.importCfg}
+			handler := server.HTTPHandler(zaptest.NewLogger(t), newAPI(), cfg)
+
+			// execute
+			var response *httptest.ResponseRecorder
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(r.method, r.target, strings.NewReader(r.body))
+				for k, v := range r.headers {
+					req.Header.Set(k, v)
+				}
+				if r.authorized {
+					req.Header.Set("Authorization", "Bearer "+sessionToken(t))
+				}
+				response = httptest.NewRecorder()
+				handler.ServeHTTP(response, req)
+
+				// assert
+				assert.Equal(t, tt.expectedCodes[i], response.Code, response.Body.String())
+			}
+
+			if tt.expectedCode != "" {
+				var problem controller.ProblemResponse
+				require.NoError(t, json.NewDecoder(response.Body).Decode(&problem))
+				assert.Equal(t, tt.expectedCode, problem.Code)
+			}
+			for k, v := range tt.expectedHeaders {
+				assert.Equal(t, v, response.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestHTTPHandler_MatchesOpenAPISpec(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	specRoutes := map[string]bool{}
+	for path, item := range spec.Doc.Paths.Map() {
+		for method := range item.Operations() {
+			specRoutes[method+" "+path] = true
+		}
+	}
+
+	routes, ok := server.HTTPHandler(zaptest.NewLogger(t), newAPI(), config.Config{}).(chi.Routes)
+	require.True(t, ok)
+
+	// execute
+	served := map[string]bool{}
+	err = chi.Walk(routes, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// the swagger UI serves its own files.
+		if strings.HasPrefix(route, "/api/v1/docs/") {
+			return nil
+		}
+		served[method+" "+strings.TrimSuffix(route, "/")] = true
+		return nil
+	})
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, specRoutes, served)
+}
+
+func TestHTTPHandler_RequestValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedCode   domain.Code
+		expectedFields []controller.ProblemField
+	}{
+		{
+			name:         "missing fields are required",
+			method:       http.MethodPost,
+			target:       "/api/v1/transfers",
+			body:         `{}`,
+			expectedCode: domain.CodeValidationFailed,
+			expectedFields: []controller.ProblemField{
+				{Field: "amount", Code: domain.CodeRequired, Message: "required field"},
+				{Field: "destination_id", Code: domain.CodeRequired, Message: "required field"},
+			},
+		},
+		{
+			name:         "values of the wrong type are invalid",
+			method:       http.MethodPost,
+			target:       "/api/v1/transfers",
+			body:         `{"destination_id":"9751fe39-976f-4b3d-9611-d6c8c6370b0f","amount":"100"}`,
+			expectedCode: domain.CodeValidationFailed,
+			expectedFields: []controller.ProblemField{
+				{Field: "amount", Code: domain.CodeInvalid, Message: "value must be an integer"},
+			},
+		},
+		{
+			name:         "nested values are identified by their path",
+			method:       http.MethodPut,
+			target:       "/api/v1/notifications/preferences",
+			body:         `{"channels":["push",1]}`,
+			expectedCode: domain.CodeValidationFailed,
+			expectedFields: []controller.ProblemField{
+				{Field: "channels.1", Code: domain.CodeInvalid, Message: "value must be a string"},
+			},
+		},
+		{
+			name:         "query parameters are validated",
+			method:       http.MethodGet,
+			target:       "/api/v1/webhooks/" + webhookID.String() + "/deliveries?page_size=ten",
+			expectedCode: domain.CodeValidationFailed,
+			expectedFields: []controller.ProblemField{
+				{Field: "page_size", Code: domain.CodeInvalid, Message: "must be an integer"},
+			},
+		},
+		{
+			name:         "empty body",
+			method:       http.MethodPost,
+			target:       "/api/v1/transfers",
+			expectedCode: domain.CodeInvalidRequestBody,
+		},
+		{
+			name:         "malformed body",
+			method:       http.MethodPost,
+			target:       "/api/v1/transfers",
+			body:         `{"amount":`,
+			expectedCode: domain.CodeInvalidRequestBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			api := newAPI()
+			tUseCase := &mocks.TransferUseCaseMock{}
+			api.TransferController = controller.NewTransferController(tUseCase)
+			handler := server.HTTPHandler(zaptest.NewLogger(t), api, config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+sessionToken(t))
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, http.StatusBadRequest, response.Code, response.Body.String())
+			var problem controller.ProblemResponse
+			require.NoError(t, json.NewDecoder(response.Body).Decode(&problem))
+			assert.Equal(t, tt.expectedCode, problem.Code)
+			assert.ElementsMatch(t, tt.expectedFields, problem.Errors)
+			assert.Empty(t, tUseCase.TransferCalls())
+		})
+	}
+}
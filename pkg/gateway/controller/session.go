@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+)
+
+// NewSessionToken signs the session token of the login, with the account id as subject.
+func NewSessionToken(output usecase.LoginOutput, secretKey string) (string, error) {
+	claims := &jwt.StandardClaims{
+		Issuer:    "login",
+		Subject:   output.AccountID.String(),
+		IssuedAt:  output.IssuedAt.Unix(),
+		ExpiresAt: output.ExpiresAt.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(secretKey))
+	if err != nil {
+		return "", fmt.Errorf("signing session token: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// ParseSessionToken validates the session token and returns its subject, the account id.
+// Returns domain.ErrUnauthorized if the token doesn't match or if it has expired.
+func ParseSessionToken(tokenString, secretKey string) (string, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		return "", domain.ErrUnauthorized
+	}
+
+	claims, ok := token.Claims.(*jwt.StandardClaims)
+	if !(ok && token.Valid) {
+		return "", domain.ErrUnauthorized
+	}
+
+	return claims.Subject, nil
+}
@@ -3,7 +3,6 @@ package controller_test
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -99,7 +98,7 @@ func TestTransferController_ListTransfers(t *testing.T) {
 				},
 			},
 			args:         args{ctxWithValue: context.WithValue(context.Background(), "subject", "uuid_acc1")},
-			want:         fmt.Sprintf(`{"error":"%s"}`, controller.ErrUnexpected),
+			want:         `{"type":"urn:ecorp:problem:internal_error","title":"Internal Server Error","status":500,"detail":"internal server error","code":"internal_error"}`,
 			expectedCode: http.StatusInternalServerError,
 		},
 	}
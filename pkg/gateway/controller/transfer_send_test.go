@@ -73,15 +73,15 @@ func TestTransferController_Transfer(t *testing.T) {
 			fields: fields{
 				tUseCase: &mocks.TransferUseCaseMock{
 					TransferFunc: func(ctx context.Context, input usecase.TransferInput) (usecase.TransferOutput, error) {
-						return usecase.TransferOutput{}, domain.ErrInvalidParameter
+						return usecase.TransferOutput{}, domain.NewError(domain.ErrInvalidParameter, domain.CodeSameAccountTransfer, "the destination account must be different from the origin account")
 					},
 				},
 			},
 			args: args{
 				ctxWithValue: context.WithValue(context.Background(), "subject", "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d"),
-				requestBody:  bytes.NewReader([]byte(`{"destinationID": "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d", "amount": 10}`)),
+				requestBody:  bytes.NewReader([]byte(`{"destination_id": "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d", "amount": 10}`)),
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:same_account_transfer","title":"Bad Request","status":400,"detail":"the destination account must be different from the origin account","code":"same_account_transfer"}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -95,9 +95,9 @@ func TestTransferController_Transfer(t *testing.T) {
 			},
 			args: args{
 				ctxWithValue: context.WithValue(context.Background(), "subject", "invalid"),
-				requestBody:  bytes.NewReader([]byte(`{"destinationID": "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d", "amount": 10}`)),
+				requestBody:  bytes.NewReader([]byte(`{"destination_id": "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d", "amount": 10}`)),
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:invalid_parameter","title":"Bad Request","status":400,"code":"invalid_parameter"}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -105,15 +105,15 @@ func TestTransferController_Transfer(t *testing.T) {
 			fields: fields{
 				tUseCase: &mocks.TransferUseCaseMock{
 					TransferFunc: func(ctx context.Context, input usecase.TransferInput) (usecase.TransferOutput, error) {
-						return usecase.TransferOutput{}, domain.ErrInvalidParameter
+						return usecase.TransferOutput{}, domain.NewFieldError("amount", domain.CodeMustBePositive, "invalid transfer amount, the amount must be greater than 0")
 					},
 				},
 			},
 			args: args{
 				ctxWithValue: context.WithValue(context.Background(), "subject", "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d"),
-				requestBody:  bytes.NewReader([]byte(`{"destinationID": "5f2d4920-89c3-4ed5-af8e-1d411588746d", "amount": -10}`)),
+				requestBody:  bytes.NewReader([]byte(`{"destination_id": "5f2d4920-89c3-4ed5-af8e-1d411588746d", "amount": -10}`)),
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"amount","code":"must_be_positive","message":"invalid transfer amount, the amount must be greater than 0"}]}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -121,15 +121,15 @@ func TestTransferController_Transfer(t *testing.T) {
 			fields: fields{
 				tUseCase: &mocks.TransferUseCaseMock{
 					TransferFunc: func(ctx context.Context, input usecase.TransferInput) (usecase.TransferOutput, error) {
-						return usecase.TransferOutput{}, domain.ErrInvalidParameter
+						return usecase.TransferOutput{}, domain.NewError(domain.ErrInvalidParameter, domain.CodeInsufficientFunds, "insufficient funds")
 					},
 				},
 			},
 			args: args{
 				ctxWithValue: context.WithValue(context.Background(), "subject", "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d"),
-				requestBody:  bytes.NewReader([]byte(`{"destinationID": "5f2d4920-89c3-4ed5-af8e-1d411588746d", "amount": 1000000000000000000}`)),
+				requestBody:  bytes.NewReader([]byte(`{"destination_id": "5f2d4920-89c3-4ed5-af8e-1d411588746d", "amount": 1000000000000000000}`)),
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrInvalidParameter),
+			want:         `{"type":"urn:ecorp:problem:insufficient_funds","title":"Bad Request","status":400,"detail":"insufficient funds","code":"insufficient_funds"}`,
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -137,15 +137,15 @@ func TestTransferController_Transfer(t *testing.T) {
 			fields: fields{
 				tUseCase: &mocks.TransferUseCaseMock{
 					TransferFunc: func(ctx context.Context, input usecase.TransferInput) (usecase.TransferOutput, error) {
-						return usecase.TransferOutput{}, domain.ErrNotFound
+						return usecase.TransferOutput{}, fmt.Errorf("getting destination account balance: %w", domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account 9751fe39-976f-4b3d-9611-d6c8c6370b0f not exists"))
 					},
 				},
 			},
 			args: args{
 				ctxWithValue: context.WithValue(context.Background(), "subject", "b59c5660-d62f-4f3e-91b4-5f8e236e5d3d"),
-				requestBody:  bytes.NewReader([]byte(`{"destinationID": "5f2d4920-89c3-4ed5-af8e-1d411588746d", "amount": 1000000000000000000}`)),
+				requestBody:  bytes.NewReader([]byte(`{"destination_id": "5f2d4920-89c3-4ed5-af8e-1d411588746d", "amount": 1000000000000000000}`)),
 			},
-			want:         fmt.Sprintf(`{"error":"%s"}`, domain.ErrNotFound),
+			want:         `{"type":"urn:ecorp:problem:account_not_found","title":"Not Found","status":404,"detail":"account 9751fe39-976f-4b3d-9611-d6c8c6370b0f not exists","code":"account_not_found"}`,
 			expectedCode: http.StatusNotFound,
 		},
 	}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+)
+
+//go:generate moq -stub -pkg mocks -out mocks/webhooks_uc.go . WebhookUseCase
+
+type WebhookUseCase interface {
+	CreateWebhook(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error)
+	ListWebhooks(ctx context.Context, input usecase.ListWebhooksInput) (usecase.ListWebhooksOutput, error)
+	DeleteWebhook(ctx context.Context, input usecase.DeleteWebhookInput) error
+	ListWebhookDeliveries(ctx context.Context, input usecase.ListWebhookDeliveriesInput) (usecase.ListWebhookDeliveriesOutput, error)
+	ReplayWebhookDelivery(ctx context.Context, input usecase.ReplayWebhookDeliveryInput) (usecase.ReplayWebhookDeliveryOutput, error)
+}
+
+type WebhookController struct {
+	webhookUseCase WebhookUseCase
+}
+
+func NewWebhookController(webhookUseCase WebhookUseCase) WebhookController {
+	return WebhookController{webhookUseCase: webhookUseCase}
+}
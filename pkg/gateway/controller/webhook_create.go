@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+)
+
+// CreateWebhook subscribes the account to receive events over HTTP.
+func (wController WebhookController) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req CreateWebhookRequest
+	if err := requests.ReadRequestBody(r, &req); err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+
+	eventTypes := make([]entities.WebhookEventType, 0, len(req.EventTypes))
+	for _, e := range req.EventTypes {
+		eventTypes = append(eventTypes, entities.WebhookEventType(e))
+	}
+
+	ucOutput, err := wController.webhookUseCase.CreateWebhook(ctx, usecase.CreateWebhookInput{
+		AccountID:  accountID,
+		URL:        req.URL,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	sub := ucOutput.Subscription
+	SendResponse(ctx, w, http.StatusCreated, CreateWebhookResponse{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: webhookEventTypes(sub.EventTypes),
+		Secret:     sub.Secret,
+		CreatedAt:  sub.CreatedAt,
+	})
+}
+
+func webhookEventTypes(eventTypes []entities.WebhookEventType) []string {
+	resp := make([]string, 0, len(eventTypes))
+	for _, e := range eventTypes {
+		resp = append(resp, string(e))
+	}
+
+	return resp
+}
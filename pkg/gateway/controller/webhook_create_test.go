@@ -0,0 +1,112 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/server"
+)
+
+func TestWebhookController_CreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		webhookUseCase controller.WebhookUseCase
+	}
+
+	tests := []struct {
+		name         string
+		requestBody  *bytes.Reader
+		fields       fields
+		want         string
+		expectedCode int
+	}{
+		{
+			name:        "with success",
+			requestBody: bytes.NewReader([]byte(`{"url":"https://partner.example.com/hooks", "event_types":["transfer.received"]}`)),
+			fields: fields{
+				webhookUseCase: &mocks.WebhookUseCaseMock{
+					CreateWebhookFunc: func(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error) {
+						return usecase.CreateWebhookOutput{
+							Subscription: entities.WebhookSubscription{
+								ID:         uuid.FromStringOrNil("5f2d4920-89c3-4ed5-af8e-1d411588746d"),
+								AccountID:  input.AccountID,
+								URL:        input.URL,
+								EventTypes: input.EventTypes,
+								Secret:     "whsec_123",
+								CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+							},
+						}, nil
+					},
+				},
+			},
+			want:         `{"id":"5f2d4920-89c3-4ed5-af8e-1d411588746d","url":"https://partner.example.com/hooks","event_types":["transfer.received"],"secret":"whsec_123","created_at":"2024-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:        "invalid url should return an error and status code 400",
+			requestBody: bytes.NewReader([]byte(`{"url":"partner", "event_types":["transfer.received"]}`)),
+			fields: fields{
+				webhookUseCase: &mocks.WebhookUseCaseMock{
+					CreateWebhookFunc: func(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error) {
+						return usecase.CreateWebhookOutput{}, domain.NewFieldError("url", domain.CodeInvalid, "must be an absolute http or https url")
+					},
+				},
+			},
+			want:         `{"type":"urn:ecorp:problem:validation_failed","title":"Bad Request","status":400,"code":"validation_failed","errors":[{"field":"url","code":"invalid","message":"must be an absolute http or https url"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			api := controller.API{
+				WebhookController: controller.NewWebhookController(tt.fields.webhookUseCase),
+			}
+
+			now := time.Now()
+			claims := &jwt.StandardClaims{
+				Issuer:    "login",
+				Subject:   "0457c690-f884-4d57-810c-85cf09a50d8b",
+				IssuedAt:  now.UTC().Unix(),
+				ExpiresAt: now.UTC().Add(time.Hour).Unix(),
+			}
+
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+			tokenString, err := token.SignedString([]byte("test_secret_key"))
+			require.NoError(t, err)
+
+			handler := server.HTTPHandler(zaptest.NewLogger(t), api, config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", tt.requestBody)
+			req.Header.Set("Authorization", "Bearer "+tokenString)
+			response := httptest.NewRecorder()
+
+			// execute
+			handler.ServeHTTP(response, req)
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, strings.TrimSpace(tt.want), strings.TrimSpace(response.Body.String()))
+		})
+	}
+}
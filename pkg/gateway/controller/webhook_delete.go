@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+)
+
+// DeleteWebhook deletes a webhook subscription of the account.
+func (wController WebhookController) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+	webhookID, err := requests.PathUUID(r, "webhook_id")
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	err = wController.webhookUseCase.DeleteWebhook(ctx, usecase.DeleteWebhookInput{
+		AccountID:      accountID,
+		SubscriptionID: webhookID,
+	})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
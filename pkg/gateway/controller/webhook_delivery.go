@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/requests"
+	"github.com/higordasneves/e-corp/utils/pagination"
+)
+
+// ListWebhookDeliveries lists the most recent deliveries of a webhook in desc order.
+func (wController WebhookController) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+	webhookID, err := requests.PathUUID(r, "webhook_id")
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	pageSize := r.URL.Query().Get("page_size")
+	if pageSize == "" {
+		pageSize = "0"
+	}
+	size, err := strconv.Atoi(pageSize)
+	if err != nil {
+		HandleError(ctx, w, domain.NewFieldError("page_size", domain.CodeInvalid, "must be an integer"))
+		return
+	}
+
+	ucOutput, err := wController.webhookUseCase.ListWebhookDeliveries(ctx, usecase.ListWebhookDeliveriesInput{
+		AccountID:      accountID,
+		SubscriptionID: webhookID,
+		PageSize:       pagination.ValidatePageSize(uint32(size)), // nolint:gosec
+	})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	resp := make([]WebhookDeliveryResponse, 0, len(ucOutput.Deliveries))
+	for _, d := range ucOutput.Deliveries {
+		resp = append(resp, webhookDeliveryResponse(d))
+	}
+
+	SendResponse(ctx, w, http.StatusOK, ListWebhookDeliveriesResponse{Deliveries: resp})
+}
+
+// ReplayWebhookDelivery schedules a new delivery of the event to the webhook.
+func (wController WebhookController) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+	webhookID, err := requests.PathUUID(r, "webhook_id")
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	deliveryID, err := requests.PathUUID(r, "delivery_id")
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	ucOutput, err := wController.webhookUseCase.ReplayWebhookDelivery(ctx, usecase.ReplayWebhookDeliveryInput{
+		AccountID:      accountID,
+		SubscriptionID: webhookID,
+		DeliveryID:     deliveryID,
+	})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	SendResponse(ctx, w, http.StatusAccepted, webhookDeliveryResponse(ucOutput.Delivery))
+}
+
+func webhookDeliveryResponse(d entities.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
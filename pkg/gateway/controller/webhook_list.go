@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+)
+
+// ListWebhooks lists the webhook subscriptions of the account in desc order.
+func (wController WebhookController) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accountID := uuid.FromStringOrNil(fmt.Sprint(r.Context().Value("subject")))
+
+	ucOutput, err := wController.webhookUseCase.ListWebhooks(ctx, usecase.ListWebhooksInput{AccountID: accountID})
+	if err != nil {
+		HandleError(ctx, w, err)
+		return
+	}
+
+	resp := make([]ListWebhooksResponseItem, 0, len(ucOutput.Subscriptions))
+	for _, sub := range ucOutput.Subscriptions {
+		resp = append(resp, ListWebhooksResponseItem{
+			ID:         sub.ID,
+			URL:        sub.URL,
+			EventTypes: webhookEventTypes(sub.EventTypes),
+			CreatedAt:  sub.CreatedAt,
+		})
+	}
+
+	SendResponse(ctx, w, http.StatusOK, ListWebhooksResponse{Webhooks: resp})
+}
@@ -0,0 +1,115 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/grpcserver/ecorpv1"
+	"github.com/higordasneves/e-corp/utils/pagination"
+)
+
+type AccountService struct {
+	ecorpv1.UnimplementedAccountServiceServer
+
+	accUseCase controller.AccountUseCase
+}
+
+func NewAccountService(accUseCase controller.AccountUseCase) AccountService {
+	return AccountService{accUseCase: accUseCase}
+}
+
+// CreateAccount creates a banking account.
+func (s AccountService) CreateAccount(ctx context.Context, req *ecorpv1.CreateAccountRequest) (*ecorpv1.CreateAccountResponse, error) {
+	output, err := s.accUseCase.CreateAccount(ctx, usecase.CreateAccountInput{
+		Name:     req.GetName(),
+		Document: req.GetDocument(),
+		Secret:   req.GetSecret(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecorpv1.CreateAccountResponse{Account: newAccount(output.Account)}, nil
+}
+
+// GetBalance returns the current balance of the account.
+func (s AccountService) GetBalance(ctx context.Context, req *ecorpv1.GetBalanceRequest) (*ecorpv1.GetBalanceResponse, error) {
+	id, err := parseUUID("account_id", req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.accUseCase.GetBalance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecorpv1.GetBalanceResponse{Balance: int64(balance)}, nil
+}
+
+// ListAccounts lists the accounts by id.
+func (s AccountService) ListAccounts(ctx context.Context, req *ecorpv1.ListAccountsRequest) (*ecorpv1.ListAccountsResponse, error) {
+	var input usecase.ListAccountsInput
+	if req.GetPageToken() != "" {
+		if err := pagination.Extract(req.GetPageToken(), &input); err != nil {
+			return nil, domain.NewFieldError("page_token", domain.CodeInvalidPageToken, "invalid page token")
+		}
+	} else {
+		for _, v := range req.GetIds() {
+			id, err := uuid.FromString(v)
+			if err != nil {
+				return nil, domain.NewFieldError("ids", domain.CodeInvalid, fmt.Sprintf("invalid account id %q", v))
+			}
+			input.IDs = append(input.IDs, id)
+		}
+		input.PageSize = pagination.ValidatePageSize(req.GetPageSize())
+	}
+
+	output, err := s.accUseCase.ListAccounts(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ecorpv1.ListAccountsResponse{Accounts: make([]*ecorpv1.Account, 0, len(output.Accounts))}
+	for _, acc := range output.Accounts {
+		resp.Accounts = append(resp.Accounts, newAccount(acc))
+	}
+
+	if output.NextPage != nil {
+		token, err := pagination.NewToken(*output.NextPage)
+		if err != nil {
+			return nil, fmt.Errorf("creating page token: %w", err)
+		}
+		resp.NextPageToken = token
+	}
+
+	return resp, nil
+}
+
+func newAccount(acc entities.Account) *ecorpv1.Account {
+	return &ecorpv1.Account{
+		Id:        acc.ID.String(),
+		Name:      acc.Name,
+		Document:  acc.Document.String(),
+		Balance:   int64(acc.Balance),
+		CreatedAt: timestamppb.New(acc.CreatedAt),
+	}
+}
+
+// parseUUID parses the id of the request field.
+// Returns domain.ErrInvalidParameter if it is not a valid uuid.
+func parseUUID(field, v string) (uuid.UUID, error) {
+	id, err := uuid.FromString(v)
+	if err != nil {
+		return uuid.Nil, domain.NewFieldError(field, domain.CodeInvalid, "invalid id")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/grpcserver/ecorpv1"
+	"github.com/higordasneves/e-corp/pkg/gateway/metrics"
+)
+
+type AuthService struct {
+	ecorpv1.UnimplementedAuthServiceServer
+
+	authUseCase controller.AuthUseCase
+	secretKey   string
+}
+
+func NewAuthService(authUseCase controller.AuthUseCase, secretKey string) AuthService {
+	return AuthService{authUseCase: authUseCase, secretKey: secretKey}
+}
+
+// Login validates the credentials of an account and returns a session token.
+func (s AuthService) Login(ctx context.Context, req *ecorpv1.LoginRequest) (*ecorpv1.LoginResponse, error) {
+	output, err := s.authUseCase.Login(ctx, usecase.LoginInput{
+		Document: vos.Document(req.GetDocument()),
+		Secret:   req.GetSecret(),
+	})
+	if err != nil {
+		code := domain.CodeInternal
+		if domainErr, ok := domain.AsError(err); ok {
+			code = domainErr.Code
+		}
+		metrics.FailedLogins.WithLabelValues(string(code)).Inc()
+		return nil, err
+	}
+
+	token, err := controller.NewSessionToken(output, s.secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecorpv1.LoginResponse{Token: token, ExpiresAt: timestamppb.New(output.ExpiresAt)}, nil
+}
@@ -0,0 +1,62 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+)
+
+// Module serves the gRPC API on its own port.
+var Module = fx.Module("grpcserver",
+	fx.Invoke(
+		func(lc fx.Lifecycle, l *zap.Logger, uc controller.UseCases, cfg config.Config) error {
+			server, err := NewServer(l, uc, cfg)
+			if err != nil {
+				return err
+			}
+			addr := cfg.GRPC.Address + ":" + cfg.GRPC.Port
+
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					lis, err := net.Listen("tcp", addr)
+					if err != nil {
+						return fmt.Errorf("listening gRPC: %w", err)
+					}
+
+					go func() {
+						l.Info("gRPC server listening", zap.String("address", addr), zap.Bool("tls", cfg.HTTP.TLS()))
+
+						if err := server.Serve(lis); err != nil {
+							l.Error("serving gRPC", zap.Error(err))
+						}
+					}()
+
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					stopped := make(chan struct{})
+					go func() {
+						server.GracefulStop()
+						close(stopped)
+					}()
+
+					select {
+					case <-stopped:
+					case <-ctx.Done():
+						server.Stop()
+					}
+
+					return nil
+				},
+			})
+
+			return nil
+		},
+	),
+)
@@ -0,0 +1,273 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/middleware"
+	"github.com/higordasneves/e-corp/pkg/gateway/tracing"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// requestIDMetadata is the metadata key of the request id, the lowercase form of the HTTP header.
+var requestIDMetadata = strings.ToLower(apictx.RequestIDHeader)
+
+// requestIDRegexp restricts the accepted request ids, so clients can't inject arbitrary content in the logs.
+var requestIDRegexp = regexp.MustCompile(`^[\w\-.:]{1,128}$`)
+
+// errorDomain identifies the service in the ErrorInfo details of the errors.
+const errorDomain = "ecorp"
+
+// callEntry holds the values of the call log set by inner interceptors.
+type callEntry struct {
+	accountID string
+}
+
+// callEntryKey is the context key used to associate the call entry.
+type callEntryKey struct{}
+
+// setAccountID records the authenticated account in the log of the call.
+func setAccountID(ctx context.Context, accountID string) {
+	if e, ok := ctx.Value(callEntryKey{}).(*callEntry); ok {
+		e.accountID = accountID
+	}
+}
+
+// Logging associates the logger and the request id with the context and logs every call
+// with its status code and latency. The request id is read from the x-request-id metadata or generated,
+// and is sent back in the response header.
+func Logging(l *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(requestIDMetadata); len(v) > 0 {
+				id = v[0]
+			}
+		}
+		if !requestIDRegexp.MatchString(id) {
+			id = uuid.Must(uuid.NewV7()).String()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, id))
+
+		ctx = logger.AssociateCtx(apictx.WithRequestID(ctx, id), l)
+		fields := []zap.Field{
+			zap.String("request_id", id),
+			zap.String("grpc_method", info.FullMethod),
+		}
+		ctx = logger.With(ctx, append(fields, tracing.LogFields(ctx)...)...)
+		entry := &callEntry{}
+		ctx = context.WithValue(ctx, callEntryKey{}, entry)
+
+		resp, err := handler(ctx, req)
+
+		fields = []zap.Field{
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("latency", time.Since(start)),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			fields = append(fields, zap.String("remote_addr", p.Addr.String()))
+		}
+		if entry.accountID != "" {
+			fields = append(fields, zap.String("account_id", entry.accountID))
+		}
+		logger.Info(ctx, "grpc request", fields...)
+
+		return resp, err
+	}
+}
+
+// MapErrors converts the domain errors returned by the handlers to gRPC status errors.
+// The details carry the stable error code in an ErrorInfo and the invalid fields in a BadRequest.
+// Errors that are not domain errors are logged and returned as internal errors.
+func MapErrors(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return resp, err
+	}
+
+	return resp, Status(ctx, err).Err()
+}
+
+// Status returns the gRPC status of the error.
+func Status(ctx context.Context, err error) *status.Status {
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		logger.Error(ctx, "an unexpected error occurred", zap.Error(err))
+		return status.New(codes.Internal, controller.ErrUnexpected.Error())
+	}
+
+	msg := domainErr.Message
+	if msg == "" {
+		msg = domainErr.Kind.Error()
+	}
+
+	st := status.New(Code(domainErr), msg)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: string(domainErr.Code), Domain: errorDomain}}
+	if len(domainErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, f := range domainErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+// Code returns the gRPC code of the kind of the domain error.
+func Code(err error) codes.Code {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return codes.Unauthenticated
+	case errors.Is(err, domain.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, domain.ErrInvalidParameter):
+		return codes.InvalidArgument
+	case errors.Is(err, domain.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, domain.ErrConflict):
+		// a version conflict is solved by reading the resource again and retrying, as with Aborted.
+		if domainErr, ok := domain.AsError(err); ok && domainErr.Code == domain.CodeAccountVersionConflict {
+			return codes.Aborted
+		}
+		return codes.AlreadyExists
+	default:
+		return codes.Internal
+	}
+}
+
+// Authenticate validates the session token of the authorization metadata, "Bearer <token>",
+// and associates its subject with the context, as the HTTP middleware does.
+// The public methods are called without a token.
+func Authenticate(secretKey string, public map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) != 1 || !strings.HasPrefix(values[0], "Bearer ") {
+			return nil, domain.ErrUnauthorized
+		}
+
+		subject, err := controller.ParseSessionToken(strings.TrimPrefix(values[0], "Bearer "), secretKey)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = context.WithValue(ctx, "subject", subject)
+		ctx = logger.With(ctx, zap.String("account_id", subject))
+		setAccountID(ctx, subject)
+
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitIP limits the calls of each client IP with a token bucket of the given rate per second and burst,
+// as the HTTP middleware does. With trustProxy the client IP is read from the x-forwarded-for metadata.
+// A zero rate disables the limit.
+func RateLimitIP(limit float64, burst int, trustProxy bool) grpc.UnaryServerInterceptor {
+	return rateLimit(limit, burst, func(ctx context.Context) string {
+		return clientIP(ctx, trustProxy)
+	})
+}
+
+// RateLimitAccount limits the calls of each account with a token bucket of the given rate per second and burst.
+// It must run after Authenticate. The calls to the public methods are not limited by it.
+// A zero rate disables the limit.
+func RateLimitAccount(limit float64, burst int) grpc.UnaryServerInterceptor {
+	return rateLimit(limit, burst, func(ctx context.Context) string {
+		subject, _ := ctx.Value("subject").(string)
+		return subject
+	})
+}
+
+// rateLimit rejects the calls above the limit of their key with a resource exhausted error,
+// whose details carry the delay until the next call is allowed. Calls without a key are not limited.
+func rateLimit(limit float64, burst int, key func(ctx context.Context) string) grpc.UnaryServerInterceptor {
+	if limit <= 0 {
+		return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(ctx, req)
+		}
+	}
+
+	l := middleware.NewLimiter(limit, burst)
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		k := key(ctx)
+		if k == "" {
+			return handler(ctx, req)
+		}
+
+		if ok, delay := l.Reserve(k, time.Now()); !ok {
+			st := status.New(codes.ResourceExhausted, "too many requests")
+			withDetails, err := st.WithDetails(
+				&errdetails.ErrorInfo{Reason: string(domain.CodeRateLimited), Domain: errorDomain},
+				&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+			)
+			if err != nil {
+				return nil, st.Err()
+			}
+
+			return nil, withDetails.Err()
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// clientIP returns the IP of the peer. With trustProxy, it is the last address of the x-forwarded-for
+// metadata, which is the one appended by the proxy and can't be spoofed.
+func clientIP(ctx context.Context, trustProxy bool) string {
+	if trustProxy {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if ip := middleware.ForwardedIP(md.Get("x-forwarded-for")); ip != "" {
+			return ip
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
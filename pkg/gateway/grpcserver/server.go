@@ -0,0 +1,56 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/grpcserver/ecorpv1"
+)
+
+// publicMethods don't require a session token, as the equivalent HTTP routes.
+var publicMethods = map[string]bool{
+	ecorpv1.AuthService_Login_FullMethodName:            true,
+	ecorpv1.AccountService_CreateAccount_FullMethodName: true,
+	ecorpv1.AccountService_GetBalance_FullMethodName:    true,
+	ecorpv1.AccountService_ListAccounts_FullMethodName:  true,
+}
+
+// NewServer returns the gRPC server with the services of the use cases and the server reflection.
+// The calls are rate limited and, when the certificate of the HTTP server is set, served over TLS
+// as the HTTP API.
+func NewServer(l *zap.Logger, uc controller.UseCases, cfg config.Config) (*grpc.Server, error) {
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			Logging(l),
+			MapErrors,
+			RateLimitIP(cfg.HTTP.IPRateLimit, cfg.HTTP.IPRateBurst, cfg.HTTP.TrustProxyHeaders),
+			Authenticate(cfg.Auth.SecretKey, publicMethods),
+			RateLimitAccount(cfg.HTTP.AccountRateLimit, cfg.HTTP.AccountRateBurst),
+		),
+	}
+
+	if cfg.HTTP.TLS() {
+		creds, err := credentials.NewServerTLSFromFile(cfg.HTTP.TLSCertFile, cfg.HTTP.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading gRPC TLS credentials: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	server := grpc.NewServer(opts...)
+
+	ecorpv1.RegisterAuthServiceServer(server, NewAuthService(uc.Auth, cfg.Auth.SecretKey))
+	ecorpv1.RegisterAccountServiceServer(server, NewAccountService(uc.Accounts))
+	ecorpv1.RegisterTransferServiceServer(server, NewTransferService(uc.Transfers))
+	reflection.Register(server)
+
+	return server, nil
+}
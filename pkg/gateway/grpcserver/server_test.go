@@ -0,0 +1,393 @@
+package grpcserver_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/mocks"
+	"github.com/higordasneves/e-corp/pkg/gateway/grpcserver"
+	"github.com/higordasneves/e-corp/pkg/gateway/grpcserver/ecorpv1"
+)
+
+var (
+	accountID     = uuid.FromStringOrNil("0457c690-f884-4d57-810c-85cf09a50d8b")
+	destinationID = uuid.FromStringOrNil("5f2d4920-89c3-4ed5-af8e-1d411588746d")
+	transferID    = uuid.FromStringOrNil("9ee14852-1011-422e-b9f3-abd905d5103c")
+)
+
+func newUseCases() controller.UseCases {
+	return controller.UseCases{
+		Auth: &mocks.AuthUseCaseMock{
+			LoginFunc: func(ctx context.Context, input usecase.LoginInput) (usecase.LoginOutput, error) {
+				if input.Secret != "12345678" {
+					return usecase.LoginOutput{}, domain.NewError(domain.ErrInvalidParameter, domain.CodeInvalidCredentials, "invalid credentials")
+				}
+				now := time.Now()
+				return usecase.LoginOutput{AccountID: accountID, IssuedAt: now, ExpiresAt: now.Add(time.Hour)}, nil
+			},
+		},
+		Accounts: &mocks.AccountUseCaseMock{
+			GetBalanceFunc: func(ctx context.Context, id uuid.UUID) (int, error) {
+				if id != accountID {
+					return 0, domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account not found")
+				}
+				return 100, nil
+			},
+		},
+		Transfers: &mocks.TransferUseCaseMock{
+			TransferFunc: func(ctx context.Context, input usecase.TransferInput) (usecase.TransferOutput, error) {
+				if input.AccountOriginID != accountID {
+					return usecase.TransferOutput{}, fmt.Errorf("unexpected origin %s", input.AccountOriginID)
+				}
+				if input.Amount <= 0 {
+					return usecase.TransferOutput{}, domain.NewFieldError("amount", domain.CodeMustBePositive, "the amount must be greater than 0")
+				}
+				return usecase.TransferOutput{Transfer: entities.Transfer{
+					ID:                   transferID,
+					AccountOriginID:      input.AccountOriginID,
+					AccountDestinationID: input.AccountDestinationID,
+					Amount:               input.Amount,
+					CreatedAt:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				}}, nil
+			},
+		},
+	}
+}
+
+// dial serves the gRPC server in memory and returns a client connection to it.
+func dial(t *testing.T, l *zap.Logger) *grpc.ClientConn {
+	t.Helper()
+
+	return dialConfig(t, l, config.Config{Auth: config.AuthConfig{SecretKey: "test_secret_key"}})
+}
+
+// dialConfig is like dial, with the server configured by cfg.
+func dialConfig(t *testing.T, l *zap.Logger, cfg config.Config) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server, err := grpcserver.NewServer(l, newUseCases(), cfg)
+	require.NoError(t, err)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func login(t *testing.T, conn *grpc.ClientConn) context.Context {
+	t.Helper()
+
+	resp, err := ecorpv1.NewAuthServiceClient(conn).Login(context.Background(), &ecorpv1.LoginRequest{Document: "44455566678", Secret: "12345678"})
+	require.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+resp.GetToken())
+}
+
+func TestTransferService(t *testing.T) {
+	t.Parallel()
+
+	conn := dial(t, zaptest.NewLogger(t))
+	client := ecorpv1.NewTransferServiceClient(conn)
+	authCtx := login(t, conn)
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		req            *ecorpv1.TransferRequest
+		expectedCode   codes.Code
+		expectedReason string
+		expectedFields []string
+	}{
+		{
+			name: "authenticated account transfers",
+			ctx:  authCtx,
+			req:  &ecorpv1.TransferRequest{DestinationId: destinationID.String(), Amount: 10},
+		},
+		{
+			name:           "missing token",
+			ctx:            context.Background(),
+			req:            &ecorpv1.TransferRequest{DestinationId: destinationID.String(), Amount: 10},
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: string(domain.CodeUnauthorized),
+		},
+		{
+			name:           "invalid token",
+			ctx:            metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid"),
+			req:            &ecorpv1.TransferRequest{DestinationId: destinationID.String(), Amount: 10},
+			expectedCode:   codes.Unauthenticated,
+			expectedReason: string(domain.CodeUnauthorized),
+		},
+		{
+			name:           "invalid destination id",
+			ctx:            authCtx,
+			req:            &ecorpv1.TransferRequest{DestinationId: "invalid", Amount: 10},
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: string(domain.CodeValidationFailed),
+			expectedFields: []string{"destination_id"},
+		},
+		{
+			name:           "invalid amount",
+			ctx:            authCtx,
+			req:            &ecorpv1.TransferRequest{DestinationId: destinationID.String(), Amount: -10},
+			expectedCode:   codes.InvalidArgument,
+			expectedReason: string(domain.CodeValidationFailed),
+			expectedFields: []string{"amount"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			resp, err := client.Transfer(tt.ctx, tt.req)
+
+			// assert
+			if tt.expectedCode == codes.OK {
+				require.NoError(t, err)
+				assert.Equal(t, transferID.String(), resp.GetTransfer().GetId())
+				assert.Equal(t, accountID.String(), resp.GetTransfer().GetAccountOriginId())
+				assert.Equal(t, int64(10), resp.GetTransfer().GetAmount())
+				return
+			}
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.expectedCode, st.Code())
+
+			var fields []string
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					assert.Equal(t, tt.expectedReason, d.GetReason())
+				case *errdetails.BadRequest:
+					for _, f := range d.GetFieldViolations() {
+						fields = append(fields, f.GetField())
+					}
+				}
+			}
+			assert.Equal(t, tt.expectedFields, fields)
+		})
+	}
+}
+
+func TestAccountService_GetBalance(t *testing.T) {
+	t.Parallel()
+
+	conn := dial(t, zaptest.NewLogger(t))
+	client := ecorpv1.NewAccountServiceClient(conn)
+
+	tests := []struct {
+		name            string
+		accountID       string
+		expectedCode    codes.Code
+		expectedBalance int64
+	}{
+		{name: "public method without token", accountID: accountID.String(), expectedBalance: 100},
+		{name: "account not found", accountID: destinationID.String(), expectedCode: codes.NotFound},
+		{name: "invalid account id", accountID: "invalid", expectedCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			resp, err := client.GetBalance(context.Background(), &ecorpv1.GetBalanceRequest{AccountId: tt.accountID})
+
+			// assert
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedBalance, resp.GetBalance())
+		})
+	}
+}
+
+func TestLogging(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	core, logs := observer.New(zap.InfoLevel)
+	conn := dial(t, zap.New(core))
+	client := ecorpv1.NewTransferServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(login(t, conn), "x-request-id", "req-123")
+
+	// execute
+	var header metadata.MD
+	_, err := client.Transfer(ctx, &ecorpv1.TransferRequest{DestinationId: destinationID.String(), Amount: 10}, grpc.Header(&header))
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-123"}, header.Get("x-request-id"))
+
+	entries := logs.FilterMessage("grpc request").FilterField(zap.String("request_id", "req-123")).All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, ecorpv1.TransferService_Transfer_FullMethodName, fields["grpc_method"])
+	assert.Equal(t, codes.OK.String(), fields["code"])
+	assert.Equal(t, accountID.String(), fields["account_id"])
+}
+
+func TestReflection(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	conn := dial(t, zaptest.NewLogger(t))
+	stream, err := grpc_reflection_v1.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	// execute
+	require.NoError(t, stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+
+	// assert
+	require.NoError(t, err)
+	var services []string
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		services = append(services, s.GetName())
+	}
+	assert.Subset(t, services, []string{"ecorp.v1.AuthService", "ecorp.v1.AccountService", "ecorp.v1.TransferService"})
+}
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "not found", err: domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account not exists"), want: codes.NotFound},
+		{name: "already exists", err: domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account already exists"), want: codes.AlreadyExists},
+		{
+			name: "version conflict",
+			err:  fmt.Errorf("updating account: %w", domain.NewError(domain.ErrConflict, domain.CodeAccountVersionConflict, "account was updated")),
+			want: codes.Aborted,
+		},
+		{name: "unknown error", err: fmt.Errorf("unexpected"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			got := grpcserver.Code(tt.err)
+
+			// assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	// assertLimited checks the error of a call above the limit.
+	assertLimited := func(t *testing.T, err error) {
+		t.Helper()
+
+		st := status.Convert(err)
+		require.Equal(t, codes.ResourceExhausted, st.Code())
+		var reason string
+		var delay time.Duration
+		for _, d := range st.Details() {
+			switch d := d.(type) {
+			case *errdetails.ErrorInfo:
+				reason = d.GetReason()
+			case *errdetails.RetryInfo:
+				delay = d.GetRetryDelay().AsDuration()
+			}
+		}
+		assert.Equal(t, string(domain.CodeRateLimited), reason)
+		assert.Positive(t, delay)
+	}
+
+	t.Run("calls above the ip rate are limited, including the logins", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		conn := dialConfig(t, zaptest.NewLogger(t), config.Config{
+			Auth: config.AuthConfig{SecretKey: "test_secret_key"},
+			HTTP: config.HTTP{IPRateLimit: 0.001, IPRateBurst: 2},
+		})
+		client := ecorpv1.NewAuthServiceClient(conn)
+		req := &ecorpv1.LoginRequest{Document: "44455566678", Secret: "12345678"}
+
+		// execute
+		for range 2 {
+			_, err := client.Login(context.Background(), req)
+			require.NoError(t, err)
+		}
+		_, err := client.Login(context.Background(), req)
+
+		// assert
+		assertLimited(t, err)
+	})
+
+	t.Run("calls above the account rate are limited across methods", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		conn := dialConfig(t, zaptest.NewLogger(t), config.Config{
+			Auth: config.AuthConfig{SecretKey: "test_secret_key"},
+			HTTP: config.HTTP{AccountRateLimit: 0.001, AccountRateBurst: 1},
+		})
+		authCtx := login(t, conn)
+		req := &ecorpv1.TransferRequest{DestinationId: destinationID.String(), Amount: 10}
+
+		// execute
+		_, err := ecorpv1.NewTransferServiceClient(conn).Transfer(authCtx, req)
+		require.NoError(t, err)
+		_, err = ecorpv1.NewTransferServiceClient(conn).Transfer(authCtx, req)
+
+		// assert
+		assertLimited(t, err)
+
+		// the public methods called without a session aren't limited by the account.
+		_, err = ecorpv1.NewAccountServiceClient(conn).GetBalance(context.Background(), &ecorpv1.GetBalanceRequest{AccountId: accountID.String()})
+		assert.NoError(t, err)
+	})
+}
+
+func TestNewServer_TLS(t *testing.T) {
+	t.Parallel()
+
+	// execute
+	_, err := grpcserver.NewServer(zaptest.NewLogger(t), newUseCases(), config.Config{
+		HTTP: config.HTTP{TLSCertFile: "missing_cert.pem", TLSKeyFile: "missing_key.pem"},
+	})
+
+	// assert
+	assert.ErrorContains(t, err, "loading gRPC TLS credentials")
+}
@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/grpcserver/ecorpv1"
+)
+
+type TransferService struct {
+	ecorpv1.UnimplementedTransferServiceServer
+
+	tUseCase controller.TransferUseCase
+}
+
+func NewTransferService(tUseCase controller.TransferUseCase) TransferService {
+	return TransferService{tUseCase: tUseCase}
+}
+
+// Transfer creates a transfer from the authenticated account.
+func (s TransferService) Transfer(ctx context.Context, req *ecorpv1.TransferRequest) (*ecorpv1.TransferResponse, error) {
+	destinationID, err := parseUUID("destination_id", req.GetDestinationId())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := s.tUseCase.Transfer(ctx, usecase.TransferInput{
+		AccountOriginID:      subject(ctx),
+		AccountDestinationID: destinationID,
+		Amount:               int(req.GetAmount()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecorpv1.TransferResponse{Transfer: newTransfer(output.Transfer)}, nil
+}
+
+// ListTransfers lists the transfers sent or received by the authenticated account in desc order.
+func (s TransferService) ListTransfers(ctx context.Context, _ *ecorpv1.ListTransfersRequest) (*ecorpv1.ListTransfersResponse, error) {
+	output, err := s.tUseCase.ListAccountTransfers(ctx, usecase.ListAccountTransfersInput{AccountID: subject(ctx)})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ecorpv1.ListTransfersResponse{Transfers: make([]*ecorpv1.Transfer, 0, len(output.Transfers))}
+	for _, t := range output.Transfers {
+		resp.Transfers = append(resp.Transfers, newTransfer(t))
+	}
+
+	return resp, nil
+}
+
+func newTransfer(t entities.Transfer) *ecorpv1.Transfer {
+	return &ecorpv1.Transfer{
+		Id:                   t.ID.String(),
+		AccountOriginId:      t.AccountOriginID.String(),
+		AccountDestinationId: t.AccountDestinationID.String(),
+		Amount:               int64(t.Amount),
+		CreatedAt:            timestamppb.New(t.CreatedAt),
+	}
+}
+
+// subject returns the id of the account authenticated by the Authenticate interceptor.
+func subject(ctx context.Context) uuid.UUID {
+	return uuid.FromStringOrNil(fmt.Sprint(ctx.Value("subject")))
+}
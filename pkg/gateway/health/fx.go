@@ -0,0 +1,19 @@
+package health
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+// Module provides the Registry with the checks of the health_checks group.
+var Module = fx.Module("health",
+	fx.Provide(
+		fx.Annotate(
+			func(cfg config.Config, checks []Check) *Registry {
+				return NewRegistry(cfg.Health.Timeout, checks...)
+			},
+			fx.ParamTags("", Group),
+		),
+	),
+)
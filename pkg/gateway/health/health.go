@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Group is the fx group of the health checks. Modules register their checks by
+// providing a Check with fx.ResultTags(health.Group).
+const Group = `group:"health_checks"`
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check probes a dependency of the application.
+type Check struct {
+	// Name identifies the component in the report.
+	Name string
+	// Probe returns an error if the component is unavailable.
+	Probe func(ctx context.Context) error
+}
+
+// Report is the result of the health checks.
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// ComponentStatus is the result of the check of a component.
+type ComponentStatus struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Registry runs the registered health checks.
+type Registry struct {
+	checks  []Check
+	timeout time.Duration
+
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry returns a Registry running the checks with the timeout.
+func NewRegistry(timeout time.Duration, checks ...Check) *Registry {
+	return &Registry{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Shutdown makes the readiness fail, so the load balancers stop routing requests
+// to the instance before the server stops accepting them.
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+}
+
+// Ready runs the checks concurrently and reports whether all components are up.
+// It is down without running the checks once the registry is shutting down.
+func (r *Registry) Ready(ctx context.Context) Report {
+	if r.shuttingDown.Load() {
+		return Report{Status: StatusDown}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report = Report{Status: StatusUp, Components: make(map[string]ComponentStatus, len(r.checks))}
+	)
+	for _, c := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			err := c.Probe(ctx)
+			status := ComponentStatus{
+				Status:    StatusUp,
+				LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[c.Name] = status
+			if err != nil {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// Livez reports that the process is running. It doesn't probe the dependencies,
+// so an unavailable database doesn't make the orchestrator restart the instance.
+func (r *Registry) Livez(w http.ResponseWriter, _ *http.Request) {
+	writeReport(w, Report{Status: StatusUp})
+}
+
+// Readyz reports whether the instance can serve requests, with the status of each component.
+func (r *Registry) Readyz(w http.ResponseWriter, req *http.Request) {
+	writeReport(w, r.Ready(req.Context()))
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != StatusUp {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,118 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/health"
+)
+
+func TestRegistry_Readyz(t *testing.T) {
+	t.Parallel()
+
+	up := health.Check{Name: "postgres", Probe: func(context.Context) error { return nil }}
+	down := health.Check{Name: "broker", Probe: func(context.Context) error { return errors.New("connection refused") }}
+	slow := health.Check{Name: "slow", Probe: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	tests := []struct {
+		name           string
+		checks         []health.Check
+		shutdown       bool
+		expectedCode   int
+		expectedReport health.Report
+	}{
+		{
+			name:         "all components up",
+			checks:       []health.Check{up},
+			expectedCode: http.StatusOK,
+			expectedReport: health.Report{
+				Status:     health.StatusUp,
+				Components: map[string]health.ComponentStatus{"postgres": {Status: health.StatusUp}},
+			},
+		},
+		{
+			name:         "a component down",
+			checks:       []health.Check{up, down},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedReport: health.Report{
+				Status: health.StatusDown,
+				Components: map[string]health.ComponentStatus{
+					"postgres": {Status: health.StatusUp},
+					"broker":   {Status: health.StatusDown, Error: "connection refused"},
+				},
+			},
+		},
+		{
+			name:         "checks are bounded by the timeout",
+			checks:       []health.Check{slow},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedReport: health.Report{
+				Status:     health.StatusDown,
+				Components: map[string]health.ComponentStatus{"slow": {Status: health.StatusDown, Error: context.DeadlineExceeded.Error()}},
+			},
+		},
+		{
+			name:           "shutting down",
+			checks:         []health.Check{up},
+			shutdown:       true,
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedReport: health.Report{Status: health.StatusDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup
+			r := health.NewRegistry(50*time.Millisecond, tt.checks...)
+			if tt.shutdown {
+				r.Shutdown()
+			}
+			response := httptest.NewRecorder()
+
+			// execute
+			r.Readyz(response, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			// assert
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+
+			var report health.Report
+			require.NoError(t, json.NewDecoder(response.Body).Decode(&report))
+			for name, c := range report.Components {
+				assert.GreaterOrEqual(t, c.LatencyMS, float64(0), name)
+				c.LatencyMS = 0
+				report.Components[name] = c
+			}
+			assert.Equal(t, tt.expectedReport, report)
+		})
+	}
+}
+
+func TestRegistry_Livez(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	down := health.Check{Name: "postgres", Probe: func(context.Context) error { return errors.New("connection refused") }}
+	r := health.NewRegistry(time.Second, down)
+	response := httptest.NewRecorder()
+
+	// execute
+	r.Livez(response, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	// assert
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"status":"up"}`, response.Body.String())
+}
@@ -0,0 +1,300 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+)
+
+// CreateAccount inserts an account.
+func (r Repository) CreateAccount(ctx context.Context, acc entities.Account) error {
+	return r.update(ctx, func(d *data) error {
+		if acc.Balance < 0 {
+			return fmt.Errorf("creating account: %w", errNegativeBalance)
+		}
+
+		if _, ok := d.accounts[acc.ID]; ok {
+			return domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account with document %s already exists", acc.Document)
+		}
+
+		if _, ok := d.accountByDocument(acc.Document); ok {
+			return domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account with document %s already exists", acc.Document)
+		}
+
+		acc.CreatedAt = timestamp(acc.CreatedAt)
+		acc.Version = 1
+		d.accounts[acc.ID] = acc
+
+		return nil
+	})
+}
+
+// CreateAccounts inserts the accounts at once.
+// Returns domain.ErrConflict if an account with the document of one of them exists, in which case none is inserted.
+func (r Repository) CreateAccounts(ctx context.Context, accs []entities.Account) error {
+	return r.update(ctx, func(d *data) error {
+		documents := make(map[vos.Document]bool, len(accs))
+		for _, acc := range accs {
+			if acc.Balance < 0 {
+				return fmt.Errorf("creating accounts: %w", errNegativeBalance)
+			}
+
+			_, exists := d.accounts[acc.ID]
+			if _, ok := d.accountByDocument(acc.Document); ok || exists || documents[acc.Document] {
+				return domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account with one of the documents already exists")
+			}
+			documents[acc.Document] = true
+		}
+
+		for _, acc := range accs {
+			acc.CreatedAt = timestamp(acc.CreatedAt)
+			acc.Version = 1
+			d.accounts[acc.ID] = acc
+		}
+
+		return nil
+	})
+}
+
+// ListExistingDocuments returns the documents, among the provided ones, that belong to an account.
+func (r Repository) ListExistingDocuments(ctx context.Context, documents []vos.Document) ([]vos.Document, error) {
+	var existing []vos.Document
+	err := r.view(ctx, func(d *data) error {
+		for _, acc := range d.accounts {
+			if slices.Contains(documents, acc.Document) {
+				existing = append(existing, acc.Document)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing existing documents: %w", err)
+	}
+
+	return existing, nil
+}
+
+// ListAccounts Lists accounts by filtering the IDs provided in the input.
+// The field LastFetchedID is a cursor and represents the ID of
+// the last account listed (on the previous page).
+// The list is sorted in descending order.
+func (r Repository) ListAccounts(ctx context.Context, input usecase.ListAccountsInput) (usecase.ListAccountsOutput, error) {
+	var accList []entities.Account
+	err := r.view(ctx, func(d *data) error {
+		for id, acc := range d.accounts {
+			if !slices.Contains(input.IDs, id) {
+				continue
+			}
+			if !input.LastFetchedID.IsNil() && compareIDs(id, input.LastFetchedID) >= 0 {
+				continue
+			}
+			accList = append(accList, d.withShards(acc))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return usecase.ListAccountsOutput{}, fmt.Errorf("listing accounts: %w", err)
+	}
+
+	slices.SortFunc(accList, func(a, b entities.Account) int {
+		return compareIDs(b.ID, a.ID)
+	})
+	// We list page size + 1 to check if there will be more items to list on the next page.
+	accList = limit(accList, input.PageSize+1)
+
+	var nextPage *usecase.ListAccountsInput
+	// If the number of listed items is equal to page size + 1, there will be a next page.
+	// We need to construct the cursor.
+	if len(accList) >= input.PageSize+1 {
+		nextPage = &input
+		accList = accList[:len(accList)-1]
+		nextPage.LastFetchedID = accList[len(accList)-1].ID
+	}
+
+	if accList == nil {
+		accList = []entities.Account{}
+	}
+
+	return usecase.ListAccountsOutput{
+		Accounts: accList,
+		NextPage: nextPage,
+	}, nil
+}
+
+// GetAccount fetches an account by id.
+func (r Repository) GetAccount(ctx context.Context, id uuid.UUID) (entities.Account, error) {
+	var acc entities.Account
+	err := r.view(ctx, func(d *data) error {
+		var ok bool
+		if acc, ok = d.accounts[id]; !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", id)
+		}
+		acc = d.withShards(acc)
+
+		return nil
+	})
+	if err != nil {
+		return entities.Account{}, err
+	}
+
+	return acc, nil
+}
+
+// GetBalance returns the balance of the account for the provided ID, including the balance of its shards.
+func (r Repository) GetBalance(ctx context.Context, id uuid.UUID) (int, error) {
+	acc, err := r.GetAccount(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return acc.Balance, nil
+}
+
+// UpdateBalance updates an account by adding transactionAmount to the balance.
+// The credits of an account with balance shards go to a random shard and don't change the account.
+// The debits sweep the shards into the balance of the account before subtracting from it.
+func (r Repository) UpdateBalance(ctx context.Context, id uuid.UUID, transactionAmount int) error {
+	return r.update(ctx, func(d *data) error {
+		acc, ok := d.accounts[id]
+		if !ok {
+			return nil
+		}
+
+		shards := d.balanceShards[id]
+		if transactionAmount >= 0 && len(shards) > 0 {
+			shards = slices.Clone(shards)
+			shards[rand.IntN(len(shards))] += transactionAmount // nolint:gosec
+			d.balanceShards[id] = shards
+
+			return nil
+		}
+
+		swept := false
+		if transactionAmount < 0 {
+			for _, balance := range shards {
+				acc.Balance += balance
+				swept = swept || balance > 0
+			}
+		}
+
+		acc.Balance += transactionAmount
+		if acc.Balance < 0 {
+			return fmt.Errorf("updating account balance: %w", errNegativeBalance)
+		}
+		d.accounts[id] = acc
+
+		if swept {
+			d.balanceShards[id] = make([]int, len(shards))
+		}
+
+		return nil
+	})
+}
+
+// SetBalanceShards splits the balance of the account in the number of shards.
+// The balance of the current shards is moved to the account. Zero shards turns the sharding off.
+func (r Repository) SetBalanceShards(ctx context.Context, id uuid.UUID, shards int) error {
+	if shards < 0 {
+		return fmt.Errorf("invalid number of balance shards: %d", shards)
+	}
+
+	return r.update(ctx, func(d *data) error {
+		acc, ok := d.accounts[id]
+		if !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", id)
+		}
+
+		for _, balance := range d.balanceShards[id] {
+			acc.Balance += balance
+		}
+		d.accounts[id] = acc
+
+		if shards == 0 {
+			delete(d.balanceShards, id)
+		} else {
+			d.balanceShards[id] = make([]int, shards)
+		}
+
+		return nil
+	})
+}
+
+// UpdateAccount updates the name of the account if its version is still the expected one,
+// or whatever its version is if the expected one is zero.
+// Returns domain.ErrConflict if the account was updated since the expected version was read.
+func (r Repository) UpdateAccount(ctx context.Context, acc entities.Account, expectedVersion int) (entities.Account, error) {
+	var updated entities.Account
+	err := r.update(ctx, func(d *data) error {
+		var ok bool
+		if updated, ok = d.accounts[acc.ID]; !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", acc.ID)
+		}
+
+		if expectedVersion != 0 && updated.Version != expectedVersion {
+			return domain.NewError(domain.ErrConflict, domain.CodeAccountVersionConflict, "account %s was updated since version %d", acc.ID, expectedVersion)
+		}
+
+		// as the trigger of the database, the version changes with the profile only.
+		if updated.Name != acc.Name {
+			updated.Name = acc.Name
+			updated.Version++
+		}
+		d.accounts[acc.ID] = updated
+		updated = d.withShards(updated)
+
+		return nil
+	})
+	if err != nil {
+		return entities.Account{}, err
+	}
+
+	return updated, nil
+}
+
+// GetAccountByDocument fetches an account the by document number.
+func (r Repository) GetAccountByDocument(ctx context.Context, cpf vos.Document) (entities.Account, error) {
+	var acc entities.Account
+	err := r.view(ctx, func(d *data) error {
+		var ok bool
+		if acc, ok = d.accountByDocument(cpf); !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", cpf)
+		}
+		acc = d.withShards(acc)
+
+		return nil
+	})
+	if err != nil {
+		return entities.Account{}, err
+	}
+
+	return acc, nil
+}
+
+func (d *data) accountByDocument(document vos.Document) (entities.Account, bool) {
+	for _, acc := range d.accounts {
+		if acc.Document == document {
+			return acc, true
+		}
+	}
+
+	return entities.Account{}, false
+}
+
+// withShards adds the balance of the shards of the account to its balance.
+func (d *data) withShards(acc entities.Account) entities.Account {
+	for _, balance := range d.balanceShards[acc.ID] {
+		acc.Balance += balance
+	}
+
+	return acc
+}
@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// AppendEvent inserts an event in the event store.
+func (r Repository) AppendEvent(ctx context.Context, e entities.Event) error {
+	err := r.update(ctx, func(d *data) error {
+		if !json.Valid(e.Payload) {
+			return errInvalidJSON
+		}
+
+		if _, ok := d.events[e.ID]; ok {
+			return errDuplicateKey
+		}
+
+		d.eventSequence++
+		e.Sequence = d.eventSequence
+		e.Payload = slices.Clone(e.Payload)
+		e.CreatedAt = timestamp(e.CreatedAt)
+		d.events[e.ID] = e
+
+		if e.AggregateType == entities.EventAggregateTransfer {
+			d.appendAccountEvents(e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("inserting event %s: %w", e.ID, err)
+	}
+
+	return nil
+}
+
+// ListEvents lists the events matching the filter in the order they were appended, starting after the event
+// at the position afterSequence.
+func (r Repository) ListEvents(ctx context.Context, filter entities.EventFilter, afterSequence int64, pageSize int) ([]entities.Event, error) {
+	from, to := timestamp(filter.From), timestamp(filter.To)
+
+	events, err := r.listEvents(ctx, afterSequence, pageSize, func(e entities.Event) bool {
+		if !filter.AggregateID.IsNil() && e.AggregateID != filter.AggregateID {
+			return false
+		}
+
+		if e.CreatedAt.Before(from) {
+			return false
+		}
+
+		return filter.To.IsZero() || e.CreatedAt.Before(to)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing events: %w", err)
+	}
+
+	return events, nil
+}
+
+// ListAccountEvents lists the events of the transfers sent or received by the account in the order they were committed,
+// starting after the event at the position afterSequence of the stream of the account.
+func (r Repository) ListAccountEvents(ctx context.Context, accountID uuid.UUID, afterSequence int64, pageSize int) ([]entities.AccountEvent, error) {
+	events := []entities.AccountEvent{}
+	err := r.view(ctx, func(d *data) error {
+		for _, e := range d.accountEvents[accountID] {
+			if e.Sequence > afterSequence {
+				e.Event.Payload = slices.Clone(e.Event.Payload)
+				events = append(events, e)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing account events: %w", err)
+	}
+
+	return limit(events, pageSize), nil
+}
+
+// appendAccountEvents appends the transfer event to the streams of its accounts,
+// with the balance of the accounts at this point.
+func (d *data) appendAccountEvents(e entities.Event) {
+	var accountIDs []uuid.UUID
+	for _, key := range []string{"account_origin_id", "account_destination_id"} {
+		id, err := uuid.FromString(jsonField(e.Payload, key))
+		if err != nil || slices.Contains(accountIDs, id) {
+			continue
+		}
+		accountIDs = append(accountIDs, id)
+	}
+
+	for _, id := range accountIDs {
+		stream := d.accountEvents[id]
+
+		var balance int
+		if acc, ok := d.accounts[id]; ok {
+			balance = d.withShards(acc).Balance
+		}
+
+		// clipped, so the stream shared with the data of the transactions isn't overwritten.
+		d.accountEvents[id] = append(slices.Clip(stream), entities.AccountEvent{
+			AccountID: id,
+			Sequence:  int64(len(stream)) + 1,
+			Balance:   balance,
+			Event:     e,
+		})
+	}
+}
+
+// listEvents lists the events after the position afterSequence that match the filter, ordered by sequence.
+func (r Repository) listEvents(ctx context.Context, afterSequence int64, pageSize int, match func(e entities.Event) bool) ([]entities.Event, error) {
+	events := []entities.Event{}
+	err := r.view(ctx, func(d *data) error {
+		for _, e := range d.events {
+			if e.Sequence > afterSequence && match(e) {
+				e.Payload = slices.Clone(e.Payload)
+				events = append(events, e)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(events, func(a, b entities.Event) int {
+		return cmp.Compare(a.Sequence, b.Sequence)
+	})
+
+	return limit(events, pageSize), nil
+}
+
+// jsonField returns the text of a field of the JSON object, like the ->> operator.
+// It returns an empty string if the field doesn't exist or is null.
+func jsonField(payload []byte, key string) string {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &object); err != nil {
+		return ""
+	}
+
+	field, ok := object[key]
+	if !ok || string(field) == "null" {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(field, &s); err != nil {
+		return string(field)
+	}
+
+	return s
+}
@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+)
+
+type processedMessage struct {
+	consumer  string
+	messageID string
+}
+
+// MarkMessageProcessed records that the message was processed by the consumer.
+// It returns false if the message had already been recorded, meaning it is a duplicate delivery.
+func (r Repository) MarkMessageProcessed(ctx context.Context, consumer, messageID string) (bool, error) {
+	var inserted bool
+	err := r.update(ctx, func(d *data) error {
+		key := processedMessage{consumer: consumer, messageID: messageID}
+		if _, ok := d.processedMessages[key]; ok {
+			return nil
+		}
+
+		d.processedMessages[key] = struct{}{}
+		inserted = true
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("inserting processed message %s: %w", messageID, err)
+	}
+
+	return inserted, nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// SaveNotificationPreferences creates or replaces the notification preferences of an account.
+// It returns the preferences with the update time set.
+func (r Repository) SaveNotificationPreferences(ctx context.Context, p entities.NotificationPreferences) (entities.NotificationPreferences, error) {
+	err := r.update(ctx, func(d *data) error {
+		if _, ok := d.accounts[p.AccountID]; !ok {
+			return errForeignKey
+		}
+
+		p.UpdatedAt = timestamp(time.Now())
+
+		stored := p
+		stored.Channels = append([]entities.NotificationChannel{}, p.Channels...)
+		stored.Events = append([]entities.NotificationEvent{}, p.Events...)
+		d.notificationPreferences[p.AccountID] = stored
+
+		return nil
+	})
+	if err != nil {
+		return entities.NotificationPreferences{}, fmt.Errorf("saving notification preferences: %w", err)
+	}
+
+	return p, nil
+}
+
+// GetNotificationPreferences fetches the notification preferences of an account.
+func (r Repository) GetNotificationPreferences(ctx context.Context, accountID uuid.UUID) (entities.NotificationPreferences, error) {
+	var p entities.NotificationPreferences
+	err := r.view(ctx, func(d *data) error {
+		var ok bool
+		if p, ok = d.notificationPreferences[accountID]; !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeNotificationPreferencesNotFound, "notification preferences of account %s not exists", accountID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entities.NotificationPreferences{}, err
+	}
+
+	p.Channels = append([]entities.NotificationChannel{}, p.Channels...)
+	p.Events = append([]entities.NotificationEvent{}, p.Events...)
+
+	return p, nil
+}
+
+// UpsertNotification inserts the notification, or increments the attempts of the one already
+// created for the same account, event and channel. It returns the stored notification.
+func (r Repository) UpsertNotification(ctx context.Context, n entities.Notification) (entities.Notification, error) {
+	var stored entities.Notification
+	err := r.update(ctx, func(d *data) error {
+		for id, other := range d.notifications {
+			if other.AccountID == n.AccountID && other.Event == n.Event && other.EventID == n.EventID && other.Channel == n.Channel {
+				other.Attempts++
+				d.notifications[id] = other
+				stored = other
+
+				return nil
+			}
+		}
+
+		if _, ok := d.notifications[n.ID]; ok {
+			return errDuplicateKey
+		}
+
+		if _, ok := d.accounts[n.AccountID]; !ok {
+			return errForeignKey
+		}
+
+		stored = entities.Notification{
+			ID:        n.ID,
+			AccountID: n.AccountID,
+			EventID:   n.EventID,
+			Event:     n.Event,
+			Channel:   n.Channel,
+			Recipient: n.Recipient,
+			Subject:   n.Subject,
+			Body:      n.Body,
+			Status:    n.Status,
+			Attempts:  1,
+			CreatedAt: timestamp(n.CreatedAt),
+		}
+		d.notifications[n.ID] = stored
+
+		return nil
+	})
+	if err != nil {
+		return entities.Notification{}, fmt.Errorf("upserting notification: %w", err)
+	}
+
+	return stored, nil
+}
+
+// MarkNotificationSent updates the notification status to sent.
+func (r Repository) MarkNotificationSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	err := r.update(ctx, func(d *data) error {
+		n, ok := d.notifications[id]
+		if !ok {
+			return nil
+		}
+
+		n.Status = entities.NotificationSent
+		n.SentAt = timestampPtr(&sentAt)
+		d.notifications[id] = n
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("updating notification %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+// Package memory implements the repositories of the use cases in memory.
+// It behaves like the postgres repository, which is checked by the contract suite of the repotest package,
+// so the tests that don't need a database can use it instead.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// The errors of the constraints of the database schema.
+var (
+	errDuplicateKey    = errors.New("duplicate key value violates unique constraint")
+	errForeignKey      = errors.New("insert violates foreign key constraint")
+	errNegativeBalance = errors.New("balance violates check constraint")
+	errInvalidJSON     = errors.New("invalid input syntax for type json")
+)
+
+var errTxClosed = errors.New("tx is closed")
+
+type Repository struct {
+	db *database
+}
+
+func NewRepository() Repository {
+	return Repository{db: &database{
+		data:   newData(),
+		writer: make(chan struct{}, 1),
+	}}
+}
+
+// database holds the committed data.
+// The transactions and the writes outside them hold the writer lock, so they are serialized
+// as if every write locked the whole database. A transaction works on a copy of the data,
+// which replaces the committed one on commit. The reads outside a transaction see the committed data.
+type database struct {
+	mu     sync.RWMutex
+	data   *data
+	writer chan struct{}
+}
+
+func (db *database) lock(ctx context.Context) error {
+	select {
+	case db.writer <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() // nolint:wrapcheck
+	}
+}
+
+func (db *database) unlock() {
+	<-db.writer
+}
+
+type txCtxKey struct{}
+
+// tx is a transaction, or a savepoint of the parent transaction.
+// The savepoints work on the data of their transaction and keep a copy of it to roll back to.
+type tx struct {
+	// mu is shared by the transaction and its savepoints.
+	mu        *sync.Mutex
+	db        *database
+	data      *data
+	parent    *tx
+	savepoint *data
+	closed    bool
+}
+
+// BeginTX starts a transaction and returns a context carrying it.
+// If the context already carries a transaction, it creates a savepoint in it instead,
+// which CommitTX releases and RollbackTX rolls back to.
+func (r Repository) BeginTX(ctx context.Context) (context.Context, error) {
+	if parent, ok := r.tx(ctx); ok {
+		parent.mu.Lock()
+		defer parent.mu.Unlock()
+
+		if parent.isClosed() {
+			return nil, fmt.Errorf("creating a savepoint: %w", errTxClosed)
+		}
+
+		t := &tx{mu: parent.mu, db: r.db, data: parent.data, parent: parent, savepoint: parent.data.clone()}
+		return context.WithValue(ctx, txCtxKey{}, t), nil
+	}
+
+	if err := r.db.lock(ctx); err != nil {
+		return nil, fmt.Errorf("beginning a transaction: %w", err)
+	}
+
+	r.db.mu.RLock()
+	t := &tx{mu: &sync.Mutex{}, db: r.db, data: r.db.data.clone()}
+	r.db.mu.RUnlock()
+
+	return context.WithValue(ctx, txCtxKey{}, t), nil
+}
+
+func (r Repository) CommitTX(ctx context.Context) error {
+	t, ok := r.tx(ctx)
+	if !ok {
+		return errors.New("transaction not found")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isClosed() {
+		return fmt.Errorf("committing a transaction: %w", errTxClosed)
+	}
+	t.closed = true
+
+	// releasing a savepoint keeps its changes in the transaction.
+	if t.parent != nil {
+		return nil
+	}
+
+	r.db.mu.Lock()
+	r.db.data = t.data
+	r.db.mu.Unlock()
+	r.db.unlock()
+
+	return nil
+}
+
+func (r Repository) RollbackTX(ctx context.Context) error {
+	t, ok := r.tx(ctx)
+	if !ok {
+		return errors.New("transaction not found")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isClosed() {
+		return fmt.Errorf("rolling back a transaction: %w", errTxClosed)
+	}
+	t.closed = true
+
+	if t.parent != nil {
+		*t.data = *t.savepoint
+		return nil
+	}
+
+	r.db.unlock()
+
+	return nil
+}
+
+// WithTx runs fn in a transaction, or in a savepoint of the transaction of the context.
+// The transaction is committed if fn succeeds and rolled back if it returns an error or panics.
+func (r Repository) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, err = r.BeginTX(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = r.RollbackTX(ctx)
+			panic(p)
+		}
+	}()
+
+	if err = fn(ctx); err != nil {
+		if rollbackErr := r.RollbackTX(ctx); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+
+	return r.CommitTX(ctx)
+}
+
+// RunTx runs fn in a transaction, like WithTx.
+// The transactions are serialized, so they never fail on conflicts and don't need to be retried.
+func (r Repository) RunTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.WithTx(ctx, fn)
+}
+
+func (r Repository) tx(ctx context.Context) (*tx, bool) {
+	t, ok := ctx.Value(txCtxKey{}).(*tx)
+	if !ok || t.db != r.db {
+		return nil, false
+	}
+
+	return t, true
+}
+
+// view runs fn over the data visible to the context, the one of its transaction or the committed one.
+func (r Repository) view(ctx context.Context, fn func(d *data) error) error {
+	if t, ok := r.tx(ctx); ok {
+		return t.run(fn)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	return fn(r.db.data)
+}
+
+// update runs fn over the data of the transaction of the context.
+// Outside a transaction, it waits for the running transactions and changes the committed data.
+// fn must check the constraints before changing the data, a failed write leaves it untouched.
+func (r Repository) update(ctx context.Context, fn func(d *data) error) error {
+	if t, ok := r.tx(ctx); ok {
+		return t.run(fn)
+	}
+
+	if err := r.db.lock(ctx); err != nil {
+		return err
+	}
+	defer r.db.unlock()
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	return fn(r.db.data)
+}
+
+func (t *tx) run(fn func(d *data) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isClosed() {
+		return errTxClosed
+	}
+
+	return fn(t.data)
+}
+
+// isClosed reports whether the transaction or the one it's a savepoint of has ended.
+// Ending a transaction also ends the savepoints created in it.
+func (t *tx) isClosed() bool {
+	for ; t != nil; t = t.parent {
+		if t.closed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// data are the tables of the database.
+// The rows are replaced on update and never changed in place, so the copies of the data can share them.
+// The savepoints of a transaction share its data, which is restored from a copy on rollback.
+type data struct {
+	accounts                map[uuid.UUID]entities.Account
+	balanceShards           map[uuid.UUID][]int
+	transfers               map[uuid.UUID]entities.Transfer
+	events                  map[uuid.UUID]entities.Event
+	eventSequence           int64
+	accountEvents           map[uuid.UUID][]entities.AccountEvent
+	processedMessages       map[processedMessage]struct{}
+	webhookSubscriptions    map[uuid.UUID]entities.WebhookSubscription
+	webhookDeliveries       map[uuid.UUID]entities.WebhookDelivery
+	notificationPreferences map[uuid.UUID]entities.NotificationPreferences
+	notifications           map[uuid.UUID]entities.Notification
+}
+
+func newData() *data {
+	return &data{
+		accounts:                make(map[uuid.UUID]entities.Account),
+		balanceShards:           make(map[uuid.UUID][]int),
+		transfers:               make(map[uuid.UUID]entities.Transfer),
+		events:                  make(map[uuid.UUID]entities.Event),
+		accountEvents:           make(map[uuid.UUID][]entities.AccountEvent),
+		processedMessages:       make(map[processedMessage]struct{}),
+		webhookSubscriptions:    make(map[uuid.UUID]entities.WebhookSubscription),
+		webhookDeliveries:       make(map[uuid.UUID]entities.WebhookDelivery),
+		notificationPreferences: make(map[uuid.UUID]entities.NotificationPreferences),
+		notifications:           make(map[uuid.UUID]entities.Notification),
+	}
+}
+
+func (d *data) clone() *data {
+	return &data{
+		accounts:                maps.Clone(d.accounts),
+		balanceShards:           maps.Clone(d.balanceShards),
+		transfers:               maps.Clone(d.transfers),
+		events:                  maps.Clone(d.events),
+		eventSequence:           d.eventSequence,
+		accountEvents:           maps.Clone(d.accountEvents),
+		processedMessages:       maps.Clone(d.processedMessages),
+		webhookSubscriptions:    maps.Clone(d.webhookSubscriptions),
+		webhookDeliveries:       maps.Clone(d.webhookDeliveries),
+		notificationPreferences: maps.Clone(d.notificationPreferences),
+		notifications:           maps.Clone(d.notifications),
+	}
+}
+
+// compareIDs orders the ids like postgres, byte by byte.
+func compareIDs(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// timestamp returns the time as stored in a timestamptz column and read by pgx,
+// with microseconds precision and in the local time zone.
+func timestamp(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond).Local()
+}
+
+func timestampPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	ts := timestamp(*t)
+	return &ts
+}
+
+// limit returns the first n items, like the limit clause.
+func limit[S ~[]E, E any](s S, n int) S {
+	if n >= 0 && len(s) > n {
+		return s[:n]
+	}
+
+	return s
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// CreateTransfer inserts a transfer.
+func (r Repository) CreateTransfer(ctx context.Context, transfer entities.Transfer) error {
+	err := r.update(ctx, func(d *data) error {
+		if _, ok := d.transfers[transfer.ID]; ok {
+			return errDuplicateKey
+		}
+
+		for _, id := range []uuid.UUID{transfer.AccountOriginID, transfer.AccountDestinationID} {
+			if _, ok := d.accounts[id]; !ok {
+				return domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", id).WithCause(errForeignKey)
+			}
+		}
+
+		transfer.CreatedAt = timestamp(transfer.CreatedAt)
+		d.transfers[transfer.ID] = transfer
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("inserting transfer with id %s: %w", transfer.ID.String(), err)
+	}
+
+	return nil
+}
+
+// ListAccountTransfers lists all transfers made or received by an account in descending order.
+func (r Repository) ListAccountTransfers(ctx context.Context, accountID uuid.UUID) ([]entities.Transfer, error) {
+	transferList := []entities.Transfer{}
+	err := r.view(ctx, func(d *data) error {
+		for _, t := range d.transfers {
+			if t.AccountOriginID == accountID || t.AccountDestinationID == accountID {
+				transferList = append(transferList, t)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing transfer for account %s: %w", accountID.String(), err)
+	}
+
+	slices.SortFunc(transferList, func(a, b entities.Transfer) int {
+		return compareIDs(b.ID, a.ID)
+	})
+
+	return transferList, nil
+}
@@ -0,0 +1,317 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// CreateWebhookSubscription inserts a webhook subscription.
+func (r Repository) CreateWebhookSubscription(ctx context.Context, sub entities.WebhookSubscription) error {
+	err := r.update(ctx, func(d *data) error {
+		if _, ok := d.webhookSubscriptions[sub.ID]; ok {
+			return errDuplicateKey
+		}
+
+		if _, ok := d.accounts[sub.AccountID]; !ok {
+			return errForeignKey
+		}
+
+		sub.EventTypes = append([]entities.WebhookEventType{}, sub.EventTypes...)
+		sub.CreatedAt = timestamp(sub.CreatedAt)
+		d.webhookSubscriptions[sub.ID] = sub
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("inserting webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookSubscription fetches a webhook subscription by id.
+func (r Repository) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (entities.WebhookSubscription, error) {
+	var sub entities.WebhookSubscription
+	err := r.view(ctx, func(d *data) error {
+		var ok bool
+		if sub, ok = d.webhookSubscriptions[id]; !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeWebhookNotFound, "webhook %s not exists", id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entities.WebhookSubscription{}, err
+	}
+
+	sub.EventTypes = slices.Clone(sub.EventTypes)
+
+	return sub, nil
+}
+
+// ListWebhookSubscriptions lists the webhook subscriptions of an account in descending order.
+func (r Repository) ListWebhookSubscriptions(ctx context.Context, accountID uuid.UUID) ([]entities.WebhookSubscription, error) {
+	subs, err := r.listWebhookSubscriptions(ctx, func(sub entities.WebhookSubscription) bool {
+		return sub.AccountID == accountID
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook subscriptions for account %s: %w", accountID, err)
+	}
+
+	slices.Reverse(subs)
+
+	return subs, nil
+}
+
+// ListWebhookSubscriptionsByEvent lists the webhook subscriptions of an account subscribed to the event type.
+func (r Repository) ListWebhookSubscriptionsByEvent(ctx context.Context, accountID uuid.UUID, eventType entities.WebhookEventType) ([]entities.WebhookSubscription, error) {
+	subs, err := r.listWebhookSubscriptions(ctx, func(sub entities.WebhookSubscription) bool {
+		return sub.AccountID == accountID && slices.Contains(sub.EventTypes, eventType)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing %s webhook subscriptions for account %s: %w", eventType, accountID, err)
+	}
+
+	return subs, nil
+}
+
+// listWebhookSubscriptions lists the webhook subscriptions that match the filter, ordered by id.
+func (r Repository) listWebhookSubscriptions(ctx context.Context, match func(sub entities.WebhookSubscription) bool) ([]entities.WebhookSubscription, error) {
+	subs := []entities.WebhookSubscription{}
+	err := r.view(ctx, func(d *data) error {
+		for _, sub := range d.webhookSubscriptions {
+			if match(sub) {
+				sub.EventTypes = slices.Clone(sub.EventTypes)
+				subs = append(subs, sub)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(subs, func(a, b entities.WebhookSubscription) int {
+		return compareIDs(a.ID, b.ID)
+	})
+
+	return subs, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription of the account and its deliveries.
+// Returns domain.ErrNotFound if the account doesn't have the subscription.
+func (r Repository) DeleteWebhookSubscription(ctx context.Context, accountID, id uuid.UUID) error {
+	return r.update(ctx, func(d *data) error {
+		sub, ok := d.webhookSubscriptions[id]
+		if !ok || sub.AccountID != accountID {
+			return domain.NewError(domain.ErrNotFound, domain.CodeWebhookNotFound, "webhook %s not exists", id)
+		}
+
+		delete(d.webhookSubscriptions, id)
+		for deliveryID, delivery := range d.webhookDeliveries {
+			if delivery.SubscriptionID == id {
+				delete(d.webhookDeliveries, deliveryID)
+			}
+		}
+
+		return nil
+	})
+}
+
+// CreateWebhookDelivery inserts a webhook delivery and reports whether it was inserted.
+// A delivery of the same event to the same subscription is ignored.
+func (r Repository) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) (bool, error) {
+	inserted := false
+	err := r.update(ctx, func(d *data) error {
+		if !json.Valid(delivery.Payload) {
+			return errInvalidJSON
+		}
+
+		for _, other := range d.webhookDeliveries {
+			if other.SubscriptionID == delivery.SubscriptionID && other.EventID == delivery.EventID && other.EventType == delivery.EventType {
+				return nil
+			}
+		}
+
+		if _, ok := d.webhookDeliveries[delivery.ID]; ok {
+			return errDuplicateKey
+		}
+
+		if _, ok := d.webhookSubscriptions[delivery.SubscriptionID]; !ok {
+			return errForeignKey
+		}
+
+		d.webhookDeliveries[delivery.ID] = entities.WebhookDelivery{
+			ID:             delivery.ID,
+			SubscriptionID: delivery.SubscriptionID,
+			EventID:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Payload:        slices.Clone(delivery.Payload),
+			Status:         delivery.Status,
+			NextAttemptAt:  timestamp(delivery.NextAttemptAt),
+			CreatedAt:      timestamp(delivery.CreatedAt),
+		}
+		inserted = true
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("inserting webhook delivery: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// GetWebhookDelivery fetches a webhook delivery by id.
+func (r Repository) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	err := r.view(ctx, func(d *data) error {
+		var ok bool
+		if delivery, ok = d.webhookDeliveries[id]; !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeWebhookDeliveryNotFound, "webhook delivery %s not exists", id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+
+	delivery.Payload = slices.Clone(delivery.Payload)
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries lists the most recent deliveries of a webhook subscription.
+func (r Repository) ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, pageSize int) ([]entities.WebhookDelivery, error) {
+	deliveries, err := r.listWebhookDeliveries(ctx, func(delivery entities.WebhookDelivery) bool {
+		return delivery.SubscriptionID == subscriptionID
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook deliveries for subscription %s: %w", subscriptionID, err)
+	}
+
+	slices.SortFunc(deliveries, func(a, b entities.WebhookDelivery) int {
+		return compareIDs(b.ID, a.ID)
+	})
+
+	return limit(deliveries, pageSize), nil
+}
+
+// ClaimDueWebhookDeliveries claims the pending deliveries whose next attempt is due, in no particular order,
+// by moving their next attempt to leaseUntil. The claimed deliveries are skipped by concurrent workers until then,
+// when they are claimed again unless the attempt was recorded.
+func (r Repository) ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, maxItems int) ([]entities.WebhookDelivery, error) {
+	now = timestamp(now)
+
+	var claimed []entities.WebhookDelivery
+	err := r.update(ctx, func(d *data) error {
+		var due []entities.WebhookDelivery
+		for _, delivery := range d.webhookDeliveries {
+			if delivery.Status == entities.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+				due = append(due, delivery)
+			}
+		}
+
+		slices.SortFunc(due, func(a, b entities.WebhookDelivery) int {
+			if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		})
+
+		for _, delivery := range limit(due, maxItems) {
+			delivery.NextAttemptAt = timestamp(leaseUntil)
+			d.webhookDeliveries[delivery.ID] = delivery
+
+			delivery.Payload = slices.Clone(delivery.Payload)
+			claimed = append(claimed, delivery)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claiming due webhook deliveries: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// listWebhookDeliveries lists the webhook deliveries that match the filter.
+func (r Repository) listWebhookDeliveries(ctx context.Context, match func(delivery entities.WebhookDelivery) bool) ([]entities.WebhookDelivery, error) {
+	deliveries := []entities.WebhookDelivery{}
+	err := r.view(ctx, func(d *data) error {
+		for _, delivery := range d.webhookDeliveries {
+			if match(delivery) {
+				delivery.Payload = slices.Clone(delivery.Payload)
+				deliveries = append(deliveries, delivery)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery updates the status and the attempts log of a webhook delivery.
+func (r Repository) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	err := r.update(ctx, func(d *data) error {
+		stored, ok := d.webhookDeliveries[delivery.ID]
+		if !ok {
+			return nil
+		}
+
+		stored.Status = delivery.Status
+		stored.Attempts = delivery.Attempts
+		stored.ResponseStatus = delivery.ResponseStatus
+		stored.LastError = delivery.LastError
+		stored.NextAttemptAt = timestamp(delivery.NextAttemptAt)
+		stored.DeliveredAt = timestampPtr(delivery.DeliveredAt)
+		d.webhookDeliveries[delivery.ID] = stored
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("updating webhook delivery %s: %w", delivery.ID, err)
+	}
+
+	return nil
+}
+
+// ReplayWebhookDelivery schedules a new attempt of the delivery at nextAttemptAt and restarts its attempts,
+// unless it changed since d was read: its status, attempts and next attempt are compared in the update,
+// so an attempt recorded or claimed meanwhile isn't overwritten.
+// Returns domain.ErrConflict if the delivery changed and domain.ErrNotFound if it doesn't exist.
+func (r Repository) ReplayWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery, nextAttemptAt time.Time) error {
+	return r.update(ctx, func(d *data) error {
+		stored, ok := d.webhookDeliveries[delivery.ID]
+		if !ok {
+			return domain.NewError(domain.ErrNotFound, domain.CodeWebhookDeliveryNotFound, "webhook delivery %s not exists", delivery.ID)
+		}
+
+		if stored.Status != delivery.Status || stored.Attempts != delivery.Attempts ||
+			!stored.NextAttemptAt.Equal(timestamp(delivery.NextAttemptAt)) {
+			return domain.NewError(domain.ErrConflict, domain.CodeWebhookDeliveryChanged, "webhook delivery %s changed since it was read", delivery.ID)
+		}
+
+		stored.Status = entities.WebhookDeliveryPending
+		stored.Attempts = 0
+		stored.NextAttemptAt = timestamp(nextAttemptAt)
+		d.webhookDeliveries[delivery.ID] = stored
+
+		return nil
+	})
+}
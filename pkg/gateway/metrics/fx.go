@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+)
+
+// Module provides the registry of the application metrics and serves it at /metrics.
+var Module = fx.Module("metrics",
+	fx.Provide(
+		NewRegistry,
+	),
+	fx.Invoke(
+		func(reg *prometheus.Registry, pool *pgxpool.Pool) error {
+			return reg.Register(dbpool.NewStatsCollector(pool))
+		},
+		func(lc fx.Lifecycle, l *zap.Logger, reg *prometheus.Registry, cfg config.Config) {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+
+			server := http.Server{
+				Addr:              cfg.Metrics.Address + ":" + cfg.Metrics.Port,
+				Handler:           mux,
+				ReadHeaderTimeout: time.Second * 10,
+			}
+
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					go func() {
+						l.Info("metrics server listening", zap.String("address", server.Addr))
+
+						if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+							l.Error("listening metrics", zap.Error(err))
+						}
+					}()
+
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					return server.Shutdown(ctx)
+				},
+			})
+		},
+	),
+)
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+)
+
+const namespace = "ecorp"
+
+var (
+	// HTTPRequestDuration observes the latency of the HTTP requests by route pattern and status.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// BrokerPublished counts the messages published to the broker by routing key and result.
+	BrokerPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "broker",
+		Name:      "messages_published_total",
+		Help:      "Messages published to the broker.",
+	}, []string{"routing_key", "result"})
+
+	// BrokerConfirmed counts the publisher confirmations received from the broker by result (ack or nack).
+	BrokerConfirmed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "broker",
+		Name:      "publish_confirmations_total",
+		Help:      "Publisher confirmations received from the broker.",
+	}, []string{"result"})
+
+	// BrokerConsumed counts the messages consumed by queue and the action taken (ack, nack_discard, nack_requeue).
+	BrokerConsumed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "broker",
+		Name:      "messages_consumed_total",
+		Help:      "Messages consumed from the broker.",
+	}, []string{"queue", "action"})
+
+	// TransfersCreated counts the transfers created.
+	TransfersCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_created_total",
+		Help:      "Transfers created.",
+	})
+
+	// TransferVolume sums the amount of the transfers created, in cents.
+	TransferVolume = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_volume_cents_total",
+		Help:      "Amount of the transfers created, in cents.",
+	})
+
+	// FailedLogins counts the failed login attempts by error code.
+	FailedLogins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "failed_logins_total",
+		Help:      "Failed login attempts.",
+	}, []string{"code"})
+)
+
+// NewRegistry returns a registry with the application collectors and the go and process collectors.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		BrokerPublished,
+		BrokerConfirmed,
+		BrokerConsumed,
+		TransfersCreated,
+		TransferVolume,
+		FailedLogins,
+		dbpool.TxRetries,
+		dbpool.TxRetriesExhausted,
+		dbpool.ReplicaLag,
+		dbpool.ReplicaFallbacks,
+	)
+
+	return reg
+}
@@ -0,0 +1,42 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/controller/middleware"
+	"github.com/higordasneves/e-corp/pkg/gateway/metrics"
+)
+
+func TestHTTPRequestDuration(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	reg := metrics.NewRegistry()
+	router := chi.NewRouter()
+	router.Use(middleware.Metrics)
+	router.Get("/metrics-test/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	// execute
+	for _, target := range []string{"/metrics-test/1", "/metrics-test/2", "/metrics-test-unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	response := httptest.NewRecorder()
+	promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// assert
+	require.Equal(t, http.StatusOK, response.Code)
+	body := response.Body.String()
+	assert.Contains(t, body, `ecorp_http_request_duration_seconds_count{method="GET",route="/metrics-test/{id}",status="418"} 2`)
+	assert.Contains(t, body, `route="unmatched",status="404"`)
+	assert.Contains(t, body, "go_goroutines")
+}
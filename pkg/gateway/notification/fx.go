@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+)
+
+const (
+	// DriverLog writes the notifications to a file or to the logger.
+	DriverLog = "log"
+	// DriverLive sends the notifications through the providers.
+	DriverLive = "live"
+)
+
+// Module consumes the account and transfer events to notify the account holders.
+var Module = fx.Module("notification",
+	fx.Provide(
+		NewTemplates,
+		func(lc fx.Lifecycle, cfg config.Config) (Dispatcher, error) {
+			switch cfg.Notification.Driver {
+			case DriverLive:
+				return NewDispatcher(cfg.Notification), nil
+			case DriverLog:
+				if cfg.Notification.LogFile == "" {
+					return NewLogDispatcher(NewLogSender(nil)), nil
+				}
+
+				f, err := os.OpenFile(cfg.Notification.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+				if err != nil {
+					return nil, fmt.Errorf("opening notification log file: %w", err)
+				}
+
+				lc.Append(fx.Hook{
+					OnStop: func(_ context.Context) error {
+						return f.Close()
+					},
+				})
+
+				return NewLogDispatcher(NewLogSender(f)), nil
+			default:
+				return nil, fmt.Errorf("unknown notification driver %q", cfg.Notification.Driver)
+			}
+		},
+	),
+	fx.Invoke(
+		func(ctx context.Context, lc fx.Lifecycle, run rabbitmq.RunConsumers, cfg config.Config, broker rabbitmq.Broker, r postgres.Repository, t Templates, d Dispatcher) {
+			if !run {
+				return
+			}
+
+			// the notifications of each channel are recorded apart from the message, so the handler
+			// doesn't run in the inbox transaction, which would undo them if another channel failed.
+			h := rabbitmq.Chain(
+				Handler(usecase.NewSendNotificationsUC(r, t, d, &cfg.Notification), cfg.MQ),
+				rabbitmq.Trace,
+				rabbitmq.Correlate,
+				rabbitmq.ReadYourWrites,
+			)
+
+			lc.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					err := broker.Subscribe(ctx, cfg.MQ.NotificationQueue, []string{cfg.MQ.Bind, cfg.MQ.TransferBind}, h)
+					if err != nil {
+						return fmt.Errorf("subscribing notification consumer: %w", err)
+					}
+
+					return nil
+				},
+			})
+		},
+	),
+)
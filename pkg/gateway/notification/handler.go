@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/gofrs/uuid/v5"
+	gorabbitmq "github.com/wagslane/go-rabbitmq"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Handler notifies the account and transfer creation events.
+// The events are identified by the created entity, so replays of an event are not notified again.
+// The events that fail to be notified are nacked with requeue, so the broker delivers them again after
+// the retry delay, skipping the channels already notified, until they run out of retries.
+func Handler(uc usecase.SendNotificationsUC, cfg config.RabbitMQConfig) rabbitmq.Handler {
+	return func(ctx context.Context, d gorabbitmq.Delivery) gorabbitmq.Action {
+		routingKey := d.RoutingKey
+		if from, ok := d.Headers["x-replayed-from"].(string); ok {
+			routingKey = from
+		}
+
+		var (
+			output usecase.SendNotificationsOutput
+			err    error
+		)
+		switch {
+		case rabbitmq.MatchTopic(cfg.Bind, routingKey):
+			var event struct {
+				AccountID uuid.UUID `json:"account_id"`
+			}
+			if err = json.Unmarshal(d.Body, &event); err != nil {
+				logger.Error(ctx, "decoding account event", zap.Error(err), zap.String("message_id", d.MessageId))
+				return gorabbitmq.NackDiscard
+			}
+
+			output, err = uc.NotifyAccountCreation(ctx, usecase.NotifyAccountCreationInput{
+				EventID:   event.AccountID.String(),
+				AccountID: event.AccountID,
+			})
+		case rabbitmq.MatchTopic(cfg.TransferBind, routingKey):
+			var transfer entities.Transfer
+			if err = json.Unmarshal(d.Body, &transfer); err != nil {
+				logger.Error(ctx, "decoding transfer event", zap.Error(err), zap.String("message_id", d.MessageId))
+				return gorabbitmq.NackDiscard
+			}
+
+			output, err = uc.NotifyTransfer(ctx, usecase.NotifyTransferInput{
+				EventID:  transfer.ID.String(),
+				Transfer: transfer,
+			})
+		default:
+			logger.Error(ctx, "unexpected notification event", zap.String("routing_key", routingKey), zap.String("message_id", d.MessageId))
+			return gorabbitmq.NackDiscard
+		}
+
+		if err != nil {
+			logger.Error(ctx, "sending notifications",
+				zap.Error(err),
+				zap.String("message_id", d.MessageId),
+				zap.Int("retries", rabbitmq.Retries(d)),
+			)
+			if errors.Is(err, domain.ErrNotFound) {
+				return gorabbitmq.NackDiscard
+			}
+			return gorabbitmq.NackRequeue
+		}
+
+		logger.Info(ctx, "notifications sent",
+			zap.String("message_id", d.MessageId),
+			zap.Int("sent", output.Sent),
+			zap.Int("skipped", output.Skipped),
+		)
+
+		return gorabbitmq.Ack
+	}
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+// SMSSender sends the sms notifications through the HTTP API of the SMS provider.
+type SMSSender struct {
+	Client *http.Client
+	url    string
+	token  string
+}
+
+func NewSMSSender(cfg config.NotificationConfig) SMSSender {
+	return SMSSender{Client: &http.Client{Timeout: cfg.Timeout}, url: cfg.SMSURL, token: cfg.SMSToken}
+}
+
+func (s SMSSender) Send(ctx context.Context, n entities.Notification) error {
+	return postJSON(ctx, s.Client, s.url, s.token, n.ID.String(), map[string]string{
+		"to":      n.Recipient,
+		"message": n.Body,
+	})
+}
+
+// PushSender sends the push notifications through the HTTP API of the push provider,
+// which targets the devices registered for the account.
+type PushSender struct {
+	Client *http.Client
+	url    string
+	token  string
+}
+
+func NewPushSender(cfg config.NotificationConfig) PushSender {
+	return PushSender{Client: &http.Client{Timeout: cfg.Timeout}, url: cfg.PushURL, token: cfg.PushToken}
+}
+
+func (s PushSender) Send(ctx context.Context, n entities.Notification) error {
+	return postJSON(ctx, s.Client, s.url, s.token, n.ID.String(), map[string]string{
+		"external_id": n.Recipient,
+		"title":       n.Subject,
+		"body":        n.Body,
+	})
+}
+
+// postJSON posts the payload to the provider, authenticated with the bearer token.
+// The ID of the notification is sent as the idempotency key, so the provider can discard the retries.
+func postJSON(ctx context.Context, client *http.Client, url, token, idempotencyKey string, payload map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notification not accepted by the provider: status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
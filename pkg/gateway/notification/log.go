@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// LogSender writes the notifications instead of sending them, so they can be inspected locally.
+type LogSender struct {
+	mu *sync.Mutex
+	// W receives the notifications as JSON lines. If nil, they are written to the logger.
+	W io.Writer
+}
+
+func NewLogSender(w io.Writer) LogSender {
+	return LogSender{mu: &sync.Mutex{}, W: w}
+}
+
+func (s LogSender) Send(ctx context.Context, n entities.Notification) error {
+	if s.W == nil {
+		logger.Info(ctx, "notification sent",
+			zap.String("notification_id", n.ID.String()),
+			zap.String("channel", string(n.Channel)),
+			zap.String("recipient", n.Recipient),
+			zap.String("subject", n.Subject),
+			zap.String("body", n.Body),
+		)
+		return nil
+	}
+
+	line, err := json.Marshal(struct {
+		ID        string    `json:"id"`
+		Event     string    `json:"event"`
+		Channel   string    `json:"channel"`
+		Recipient string    `json:"recipient"`
+		Subject   string    `json:"subject"`
+		Body      string    `json:"body"`
+		SentAt    time.Time `json:"sent_at"`
+	}{
+		ID:        n.ID.String(),
+		Event:     string(n.Event),
+		Channel:   string(n.Channel),
+		Recipient: n.Recipient,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		SentAt:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling notification: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err = s.W.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing notification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+// Dispatcher sends each notification with the sender of its channel.
+type Dispatcher map[entities.NotificationChannel]usecase.NotificationSender
+
+// NewDispatcher creates a dispatcher sending the notifications through the providers of the config.
+func NewDispatcher(cfg config.NotificationConfig) Dispatcher {
+	return Dispatcher{
+		entities.NotificationChannelEmail: NewSMTPSender(cfg),
+		entities.NotificationChannelSMS:   NewSMSSender(cfg),
+		entities.NotificationChannelPush:  NewPushSender(cfg),
+	}
+}
+
+// NewLogDispatcher creates a dispatcher writing the notifications of every channel to the log sender.
+func NewLogDispatcher(s LogSender) Dispatcher {
+	d := make(Dispatcher, len(entities.NotificationChannels))
+	for _, c := range entities.NotificationChannels {
+		d[c] = s
+	}
+
+	return d
+}
+
+func (d Dispatcher) Send(ctx context.Context, n entities.Notification) error {
+	s, ok := d[n.Channel]
+	if !ok {
+		return fmt.Errorf("channel %s not supported", n.Channel)
+	}
+
+	return s.Send(ctx, n) // nolint:wrapcheck
+}
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+// SMTPSender sends the email notifications through an SMTP server.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPSender(cfg config.NotificationConfig) SMTPSender {
+	var auth smtp.Auth
+	if cfg.SMTPUser != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return SMTPSender{
+		addr: net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		auth: auth,
+		from: cfg.SMTPFrom,
+	}
+}
+
+func (s SMTPSender) Send(_ context.Context, n entities.Notification) error {
+	headers := []string{
+		"From: " + s.from,
+		"To: " + n.Recipient,
+		"Subject: " + mime.QEncoding.Encode("utf-8", n.Subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+	}
+	msg := strings.Join(headers, "\r\n") + "\r\n\r\n" + n.Body + "\r\n"
+
+	err := smtp.SendMail(s.addr, s.auth, s.from, []string{n.Recipient}, []byte(msg))
+	if err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Templates renders the notifications with the templates of each locale.
+// Every locale defines the "<event>.subject" and "<event>.body" templates of the notification events.
+type Templates map[entities.Locale]*template.Template
+
+// localeFormats are the number and date formats of each locale.
+var localeFormats = map[entities.Locale]struct {
+	currency  string
+	thousands string
+	decimal   string
+	date      string
+}{
+	entities.LocalePtBR: {currency: "R$ ", thousands: ".", decimal: ",", date: "02/01/2006 15:04 MST"},
+	entities.LocaleEn:   {currency: "BRL ", thousands: ",", decimal: ".", date: "Jan 2, 2006 3:04 PM MST"},
+}
+
+func NewTemplates() (Templates, error) {
+	templates := make(Templates, len(entities.Locales))
+	for _, locale := range entities.Locales {
+		format := localeFormats[locale]
+		funcs := template.FuncMap{
+			"money": func(cents int) string {
+				return formatMoney(cents, format.currency, format.thousands, format.decimal)
+			},
+			"date": func(t time.Time) string {
+				return t.UTC().Format(format.date)
+			},
+		}
+
+		t, err := template.New(string(locale)).
+			Funcs(funcs).
+			Option("missingkey=error").
+			ParseFS(templateFS, "templates/"+string(locale)+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s templates: %w", locale, err)
+		}
+
+		for _, event := range entities.NotificationEvents {
+			for _, part := range []string{"subject", "body"} {
+				if t.Lookup(string(event)+"."+part) == nil {
+					return nil, fmt.Errorf("template %s.%s of locale %s not defined", event, part, locale)
+				}
+			}
+		}
+
+		templates[locale] = t
+	}
+
+	return templates, nil
+}
+
+func (t Templates) Render(locale entities.Locale, event entities.NotificationEvent, data usecase.NotificationData) (string, string, error) {
+	tmpl, ok := t[locale]
+	if !ok {
+		return "", "", fmt.Errorf("locale %s not supported", locale)
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, string(event)+".subject", data); err != nil {
+		return "", "", fmt.Errorf("executing subject template: %w", err)
+	}
+
+	if err := tmpl.ExecuteTemplate(&body, string(event)+".body", data); err != nil {
+		return "", "", fmt.Errorf("executing body template: %w", err)
+	}
+
+	return subject.String(), body.String(), nil
+}
+
+// formatMoney formats an amount in cents with the separators of the locale, e.g. 123456 as R$ 1.234,56.
+func formatMoney(cents int, currency, thousands, decimal string) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	units := fmt.Sprint(cents / 100)
+	var groups []string
+	for len(units) > 3 {
+		groups = append([]string{units[len(units)-3:]}, groups...)
+		units = units[:len(units)-3]
+	}
+	groups = append([]string{units}, groups...)
+
+	return fmt.Sprintf("%s%s%s%s%02d", sign, currency, strings.Join(groups, thousands), decimal, cents%100)
+}
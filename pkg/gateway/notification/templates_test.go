@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	t.Parallel()
+
+	templates, err := NewTemplates()
+	require.NoError(t, err)
+
+	data := usecase.NotificationData{
+		Name:        "Elliot",
+		Counterpart: "Mr.Robot",
+		Amount:      123456,
+		CreatedAt:   time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name        string
+		locale      entities.Locale
+		event       entities.NotificationEvent
+		wantSubject string
+		wantBody    string
+		wantErr     bool
+	}{
+		{
+			name:        "transfer received in pt-BR",
+			locale:      entities.LocalePtBR,
+			event:       entities.NotificationTransferReceived,
+			wantSubject: "Você recebeu uma transferência",
+			wantBody:    "Olá, Elliot! Você recebeu R$ 1.234,56 de Mr.Robot em 02/01/2024 15:04 UTC.",
+		},
+		{
+			name:        "transfer received in en",
+			locale:      entities.LocaleEn,
+			event:       entities.NotificationTransferReceived,
+			wantSubject: "You received a transfer",
+			wantBody:    "Hi, Elliot! You received BRL 1,234.56 from Mr.Robot on Jan 2, 2024 3:04 PM UTC.",
+		},
+		{
+			name:        "account created in en",
+			locale:      entities.LocaleEn,
+			event:       entities.NotificationAccountCreated,
+			wantSubject: "Welcome to E-Corp",
+			wantBody:    "Hi, Elliot! Your account was created on Jan 2, 2024 3:04 PM UTC.",
+		},
+		{
+			name:    "unsupported locale",
+			locale:  "es",
+			event:   entities.NotificationAccountCreated,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			subject, body, err := templates.Render(tt.locale, tt.event, data)
+
+			// assert
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantSubject, subject)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cents int
+		want  string
+	}{
+		{cents: 0, want: "R$ 0,00"},
+		{cents: 5, want: "R$ 0,05"},
+		{cents: 100000, want: "R$ 1.000,00"},
+		{cents: 123456789, want: "R$ 1.234.567,89"},
+		{cents: -2550, want: "-R$ 25,50"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatMoney(tt.cents, "R$ ", ".", ","))
+	}
+}
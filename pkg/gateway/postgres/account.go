@@ -14,6 +14,7 @@ import (
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
 	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
 	"github.com/higordasneves/e-corp/pkg/gateway/postgres/sqlc"
 )
 
@@ -31,7 +32,7 @@ func (r Repository) CreateAccount(ctx context.Context, acc entities.Account) err
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == pgerrcode.UniqueViolation {
-				return fmt.Errorf("%w: account with document %s already exists", domain.ErrInvalidParameter, acc.Document)
+				return domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account with document %s already exists", acc.Document)
 			}
 		}
 		return fmt.Errorf("creating account: %w", err)
@@ -40,6 +41,55 @@ func (r Repository) CreateAccount(ctx context.Context, acc entities.Account) err
 	return nil
 }
 
+// CreateAccounts inserts the accounts in the database at once, with a copy.
+// Returns domain.ErrConflict if an account with the document of one of them exists, in which case none is inserted.
+func (r Repository) CreateAccounts(ctx context.Context, accs []entities.Account) error {
+	params := make([]sqlc.InsertAccountsParams, 0, len(accs))
+	for _, acc := range accs {
+		params = append(params, sqlc.InsertAccountsParams{
+			ID:             acc.ID,
+			DocumentNumber: acc.Document.String(),
+			Name:           acc.Name,
+			Secret:         acc.Secret.String(),
+			Balance:        int64(acc.Balance),
+			CreatedAt:      acc.CreatedAt,
+		})
+	}
+
+	_, err := sqlc.New(r.conn.GetTxOrPool(ctx)).InsertAccounts(ctx, params)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == pgerrcode.UniqueViolation {
+				return domain.NewError(domain.ErrConflict, domain.CodeAccountAlreadyExists, "account with one of the documents already exists").WithCause(err)
+			}
+		}
+		return fmt.Errorf("creating accounts: %w", err)
+	}
+
+	return nil
+}
+
+// ListExistingDocuments returns the documents, among the provided ones, that belong to an account.
+func (r Repository) ListExistingDocuments(ctx context.Context, documents []vos.Document) ([]vos.Document, error) {
+	numbers := make([]string, 0, len(documents))
+	for _, document := range documents {
+		numbers = append(numbers, document.String())
+	}
+
+	rows, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ListExistingDocuments(ctx, numbers)
+	if err != nil {
+		return nil, fmt.Errorf("listing existing documents: %w", err)
+	}
+
+	existing := make([]vos.Document, 0, len(rows))
+	for _, number := range rows {
+		existing = append(existing, vos.Document(number))
+	}
+
+	return existing, nil
+}
+
 // ListAccounts Lists accounts by filtering the IDs provided in the input.
 // The field LastFetchedID is a cursor and represents the ID of
 // the last account listed (on the previous page).
@@ -61,12 +111,12 @@ func (r Repository) ListAccounts(ctx context.Context, input usecase.ListAccounts
 	if len(rows) >= input.PageSize+1 {
 		nextPage = &input
 		rows = rows[:len(rows)-1]
-		nextPage.LastFetchedID = rows[len(rows)-1].ID
+		nextPage.LastFetchedID = rows[len(rows)-1].Account.ID
 	}
 
 	accList := make([]entities.Account, 0, len(rows))
 	for _, row := range rows {
-		accList = append(accList, parseSqlcAccount(row))
+		accList = append(accList, parseSqlcAccount(row.Account, row.ShardBalance))
 	}
 
 	return usecase.ListAccountsOutput{
@@ -75,22 +125,65 @@ func (r Repository) ListAccounts(ctx context.Context, input usecase.ListAccounts
 	}, nil
 }
 
-// GetBalance returns the balance of the account for the provided ID.
+// GetAccount fetches an account by id.
+func (r Repository) GetAccount(ctx context.Context, id uuid.UUID) (entities.Account, error) {
+	row, err := sqlc.New(r.conn.GetTxOrPool(ctx)).GetAccount(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Account{}, domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", id)
+		}
+		return entities.Account{}, fmt.Errorf("getting account: %w", err)
+	}
+
+	return parseSqlcAccount(row.Account, row.ShardBalance), nil
+}
+
+// GetBalance returns the balance of the account for the provided ID, including the balance of its shards.
 func (r Repository) GetBalance(ctx context.Context, id uuid.UUID) (int, error) {
 	row, err := sqlc.New(r.conn.GetTxOrPool(ctx)).GetAccount(ctx, uuid.FromStringOrNil(id.String()))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, fmt.Errorf("%w: account %s not exists", domain.ErrNotFound, id)
+			return 0, domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", id)
 		}
 		return 0, fmt.Errorf("getting balance: %w", err)
 	}
 
-	return int(row.Balance), nil
+	return int(row.Account.Balance + row.ShardBalance), nil
 }
 
 // UpdateBalance updates an account by adding transactionAmount to the balance.
+// The credits of an account with balance shards go to a random shard, so concurrent credits don't wait
+// for each other. The debits sweep the shards into the balance of the account before subtracting from it,
+// locking the account and its shards until the end of the transaction.
 func (r Repository) UpdateBalance(ctx context.Context, id uuid.UUID, transactionAmount int) error {
-	err := sqlc.New(r.conn.GetTxOrPool(ctx)).UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
+	q := sqlc.New(r.conn.GetTxOrPool(ctx))
+
+	if transactionAmount < 0 {
+		err := q.SweepAccountBalance(ctx, sqlc.SweepAccountBalanceParams{
+			Amount: int64(transactionAmount),
+			ID:     id,
+		})
+		if err != nil {
+			return fmt.Errorf("debiting account balance: %w", err)
+		}
+
+		return nil
+	}
+
+	credited, err := q.CreditAccountBalanceShard(ctx, sqlc.CreditAccountBalanceShardParams{
+		Amount: int64(transactionAmount),
+		ID:     id,
+	})
+	if err != nil {
+		return fmt.Errorf("crediting account balance shard: %w", err)
+	}
+
+	if credited > 0 {
+		return nil
+	}
+
+	// the account has no shards, or they were reset since the number of shards was read.
+	err = q.UpdateAccountBalance(ctx, sqlc.UpdateAccountBalanceParams{
 		Amount: int32(transactionAmount), // nolint:gosec
 		ID:     uuid.FromStringOrNil(id.String()),
 	})
@@ -101,26 +194,96 @@ func (r Repository) UpdateBalance(ctx context.Context, id uuid.UUID, transaction
 	return nil
 }
 
+// SetBalanceShards splits the balance of the account in the number of shards.
+// The balance of the current shards is moved to the account, which holds it until it's debited.
+// Zero shards turns the sharding off.
+func (r Repository) SetBalanceShards(ctx context.Context, id uuid.UUID, shards int) error {
+	if shards < 0 {
+		return fmt.Errorf("invalid number of balance shards: %d", shards)
+	}
+
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		q := sqlc.New(r.conn.GetTxOrPool(ctx))
+
+		updated, err := q.ResetAccountBalanceShards(ctx, sqlc.ResetAccountBalanceShardsParams{
+			Shards: int32(shards), // nolint:gosec
+			ID:     id,
+		})
+		if err != nil {
+			return fmt.Errorf("resetting balance shards: %w", err)
+		}
+
+		if updated == 0 {
+			return domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", id)
+		}
+
+		err = q.InsertAccountBalanceShards(ctx, sqlc.InsertAccountBalanceShardsParams{
+			AccountID: id,
+			Shards:    int32(shards), // nolint:gosec
+		})
+		if err != nil {
+			return fmt.Errorf("inserting balance shards: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateAccount updates the name of the account if its version is still the expected one,
+// or whatever its version is if the expected one is zero.
+// Returns domain.ErrConflict if the account was updated since the expected version was read.
+func (r Repository) UpdateAccount(ctx context.Context, acc entities.Account, expectedVersion int) (entities.Account, error) {
+	q := sqlc.New(r.conn.GetTxOrPool(ctx))
+
+	row, err := q.UpdateAccount(ctx, sqlc.UpdateAccountParams{
+		Name:            acc.Name,
+		ID:              acc.ID,
+		ExpectedVersion: int64(expectedVersion),
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return entities.Account{}, fmt.Errorf("updating account: %w", err)
+		}
+
+		// either the account doesn't exist or its version changed.
+		if _, err = r.GetAccount(dbpool.ReadYourWrites(ctx), acc.ID); err != nil {
+			return entities.Account{}, err
+		}
+
+		return entities.Account{}, domain.NewError(domain.ErrConflict, domain.CodeAccountVersionConflict, "account %s was updated since version %d", acc.ID, expectedVersion)
+	}
+
+	shardBalance, err := q.GetAccountShardBalance(ctx, acc.ID)
+	if err != nil {
+		return entities.Account{}, fmt.Errorf("getting account shard balance: %w", err)
+	}
+
+	return parseSqlcAccount(row, shardBalance), nil
+}
+
 // GetAccountByDocument fetches an account the by document number.
+// It reads from the primary, so an account can log in right after it's created.
 func (r Repository) GetAccountByDocument(ctx context.Context, cpf vos.Document) (entities.Account, error) {
-	row, err := sqlc.New(r.conn.GetTxOrPool(ctx)).GetAccountByDocument(ctx, cpf.String())
+	row, err := sqlc.New(r.conn.GetTxOrPool(dbpool.ReadYourWrites(ctx))).GetAccountByDocument(ctx, cpf.String())
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return entities.Account{}, fmt.Errorf("%w: account %s not exists", domain.ErrNotFound, cpf)
+			return entities.Account{}, domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", cpf)
 		}
 		return entities.Account{}, fmt.Errorf("getting account: %w", err)
 	}
 
-	return parseSqlcAccount(row), nil
+	return parseSqlcAccount(row.Account, row.ShardBalance), nil
 }
 
-func parseSqlcAccount(a sqlc.Account) entities.Account {
+// parseSqlcAccount converts the row of the account, adding the balance of its shards to the balance.
+func parseSqlcAccount(a sqlc.Account, shardBalance int64) entities.Account {
 	return entities.Account{
 		ID:        a.ID,
 		Name:      a.Name,
 		Document:  vos.Document(a.DocumentNumber),
 		Secret:    vos.Secret(a.Secret),
-		Balance:   int(a.Balance),
+		Balance:   int(a.Balance + shardBalance),
 		CreatedAt: a.CreatedAt,
+		Version:   int(a.Version),
 	}
 }
@@ -2,17 +2,26 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/higordasneves/e-corp/pkg/domain"
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
 	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
 )
 
 func TestAccRepo_CreateAccount(t *testing.T) {
@@ -46,7 +55,7 @@ func TestAccRepo_CreateAccount(t *testing.T) {
 				Balance:   0,
 				CreatedAt: time.Now().Truncate(time.Second),
 			},
-			wantErr: domain.ErrInvalidParameter,
+			wantErr: domain.ErrConflict,
 		},
 	}
 
@@ -309,3 +318,79 @@ func TestAccRepo_GetAccountByDocument(t *testing.T) {
 		})
 	}
 }
+
+// BenchmarkTransfer_HotAccount measures concurrent transfers from many accounts to the same account,
+// whose balance is held by its row or split in shards:
+//
+//	go test ./pkg/gateway/postgres -run '^$' -bench HotAccount -cpu 16
+func BenchmarkTransfer_HotAccount(b *testing.B) {
+	for _, shards := range []int{0, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			// setup: an origin account for each goroutine, so only the destination is contended.
+			conn := NewDB(b)
+			r := NewRepository(conn)
+			ctx := context.Background()
+
+			newAccount := func(i int) entities.Account {
+				acc := entities.Account{
+					ID:        uuid.Must(uuid.NewV7()),
+					Name:      "Elliot",
+					Document:  vos.Document(fmt.Sprintf("%011d", i)),
+					Secret:    "password",
+					Balance:   1 << 40,
+					CreatedAt: time.Now().Truncate(time.Second),
+				}
+				require.NoError(b, r.CreateAccount(ctx, acc))
+
+				return acc
+			}
+
+			merchant := newAccount(0)
+			require.NoError(b, r.SetBalanceShards(ctx, merchant.ID, shards))
+
+			origins := make([]entities.Account, runtime.GOMAXPROCS(0))
+			for i := range origins {
+				origins[i] = newAccount(i + 1)
+			}
+
+			txName := fmt.Sprintf("benchmark_hot_account_%d", shards)
+			runner := dbpool.NewTxRunner(conn, config.DatabaseConfig{
+				TxMaxAttempts:    100,
+				TxRetryBaseDelay: time.Millisecond,
+				TxRetryMaxDelay:  50 * time.Millisecond,
+			}, dbpool.TxOptions{Name: txName, IsoLevel: pgx.Serializable})
+			uc := usecase.NewTransferUC(r, runner, &mocks.TransferUCBrokerMock{})
+
+			retries := func() float64 {
+				return testutil.ToFloat64(dbpool.TxRetries.WithLabelValues(txName, pgerrcode.SerializationFailure)) +
+					testutil.ToFloat64(dbpool.TxRetries.WithLabelValues(txName, pgerrcode.DeadlockDetected))
+			}
+			retriesBefore := retries()
+			var next atomic.Int32
+
+			// execute
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				origin := origins[next.Add(1)-1]
+				for pb.Next() {
+					_, err := uc.Transfer(ctx, usecase.TransferInput{
+						AccountOriginID:      origin.ID,
+						AccountDestinationID: merchant.ID,
+						Amount:               1,
+					})
+					if err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+			b.StopTimer()
+
+			// assert: every transfer was credited
+			balance, err := r.GetBalance(ctx, merchant.ID)
+			require.NoError(b, err)
+			assert.Equal(b, 1<<40+b.N, balance)
+			b.ReportMetric((retries()-retriesBefore)/float64(b.N), "retries/op")
+		})
+	}
+}
@@ -11,13 +11,20 @@ import (
 )
 
 type Conn struct {
-	dbPool *pgxpool.Pool
+	dbPool   *pgxpool.Pool
+	replicas *Replicas
 }
 
 func NewConn(dbPool *pgxpool.Pool) Conn {
 	return Conn{dbPool: dbPool}
 }
 
+// WithReplicas returns a connection that runs the read-only queries outside transactions on the replicas.
+func (c Conn) WithReplicas(replicas *Replicas) Conn {
+	c.replicas = replicas
+	return c
+}
+
 type txCtxKey struct{}
 
 type Querier interface {
@@ -28,8 +35,25 @@ type Querier interface {
 	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
 }
 
+// BeginTX starts a transaction and returns a context carrying it.
+// If the context already carries a transaction, it creates a savepoint in it instead,
+// which CommitTX releases and RollbackTX rolls back to. So composed use cases share the outer transaction.
 func (c Conn) BeginTX(ctx context.Context) (context.Context, error) {
-	tx, err := c.dbPool.Begin(ctx)
+	return c.beginTX(ctx, pgx.TxOptions{})
+}
+
+// beginTX starts a transaction with the options. The savepoints run with the options of their transaction.
+func (c Conn) beginTX(ctx context.Context, opts pgx.TxOptions) (context.Context, error) {
+	if tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		savepoint, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating a savepoint: %w", err)
+		}
+
+		return context.WithValue(ctx, txCtxKey{}, savepoint), nil
+	}
+
+	tx, err := c.dbPool.BeginTx(ctx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("beginning a transaction: %w", err)
 	}
@@ -37,41 +61,78 @@ func (c Conn) BeginTX(ctx context.Context) (context.Context, error) {
 	return context.WithValue(ctx, txCtxKey{}, tx), nil
 }
 
-func (c Conn) GetTxOrPool(ctx context.Context) Querier {
-	if v := ctx.Value(txCtxKey{}); v != nil {
-		if pgxTx, ok := v.(*pgxpool.Tx); ok {
-			return pgxTx
+// WithTx runs fn in a transaction, or in a savepoint of the transaction of the context.
+// The transaction is committed if fn succeeds and rolled back if it returns an error or panics.
+func (c Conn) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return c.withTx(ctx, pgx.TxOptions{}, fn)
+}
+
+func (c Conn) withTx(ctx context.Context, opts pgx.TxOptions, fn func(ctx context.Context) error) (err error) {
+	ctx, err = c.beginTX(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = c.RollbackTX(context.WithoutCancel(ctx))
+			panic(p)
 		}
+	}()
+
+	if err = fn(ctx); err != nil {
+		// the transaction must be rolled back even if the context was canceled.
+		if rollbackErr := c.RollbackTX(context.WithoutCancel(ctx)); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
 	}
 
-	return c.dbPool
+	return c.CommitTX(ctx)
+}
+
+// inTx reports whether the context carries a transaction.
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txCtxKey{}).(pgx.Tx)
+	return ok
+}
+
+// GetTxOrPool returns the transaction of the context, or the pool if it carries none.
+// With replicas, the selects outside transactions run on them,
+// unless the context is marked by ReadYourWrites or all the replicas lag too much.
+func (c Conn) GetTxOrPool(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	if c.replicas == nil || readsYourWrites(ctx) {
+		return c.dbPool
+	}
+
+	return router{primary: c.dbPool, replicas: c.replicas}
 }
 
 func (c Conn) CommitTX(ctx context.Context) error {
-	if v := ctx.Value(txCtxKey{}); v != nil {
-		if pgxTx, ok := v.(*pgxpool.Tx); ok {
-			err := pgxTx.Commit(ctx)
-			if err != nil {
-				return fmt.Errorf("committing a transaction: %w", err)
-			}
-
-			return nil
+	if tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		err := tx.Commit(ctx)
+		if err != nil {
+			return fmt.Errorf("committing a transaction: %w", err)
 		}
+
+		return nil
 	}
 
 	return errors.New("transaction not found")
 }
 
 func (c Conn) RollbackTX(ctx context.Context) error {
-	if v := ctx.Value(txCtxKey{}); v != nil {
-		if pgxTx, ok := v.(*pgxpool.Tx); ok {
-			err := pgxTx.Rollback(ctx)
-			if err != nil {
-				return fmt.Errorf("rolling back a transaction: %w", err)
-			}
-
-			return nil
+	if tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		err := tx.Rollback(ctx)
+		if err != nil {
+			return fmt.Errorf("rolling back a transaction: %w", err)
 		}
+
+		return nil
 	}
 
 	return errors.New("transaction not found")
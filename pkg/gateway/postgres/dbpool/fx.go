@@ -4,19 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/fx"
 
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/health"
 )
 
 var Module = fx.Module("dbpool",
 	fx.Provide(
 		fx.Annotate(
 			func(ctx context.Context, lc fx.Lifecycle, cfg config.Config) (*pgxpool.Pool, error) {
-				dbPool, err := pgxpool.New(ctx, cfg.DB.DNS())
+				dbPool, err := newPool(ctx, cfg.DB.DNS())
 				if err != nil {
-					return nil, fmt.Errorf("creating new pgx pool: %w", err)
+					return nil, err
 				}
 
 				if err = dbPool.Ping(ctx); err != nil {
@@ -33,6 +35,66 @@ var Module = fx.Module("dbpool",
 				return dbPool, nil
 			},
 		),
-		NewConn,
+		// the replicas are nil if none is configured. They are not pinged, an unreachable replica
+		// just doesn't take reads until the lag check reaches it.
+		func(ctx context.Context, lc fx.Lifecycle, cfg config.Config) (*Replicas, error) {
+			if len(cfg.DB.ReplicaDSNs) == 0 {
+				return nil, nil
+			}
+
+			pools := make([]*pgxpool.Pool, 0, len(cfg.DB.ReplicaDSNs))
+			for _, dsn := range cfg.DB.ReplicaDSNs {
+				pool, err := newPool(ctx, dsn)
+				if err != nil {
+					for _, p := range pools {
+						p.Close()
+					}
+					return nil, fmt.Errorf("replica: %w", err)
+				}
+				pools = append(pools, pool)
+			}
+
+			replicas := NewReplicas(pools, cfg.DB.ReplicaMaxLag)
+			runCtx, cancel := context.WithCancel(ctx)
+
+			lc.Append(fx.Hook{
+				OnStart: func(context.Context) error {
+					go replicas.Run(runCtx, cfg.DB.ReplicaLagCheckInterval)
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					cancel()
+					replicas.Close()
+					return nil
+				},
+			})
+
+			return replicas, nil
+		},
+		func(pool *pgxpool.Pool, replicas *Replicas) Conn {
+			return NewConn(pool).WithReplicas(replicas)
+		},
+		fx.Annotate(
+			func(pool *pgxpool.Pool) health.Check {
+				return health.Check{Name: "postgres", Probe: pool.Ping}
+			},
+			fx.ResultTags(health.Group),
+		),
 	),
 )
+
+func newPool(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parsing pool config: %w", err)
+	}
+	// the tracer uses the global tracer provider, which is a no-op if tracing is not registered.
+	poolCfg.ConnConfig.Tracer = otelpgx.NewTracer()
+
+	dbPool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating new pgx pool: %w", err)
+	}
+
+	return dbPool, nil
+}
@@ -0,0 +1,97 @@
+package dbpool
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsCollector exposes the statistics of the pool as prometheus metrics.
+// The statistics are read from the pool on every scrape.
+type StatsCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+}
+
+func NewStatsCollector(pool *pgxpool.Pool) *StatsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("ecorp", "dbpool", name), help, nil, nil)
+	}
+
+	return &StatsCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_conns", "Connections currently acquired from the pool."),
+		idleConns:            desc("idle_conns", "Idle connections in the pool."),
+		totalConns:           desc("total_conns", "Connections in the pool, including the ones being constructed."),
+		maxConns:             desc("max_conns", "Maximum size of the pool."),
+		acquireCount:         desc("acquires_total", "Successful acquires from the pool."),
+		acquireDuration:      desc("acquire_wait_seconds_total", "Time spent waiting for successful acquires from the pool."),
+		emptyAcquireCount:    desc("empty_acquires_total", "Acquires that waited for a connection because the pool was empty."),
+		canceledAcquireCount: desc("canceled_acquires_total", "Acquires canceled by the context."),
+	}
+}
+
+func (c *StatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquireCount
+}
+
+func (c *StatsCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+}
+
+var (
+	// TxRetries counts the transactions run again by use case and SQLSTATE of the failure.
+	TxRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ecorp",
+		Subsystem: "db",
+		Name:      "tx_retries_total",
+		Help:      "Transactions run again after a serialization failure or a deadlock.",
+	}, []string{"tx", "code"})
+
+	// TxRetriesExhausted counts the transactions that still failed on the last attempt by use case.
+	TxRetriesExhausted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ecorp",
+		Subsystem: "db",
+		Name:      "tx_retries_exhausted_total",
+		Help:      "Transactions that failed on a serialization failure or a deadlock on every attempt.",
+	}, []string{"tx"})
+
+	// ReplicaLag is the replication lag of the read replicas measured by the last check.
+	ReplicaLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ecorp",
+		Subsystem: "db",
+		Name:      "replica_lag_seconds",
+		Help:      "Replication lag of the read replicas.",
+	}, []string{"replica"})
+
+	// ReplicaFallbacks counts the read-only queries run on the primary because no replica was available.
+	ReplicaFallbacks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "ecorp",
+		Subsystem: "db",
+		Name:      "replica_fallbacks_total",
+		Help:      "Read-only queries run on the primary because every replica lagged or was unreachable.",
+	})
+)
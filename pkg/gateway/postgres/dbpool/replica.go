@@ -0,0 +1,203 @@
+package dbpool
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+type readYourWritesCtxKey struct{}
+
+// ReadYourWrites returns a context whose queries run on the primary,
+// so they see the writes committed right before them, which may not have reached the replicas yet.
+func ReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesCtxKey{}, true)
+}
+
+func readsYourWrites(ctx context.Context) bool {
+	v, _ := ctx.Value(readYourWritesCtxKey{}).(bool)
+	return v
+}
+
+// lagQuery returns the replication lag in seconds. A replica that replayed all the WAL it received
+// has no lag, even if the primary had no writes since the last replayed transaction.
+// A replica that is not streaming from the primary has no WAL to receive, so it would stay without lag
+// while serving stale reads: its lag is infinite until it streams again. The status of the WAL receiver
+// is only visible to the roles of pg_read_all_stats, like pg_monitor, so the user of the replicas needs it.
+// It returns zero on a server that is not a replica.
+const lagQuery = `select case
+	when not pg_is_in_recovery() then 0::float8
+	when not exists (select 1 from pg_stat_wal_receiver where status = 'streaming') then 'Infinity'::float8
+	when pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() then 0::float8
+	else coalesce(extract(epoch from now() - pg_last_xact_replay_timestamp()), 0)::float8
+end`
+
+// Replicas are the pools of the read replicas.
+// The lag of the replicas is checked periodically, and the reads are spread over the replicas
+// within the max lag. The reads go to the primary while none of them is.
+type Replicas struct {
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+type replica struct {
+	// name identifies the replica in the metrics and logs.
+	name string
+	pool *pgxpool.Pool
+	// available is false until the first lag check and while the replica lags or can't be reached.
+	available atomic.Bool
+}
+
+func NewReplicas(pools []*pgxpool.Pool, maxLag time.Duration) *Replicas {
+	replicas := make([]*replica, 0, len(pools))
+	for _, pool := range pools {
+		replicas = append(replicas, &replica{name: pool.Config().ConnConfig.Host, pool: pool})
+	}
+
+	return &Replicas{replicas: replicas, maxLag: maxLag}
+}
+
+// Run checks the lag of the replicas right away and then on every interval, until the context is canceled.
+func (r *Replicas) Run(ctx context.Context, interval time.Duration) {
+	r.CheckLag(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckLag(ctx)
+		}
+	}
+}
+
+// CheckLag measures the lag of the replicas and updates which ones take the reads.
+func (r *Replicas) CheckLag(ctx context.Context) {
+	for _, rep := range r.replicas {
+		var lag float64
+		err := rep.pool.QueryRow(ctx, lagQuery).Scan(&lag)
+		if err == nil {
+			ReplicaLag.WithLabelValues(rep.name).Set(lag)
+		}
+
+		available := err == nil && time.Duration(lag*float64(time.Second)) <= r.maxLag
+		if rep.available.Swap(available) == available {
+			continue
+		}
+
+		if available {
+			logger.Info(ctx, "replica available", zap.String("replica", rep.name))
+		} else {
+			logger.Error(ctx, "replica unavailable, reading from the primary",
+				zap.String("replica", rep.name),
+				zap.Float64("lag_seconds", lag),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// Close closes the pools of the replicas.
+func (r *Replicas) Close() {
+	for _, rep := range r.replicas {
+		rep.pool.Close()
+	}
+}
+
+// pool returns the pool of the next available replica in turn.
+func (r *Replicas) pool() (*pgxpool.Pool, bool) {
+	n := uint64(len(r.replicas))
+	if n == 0 {
+		return nil, false
+	}
+
+	start := r.next.Add(1)
+	for i := range n {
+		if rep := r.replicas[(start+i)%n]; rep.available.Load() {
+			return rep.pool, true
+		}
+	}
+
+	return nil, false
+}
+
+// router runs the read-only queries on the replicas and everything else on the primary.
+type router struct {
+	primary  *pgxpool.Pool
+	replicas *Replicas
+}
+
+func (r router) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return r.primary.Exec(ctx, sql, arguments...) // nolint:wrapcheck
+}
+
+func (r router) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return r.querier(sql).Query(ctx, sql, args...) // nolint:wrapcheck
+}
+
+func (r router) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return r.querier(sql).QueryRow(ctx, sql, args...)
+}
+
+func (r router) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return r.primary.SendBatch(ctx, b)
+}
+
+func (r router) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return r.primary.CopyFrom(ctx, tableName, columnNames, rowSrc) // nolint:wrapcheck
+}
+
+func (r router) querier(sql string) Querier {
+	if !readOnly(sql) {
+		return r.primary
+	}
+
+	if pool, ok := r.replicas.pool(); ok {
+		return pool
+	}
+
+	ReplicaFallbacks.Inc()
+	return r.primary
+}
+
+// readOnly reports whether the query is a select without locking clauses,
+// skipping the leading comments like the name of the sqlc queries.
+func readOnly(sql string) bool {
+	for {
+		sql = strings.TrimSpace(sql)
+		if !strings.HasPrefix(sql, "--") {
+			break
+		}
+
+		_, sql, _ = strings.Cut(sql, "\n")
+	}
+
+	keyword, _, _ := strings.Cut(sql, " ")
+	keyword, _, _ = strings.Cut(keyword, "\n")
+
+	if !strings.EqualFold(keyword, "select") {
+		return false
+	}
+
+	// the replicas can't lock rows.
+	lower := strings.ToLower(sql)
+	for _, clause := range []string{"for update", "for no key update", "for share", "for key share"} {
+		if strings.Contains(lower, clause) {
+			return false
+		}
+	}
+
+	return true
+}
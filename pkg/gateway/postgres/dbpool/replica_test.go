@@ -0,0 +1,113 @@
+package dbpool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		sql  string
+		want bool
+	}{
+		{
+			name: "sqlc select",
+			sql:  "-- name: GetAccount :one\nselect id, balance\nfrom accounts\nwhere id = $1\n",
+			want: true,
+		},
+		{name: "upper case select", sql: "  SELECT 1", want: true},
+		{name: "select on a new line", sql: "select\n*\nfrom accounts", want: true},
+		{
+			name: "locking select",
+			sql:  "-- name: ListDue :many\nselect id from webhook_deliveries\nfor update skip locked;",
+		},
+		{name: "select for share", sql: "select id from accounts FOR SHARE"},
+		{name: "insert", sql: "-- name: InsertAccount :exec\ninsert into accounts (id) values ($1)"},
+		{name: "update returning", sql: "update accounts set balance = 0 returning id"},
+		{name: "with", sql: "with deleted as (delete from accounts returning id) select * from deleted"},
+		{name: "comment only", sql: "-- select"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			got := readOnly(tt.sql)
+
+			// assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReplicas_pool(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	first, second := &pgxpool.Pool{}, &pgxpool.Pool{}
+	replicas := &Replicas{replicas: []*replica{{name: "first", pool: first}, {name: "second", pool: second}}}
+
+	// execute: none available
+	_, ok := replicas.pool()
+
+	// assert
+	assert.False(t, ok)
+
+	// execute: the reads are spread over the available replicas
+	replicas.replicas[0].available.Store(true)
+	replicas.replicas[1].available.Store(true)
+	got := map[*pgxpool.Pool]int{}
+	for range 10 {
+		pool, ok := replicas.pool()
+		assert.True(t, ok)
+		got[pool]++
+	}
+
+	// assert
+	assert.Equal(t, map[*pgxpool.Pool]int{first: 5, second: 5}, got)
+
+	// execute: one lags
+	replicas.replicas[0].available.Store(false)
+	for range 10 {
+		pool, ok := replicas.pool()
+
+		// assert
+		assert.True(t, ok)
+		assert.Same(t, second, pool)
+	}
+}
+
+func TestConn_GetTxOrPool(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	primary := &pgxpool.Pool{}
+	ctx := context.Background()
+
+	// execute: without replicas
+	got := NewConn(primary).GetTxOrPool(ctx)
+
+	// assert
+	assert.Same(t, primary, got)
+
+	// execute: with replicas
+	conn := NewConn(primary).WithReplicas(&Replicas{})
+	got = conn.GetTxOrPool(ctx)
+
+	// assert: the replica isn't available, so the reads fall back to the primary
+	assert.IsType(t, router{}, got)
+	assert.Same(t, primary, got.(router).querier("select 1"))
+
+	// execute: read your writes
+	got = conn.GetTxOrPool(ReadYourWrites(ctx))
+
+	// assert
+	assert.Same(t, primary, got)
+}
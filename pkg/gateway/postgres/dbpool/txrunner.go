@@ -0,0 +1,111 @@
+package dbpool
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// TxOptions configures the transactions of a use case.
+type TxOptions struct {
+	// Name identifies the use case in the metrics and logs.
+	Name     string
+	IsoLevel pgx.TxIsoLevel
+}
+
+// TxRunner runs the units of work of a use case in transactions.
+// A unit of work that fails on a serialization failure or a deadlock is run again in a new transaction,
+// after a jittered exponential backoff.
+type TxRunner struct {
+	conn        Conn
+	opts        TxOptions
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+func NewTxRunner(conn Conn, cfg config.DatabaseConfig, opts TxOptions) TxRunner {
+	return TxRunner{
+		conn:        conn,
+		opts:        opts,
+		maxAttempts: max(cfg.TxMaxAttempts, 1),
+		baseDelay:   cfg.TxRetryBaseDelay,
+		maxDelay:    cfg.TxRetryMaxDelay,
+	}
+}
+
+// RunTx runs fn in a transaction, which is committed if fn succeeds and rolled back otherwise.
+// fn may run more than once, so it must not have effects outside the transaction.
+// If the context already carries a transaction, fn runs in a savepoint of it and is not retried,
+// since the failure aborts the outer transaction, which must be retried as a whole.
+func (r TxRunner) RunTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTx(ctx) {
+		return r.conn.WithTx(ctx, fn)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.conn.withTx(ctx, pgx.TxOptions{IsoLevel: r.opts.IsoLevel}, fn)
+
+		code, retryable := retryableCode(err)
+		if !retryable {
+			return err
+		}
+
+		if attempt >= r.maxAttempts {
+			TxRetriesExhausted.WithLabelValues(r.opts.Name).Inc()
+			return err
+		}
+
+		TxRetries.WithLabelValues(r.opts.Name, code).Inc()
+		logger.Info(ctx, "retrying transaction",
+			zap.String("tx", r.opts.Name),
+			zap.String("code", code),
+			zap.Int("attempt", attempt),
+		)
+
+		select {
+		case <-time.After(r.backoff(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// backoff returns a random delay up to the exponential delay of the attempt, the "full jitter" strategy,
+// so the transactions that conflicted don't run again at the same time.
+func (r TxRunner) backoff(attempt int) time.Duration {
+	delay := r.maxDelay
+	if shift := attempt - 1; shift < 32 && r.baseDelay<<shift < r.maxDelay {
+		delay = r.baseDelay << shift
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return rand.N(delay) //nolint:gosec
+}
+
+// retryableCode returns the SQLSTATE of the error if the transaction can succeed when run again.
+func retryableCode(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return "", false
+	}
+
+	switch pgErr.Code {
+	case pgerrcode.SerializationFailure, pgerrcode.DeadlockDetected:
+		return pgErr.Code, true
+	}
+
+	return "", false
+}
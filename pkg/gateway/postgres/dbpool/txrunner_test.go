@@ -0,0 +1,91 @@
+package dbpool
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+func TestTxRunner_Backoff(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewTxRunner(Conn{}, config.DatabaseConfig{
+		TxMaxAttempts:    10,
+		TxRetryBaseDelay: 10 * time.Millisecond,
+		TxRetryMaxDelay:  50 * time.Millisecond,
+	}, TxOptions{})
+
+	tests := []struct {
+		attempt  int
+		maxDelay time.Duration
+	}{
+		{attempt: 1, maxDelay: 10 * time.Millisecond},
+		{attempt: 2, maxDelay: 20 * time.Millisecond},
+		{attempt: 3, maxDelay: 40 * time.Millisecond},
+		{attempt: 4, maxDelay: 50 * time.Millisecond},
+		{attempt: 100, maxDelay: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.attempt), func(t *testing.T) {
+			t.Parallel()
+
+			for range 100 {
+				// execute
+				got := r.backoff(tt.attempt)
+
+				// assert
+				assert.GreaterOrEqual(t, got, time.Duration(0))
+				assert.Less(t, got, tt.maxDelay)
+			}
+		})
+	}
+}
+
+func TestRetryableCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      string
+		wantRetryable bool
+	}{
+		{
+			name:          "serialization failure",
+			err:           fmt.Errorf("committing a transaction: %w", &pgconn.PgError{Code: pgerrcode.SerializationFailure}),
+			wantCode:      pgerrcode.SerializationFailure,
+			wantRetryable: true,
+		},
+		{
+			name:          "deadlock",
+			err:           &pgconn.PgError{Code: pgerrcode.DeadlockDetected},
+			wantCode:      pgerrcode.DeadlockDetected,
+			wantRetryable: true,
+		},
+		{name: "other database error", err: &pgconn.PgError{Code: pgerrcode.UniqueViolation}},
+		{name: "not a database error", err: errors.New("insufficient funds")},
+		{name: "no error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			code, retryable := retryableCode(tt.err)
+
+			// assert
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantRetryable, retryable)
+		})
+	}
+}
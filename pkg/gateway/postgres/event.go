@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/sqlc"
+)
+
+// AppendEvent inserts an event in the event store.
+func (r Repository) AppendEvent(ctx context.Context, e entities.Event) error {
+	err := sqlc.New(r.conn.GetTxOrPool(ctx)).InsertEvent(ctx, sqlc.InsertEventParams{
+		ID:            e.ID,
+		AggregateType: string(e.AggregateType),
+		AggregateID:   e.AggregateID,
+		RoutingKey:    e.RoutingKey,
+		Payload:       e.Payload,
+		CreatedAt:     e.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("inserting event %s: %w", e.ID, err)
+	}
+
+	return nil
+}
+
+// ListEvents lists the events matching the filter in the order they were appended, starting after the event
+// at the position afterSequence.
+func (r Repository) ListEvents(ctx context.Context, filter entities.EventFilter, afterSequence int64, pageSize int) ([]entities.Event, error) {
+	params := sqlc.ListEventsParams{
+		AfterSequence: afterSequence,
+		AggregateID:   filter.AggregateID,
+		FromTime:      filter.From,
+		PageSize:      int32(pageSize), //nolint:gosec
+	}
+	if !filter.To.IsZero() {
+		params.ToTime = &filter.To
+	}
+
+	rows, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ListEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("listing events: %w", err)
+	}
+
+	return newEvents(rows), nil
+}
+
+// ListAccountEvents lists the events of the transfers sent or received by the account in the order they were committed,
+// starting after the event at the position afterSequence of the stream of the account.
+func (r Repository) ListAccountEvents(ctx context.Context, accountID uuid.UUID, afterSequence int64, pageSize int) ([]entities.AccountEvent, error) {
+	rows, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ListAccountEvents(ctx, sqlc.ListAccountEventsParams{
+		AccountID:     accountID,
+		AfterSequence: afterSequence,
+		PageSize:      int32(pageSize), //nolint:gosec
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing account events: %w", err)
+	}
+
+	events := make([]entities.AccountEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, entities.AccountEvent{
+			AccountID: accountID,
+			Sequence:  row.AccountSequence,
+			Balance:   int(row.Balance),
+			Event:     newEvent(row.Event),
+		})
+	}
+
+	return events, nil
+}
+
+func newEvents(rows []sqlc.Event) []entities.Event {
+	events := make([]entities.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, newEvent(row))
+	}
+
+	return events
+}
+
+func newEvent(row sqlc.Event) entities.Event {
+	return entities.Event{
+		ID:            row.ID,
+		Sequence:      row.Sequence,
+		AggregateType: entities.EventAggregateType(row.AggregateType),
+		AggregateID:   row.AggregateID,
+		RoutingKey:    row.RoutingKey,
+		Payload:       row.Payload,
+		CreatedAt:     row.CreatedAt,
+	}
+}
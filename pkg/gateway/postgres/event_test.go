@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+func TestEventRepo_ListEvents(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewRepository(NewDB(t))
+	ctx := context.Background()
+
+	accountID := uuid.Must(uuid.NewV4())
+	transferID := uuid.Must(uuid.NewV4())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	events := []entities.Event{
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateAccount,
+			AggregateID:   accountID,
+			RoutingKey:    "ecorp.accountCreation",
+			Payload:       []byte(`{"account_id": "1"}`),
+			CreatedAt:     start,
+		},
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   transferID,
+			RoutingKey:    "ecorp.transferCreation",
+			Payload:       []byte(`{"id": "2"}`),
+			CreatedAt:     start.Add(time.Hour),
+		},
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateAccount,
+			AggregateID:   accountID,
+			RoutingKey:    "ecorp.accountCreation",
+			Payload:       []byte(`{"account_id": "3"}`),
+			CreatedAt:     start.Add(2 * time.Hour),
+		},
+	}
+	for _, e := range events {
+		require.NoError(t, r.AppendEvent(ctx, e))
+	}
+
+	ids := func(events []entities.Event) []uuid.UUID {
+		result := make([]uuid.UUID, 0, len(events))
+		for _, e := range events {
+			result = append(result, e.ID)
+		}
+		return result
+	}
+
+	// execute: all the events
+	all, err := r.ListEvents(ctx, entities.EventFilter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, ids(events), ids(all))
+	assert.JSONEq(t, `{"account_id": "1"}`, string(all[0].Payload))
+	assert.Equal(t, entities.EventAggregateAccount, all[0].AggregateType)
+
+	// execute: next page
+	got, err := r.ListEvents(ctx, entities.EventFilter{}, all[0].Sequence, 1)
+	require.NoError(t, err)
+	assert.Equal(t, ids(events[1:2]), ids(got))
+
+	// execute: by aggregate
+	got, err = r.ListEvents(ctx, entities.EventFilter{AggregateID: accountID}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[0].ID, events[2].ID}, ids(got))
+
+	// execute: by time range
+	got, err = r.ListEvents(ctx, entities.EventFilter{From: start.Add(time.Hour), To: start.Add(2 * time.Hour)}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, ids(events[1:2]), ids(got))
+}
+
+func TestEventRepo_AppendOnly(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	db := NewDB(t)
+	r := NewRepository(db)
+	ctx := context.Background()
+
+	e := entities.Event{
+		ID:            uuid.Must(uuid.NewV7()),
+		AggregateType: entities.EventAggregateAccount,
+		AggregateID:   uuid.Must(uuid.NewV4()),
+		RoutingKey:    "ecorp.accountCreation",
+		Payload:       []byte(`{}`),
+		CreatedAt:     time.Now(),
+	}
+	require.NoError(t, r.AppendEvent(ctx, e))
+
+	// execute
+	_, err := db.GetTxOrPool(ctx).Exec(ctx, "update events set routing_key = 'other' where id = $1", e.ID)
+
+	// assert
+	assert.ErrorContains(t, err, "append-only")
+}
+
+func TestEventRepo_ListAccountEvents_CommitOrder(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewRepository(NewDB(t))
+	ctx := context.Background()
+
+	accountID := uuid.Must(uuid.NewV7())
+	transferEvent := func() entities.Event {
+		return entities.Event{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   uuid.Must(uuid.NewV7()),
+			RoutingKey:    "ecorp.transferCreation",
+			Payload:       []byte(fmt.Sprintf(`{"account_origin_id": %q, "account_destination_id": %q}`, accountID, uuid.Must(uuid.NewV7()))),
+			CreatedAt:     time.Now(),
+		}
+	}
+	first, second := transferEvent(), transferEvent()
+
+	txCtx, err := r.BeginTX(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.AppendEvent(txCtx, first))
+
+	// execute: the second event is appended while the first isn't committed
+	appended := make(chan error, 1)
+	go func() { appended <- r.AppendEvent(ctx, second) }()
+
+	// assert: it waits for the first to be committed, so the stream never skips it
+	select {
+	case err := <-appended:
+		t.Fatalf("appended before the commit of the first event: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	got, err := r.ListAccountEvents(ctx, accountID, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	require.NoError(t, r.CommitTX(txCtx))
+	require.NoError(t, <-appended)
+
+	got, err = r.ListAccountEvents(ctx, accountID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, []uuid.UUID{first.ID, second.ID}, []uuid.UUID{got[0].Event.ID, got[1].Event.ID})
+	assert.Equal(t, []int64{1, 2}, []int64{got[0].Sequence, got[1].Sequence})
+}
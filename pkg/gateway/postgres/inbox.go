@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/sqlc"
+)
+
+// MarkMessageProcessed records that the message was processed by the consumer.
+// It returns false if the message had already been recorded, meaning it is a duplicate delivery.
+func (r Repository) MarkMessageProcessed(ctx context.Context, consumer, messageID string) (bool, error) {
+	n, err := sqlc.New(r.conn.GetTxOrPool(ctx)).InsertProcessedMessage(ctx, sqlc.InsertProcessedMessageParams{
+		Consumer:  consumer,
+		MessageID: messageID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("inserting processed message %s: %w", messageID, err)
+	}
+
+	return n > 0, nil
+}
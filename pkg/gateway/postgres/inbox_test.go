@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInboxRepo_MarkMessageProcessed(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewRepository(NewDB(t))
+	ctx := context.Background()
+
+	// execute: first delivery
+	first, err := r.MarkMessageProcessed(ctx, "ecorp.stream.accountCreation", "0192d6a2-0c3e-7a0e-9d2b-6f1f1a4b0c01")
+	require.NoError(t, err)
+	assert.True(t, first)
+
+	// execute: redelivery of the same message
+	first, err = r.MarkMessageProcessed(ctx, "ecorp.stream.accountCreation", "0192d6a2-0c3e-7a0e-9d2b-6f1f1a4b0c01")
+	require.NoError(t, err)
+	assert.False(t, first)
+
+	// execute: same message processed by another consumer
+	first, err = r.MarkMessageProcessed(ctx, "ecorp.stream.webhooks", "0192d6a2-0c3e-7a0e-9d2b-6f1f1a4b0c01")
+	require.NoError(t, err)
+	assert.True(t, first)
+}
+
+func TestInboxRepo_MarkMessageProcessed_Rollback(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewRepository(NewDB(t))
+	ctx := context.Background()
+
+	txCtx, err := r.BeginTX(ctx)
+	require.NoError(t, err)
+
+	first, err := r.MarkMessageProcessed(txCtx, "ecorp.stream.accountCreation", "0192d6a2-0c3e-7a0e-9d2b-6f1f1a4b0c02")
+	require.NoError(t, err)
+	assert.True(t, first)
+
+	// execute: the handler failed, so the transaction is rolled back
+	require.NoError(t, r.RollbackTX(txCtx))
+
+	// assert: the message can be processed again
+	first, err = r.MarkMessageProcessed(ctx, "ecorp.stream.accountCreation", "0192d6a2-0c3e-7a0e-9d2b-6f1f1a4b0c02")
+	require.NoError(t, err)
+	assert.True(t, first)
+}
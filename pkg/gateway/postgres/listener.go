@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// AccountEventsChannel is the notification channel of the events appended to the streams of the accounts.
+const AccountEventsChannel = "account_events"
+
+// listenRetryDelay is the delay before listening again after the connection fails.
+const listenRetryDelay = time.Second
+
+// EventListener receives the events appended to the streams of the accounts through the notifications
+// sent by the events table trigger, on a connection dedicated to it.
+type EventListener struct {
+	pool *pgxpool.Pool
+}
+
+func NewEventListener(pool *pgxpool.Pool) EventListener {
+	return EventListener{pool: pool}
+}
+
+// Listen calls handle with every event committed to the account streams, in the order of the streams,
+// until the context is done.
+// The notifications sent while the connection is down are lost, so the connection is reestablished
+// and reconnected is called, letting the receivers resynchronize from the event store.
+func (l EventListener) Listen(ctx context.Context, handle func(entities.AccountEvent), reconnected func()) {
+	for ctx.Err() == nil {
+		err := l.listen(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Error(ctx, "listening events", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+			reconnected()
+		}
+	}
+}
+
+func (l EventListener) listen(ctx context.Context, handle func(entities.AccountEvent)) error {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection: %w", err)
+	}
+	// the connection stays in the listening state, so it isn't returned to the pool.
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.Background())
+
+	if _, err := pgConn.Exec(ctx, "listen "+AccountEventsChannel); err != nil {
+		return fmt.Errorf("listening channel: %w", err)
+	}
+
+	for {
+		n, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("waiting notification: %w", err)
+		}
+
+		e, err := decodeEventNotification(n.Payload)
+		if err != nil {
+			logger.Error(ctx, "decoding event notification", zap.Error(err))
+			continue
+		}
+
+		handle(e)
+	}
+}
+
+// accountEventNotification is the account event serialized by the trigger, with the events row serialized
+// with row_to_json.
+type accountEventNotification struct {
+	AccountID uuid.UUID `json:"account_id"`
+	Sequence  int64     `json:"sequence"`
+	Balance   int       `json:"balance"`
+	Event     struct {
+		ID            uuid.UUID       `json:"id"`
+		Sequence      int64           `json:"sequence"`
+		AggregateType string          `json:"aggregate_type"`
+		AggregateID   uuid.UUID       `json:"aggregate_id"`
+		RoutingKey    string          `json:"routing_key"`
+		Payload       json.RawMessage `json:"payload"`
+		CreatedAt     time.Time       `json:"created_at"`
+	} `json:"event"`
+}
+
+func decodeEventNotification(payload string) (entities.AccountEvent, error) {
+	var n accountEventNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return entities.AccountEvent{}, fmt.Errorf("unmarshalling notification: %w", err)
+	}
+
+	if n.Event.ID.IsNil() {
+		return entities.AccountEvent{}, errors.New("notification without event id")
+	}
+
+	return entities.AccountEvent{
+		AccountID: n.AccountID,
+		Sequence:  n.Sequence,
+		Balance:   n.Balance,
+		Event: entities.Event{
+			ID:            n.Event.ID,
+			Sequence:      n.Event.Sequence,
+			AggregateType: entities.EventAggregateType(n.Event.AggregateType),
+			AggregateID:   n.Event.AggregateID,
+			RoutingKey:    n.Event.RoutingKey,
+			Payload:       n.Event.Payload,
+			CreatedAt:     n.Event.CreatedAt,
+		},
+	}, nil
+}
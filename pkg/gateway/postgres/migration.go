@@ -2,12 +2,15 @@ package postgres
 
 import (
 	"context"
+	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
+	"strings"
 
-	"github.com/golang-migrate/migrate"
-	"github.com/golang-migrate/migrate/database/postgres"
-	_ "github.com/golang-migrate/migrate/source/file"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"go.uber.org/zap"
@@ -15,27 +18,169 @@ import (
 	"github.com/higordasneves/e-corp/utils/logger"
 )
 
-func Migration(ctx context.Context, migrationPath string, dbPool *pgxpool.Pool) error {
-	cfg := dbPool.Config().ConnConfig
+//go:embed migrations/*.sql
+var migrations embed.FS
 
-	db := stdlib.OpenDB(*cfg)
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://"+migrationPath,
-		"postgres", driver)
+// migrationLockID identifies the advisory lock held while migrating, so replicas that start together
+// apply the migrations one at a time. The lock of golang-migrate gives up after a timeout, this one waits.
+const migrationLockID int64 = 4_617_020_331_295_211
+
+// Migrator applies the migrations embedded in the binary.
+type Migrator struct {
+	pool *pgxpool.Pool
+}
+
+func NewMigrator(pool *pgxpool.Pool) Migrator {
+	return Migrator{pool: pool}
+}
+
+// MigrationStatus describes the version of the database schema.
+type MigrationStatus struct {
+	// Version is the last applied migration, zero if none was applied.
+	Version uint
+	// Dirty reports that the last migration failed. The schema must be fixed and the version forced.
+	Dirty bool
+	// Latest is the version of the last embedded migration.
+	Latest uint
+	// Pending lists the embedded migrations after the current version.
+	Pending []uint
+}
+
+// Up applies all the pending migrations.
+func (m Migrator) Up(ctx context.Context) error {
+	return m.run(ctx, func(mg *migrate.Migrate) error {
+		return mg.Up()
+	})
+}
+
+// Down reverts the last n applied migrations.
+func (m Migrator) Down(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("invalid number of migrations to revert: %d", n)
+	}
+
+	return m.run(ctx, func(mg *migrate.Migrate) error {
+		return mg.Steps(-n)
+	})
+}
+
+// To applies or reverts the migrations up to the version.
+func (m Migrator) To(ctx context.Context, version uint) error {
+	return m.run(ctx, func(mg *migrate.Migrate) error {
+		return mg.Migrate(version)
+	})
+}
+
+// Force sets the version without running the migrations and clears the dirty flag.
+// It's used after fixing the schema manually when a migration fails. A version of -1 means no migration.
+func (m Migrator) Force(ctx context.Context, version int) error {
+	return m.run(ctx, func(mg *migrate.Migrate) error {
+		return mg.Force(version)
+	})
+}
+
+// Status returns the current version of the schema and the pending migrations.
+func (m Migrator) Status(ctx context.Context) (MigrationStatus, error) {
+	var status MigrationStatus
+	err := m.run(ctx, func(mg *migrate.Migrate) error {
+		var err error
+		status.Version, status.Dirty, err = mg.Version()
+		if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+			return err
+		}
+
+		src, err := iofs.New(migrations, "migrations")
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		for v, err := src.First(); ; v, err = src.Next(v) {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			status.Latest = v
+			if v > status.Version {
+				status.Pending = append(status.Pending, v)
+			}
+		}
+	})
 	if err != nil {
-		return fmt.Errorf("creating migration: %w", err)
+		return MigrationStatus{}, err
 	}
 
-	err = m.Up()
+	return status, nil
+}
+
+// run executes fn holding the migration lock.
+// The operations that find nothing to migrate succeed.
+func (m Migrator) run(ctx context.Context, fn func(mg *migrate.Migrate) error) error {
+	conn, err := m.pool.Acquire(ctx)
 	if err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			logger.Error(ctx, "migration error no change", zap.Error(err))
-			return nil
+		return fmt.Errorf("acquiring connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "select pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.WithoutCancel(ctx), "select pg_advisory_unlock($1)", migrationLockID); err != nil {
+			// the session lock is released with the connection.
+			logger.Error(ctx, "releasing migration lock", zap.Error(err))
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
 		}
+	}()
+
+	src, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		return fmt.Errorf("reading migrations: %w", err)
+	}
 
-		return fmt.Errorf("executin migration: %w", err)
+	driver, err := pgx.WithInstance(stdlib.OpenDB(*m.pool.Config().ConnConfig), &pgx.Config{})
+	if err != nil {
+		return fmt.Errorf("creating migration driver: %w", err)
+	}
+
+	mg, err := migrate.NewWithInstance("iofs", src, "pgx5", driver)
+	if err != nil {
+		_ = driver.Close()
+		return fmt.Errorf("creating migration: %w", err)
+	}
+	defer mg.Close()
+	mg.Log = migrationLogger{ctx: ctx}
+
+	// stops after the running migration when the context is canceled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			mg.GracefulStop <- true
+		case <-done:
+		}
+	}()
+
+	if err = fn(mg); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("executing migration: %w", err)
 	}
 
 	return nil
 }
+
+// migrationLogger logs the migrations applied by golang-migrate.
+type migrationLogger struct {
+	ctx context.Context
+}
+
+func (l migrationLogger) Printf(format string, v ...any) {
+	logger.Info(l.ctx, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrationLogger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sync"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// embeddedVersions lists the versions of the embedded migrations, so the tests don't change with each new migration.
+func embeddedVersions(t *testing.T) []uint {
+	t.Helper()
+
+	src, err := iofs.New(migrations, "migrations")
+	require.NoError(t, err)
+	defer src.Close()
+
+	var versions []uint
+	for v, err := src.First(); !errors.Is(err, fs.ErrNotExist); v, err = src.Next(v) {
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+	require.GreaterOrEqual(t, len(versions), 3)
+
+	return versions
+}
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	m := NewMigrator(newPool(t))
+	ctx := context.Background()
+	versions := embeddedVersions(t)
+	n := len(versions)
+	latest := versions[n-1]
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationStatus{Latest: latest, Pending: versions}, status)
+
+	// execute: replicas starting together
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = m.Up(ctx)
+		}()
+	}
+	wg.Wait()
+
+	// assert
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationStatus{Version: latest, Latest: latest}, status)
+
+	// execute: revert the last migrations
+	require.NoError(t, m.Down(ctx, 2))
+
+	// assert
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationStatus{Version: versions[n-3], Latest: latest, Pending: versions[n-2:]}, status)
+
+	// execute: migrate to a version
+	require.NoError(t, m.To(ctx, versions[n-2]))
+
+	// assert
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationStatus{Version: versions[n-2], Latest: latest, Pending: versions[n-1:]}, status)
+
+	// execute: force the version without running the migrations
+	require.NoError(t, m.Force(ctx, int(latest)))
+
+	// assert
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, MigrationStatus{Version: latest, Latest: latest}, status)
+}
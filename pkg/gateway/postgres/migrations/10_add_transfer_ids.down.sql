@@ -0,0 +1,8 @@
+begin;
+
+    drop trigger if exists tg_transfers_id on transfers;
+    drop function if exists fn_trigger_transfer_id();
+    drop table if exists transfer_ids;
+    alter table transfers drop constraint if exists transfers_legacy_created_at_check;
+
+commit;
@@ -0,0 +1,46 @@
+-- the transfers are partitioned by the migrations 10 to 14, so the transfers are scanned before their table is
+-- locked: this one adds the constraints checked by the scans and runs them while the transfers are still written,
+-- the next ones create the indexes of the partitioned table concurrently, and the last one partitions the table
+-- relying on the constraints and the indexes instead of scanning it.
+begin;
+
+    -- the primary key of the partitioned table includes the creation time, so the uniqueness of the ids is
+    -- enforced by this table instead. The ids of the archived partitions are kept, so they can't be reused.
+    create table transfer_ids
+    (
+        id uuid primary key
+    );
+
+    create or replace function fn_trigger_transfer_id()
+        returns trigger
+        language plpgsql
+    as
+    $$
+    begin
+        insert into transfer_ids (id) values (new.id);
+        return new;
+    end;
+    $$;
+
+    create or replace trigger tg_transfers_id
+        before insert
+        on transfers
+        for each row
+    execute procedure fn_trigger_transfer_id();
+
+    -- the existing table holds the transfers created until the end of the current month. The check matches
+    -- its partition bound, so attaching it doesn't scan it. A transfer created after the end of the month is
+    -- rejected until the migration completes, so it must not run across the end of a month.
+    do
+    $$
+    begin
+        execute format('alter table transfers add constraint transfers_legacy_created_at_check check (created_at < %L) not valid',
+                       (date_trunc('month', now() at time zone 'utc') + interval '1 month') at time zone 'utc');
+    end
+    $$;
+
+commit;
+
+-- validating the check and copying the ids don't block the writes on the transfers.
+alter table transfers validate constraint transfers_legacy_created_at_check;
+insert into transfer_ids (id) select id from transfers on conflict do nothing;
@@ -0,0 +1 @@
+drop index concurrently if exists transfers_legacy_id_created_at_idx;
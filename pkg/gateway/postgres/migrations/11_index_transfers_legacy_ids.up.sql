@@ -0,0 +1,4 @@
+-- the unique index of the primary key of the partitioned transfers, adopted by the primary key of the legacy
+-- partition. It's built concurrently, which can't run in a transaction, so it's the only statement of the migration.
+-- A failed build leaves an invalid index, which must be dropped before running the migration again.
+create unique index concurrently if not exists transfers_legacy_id_created_at_idx on transfers (id, created_at);
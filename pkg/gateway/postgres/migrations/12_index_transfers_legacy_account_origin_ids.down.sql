@@ -0,0 +1 @@
+drop index concurrently if exists transfers_legacy_account_origin_id_id_idx;
@@ -0,0 +1,4 @@
+-- the index of the partitioned transfers by destination account, adopted by the legacy partition when it's attached.
+-- It's built concurrently, which can't run in a transaction, so it's the only statement of the migration.
+-- A failed build leaves an invalid index, which must be dropped before running the migration again.
+create index concurrently if not exists transfers_legacy_account_destination_id_id_idx on transfers (account_destination_id, id);
@@ -0,0 +1,44 @@
+begin;
+
+    -- copies the transfers of all the attached partitions back to an unpartitioned table.
+    -- The detached partitions were archived and are not restored.
+    alter table transfers rename to transfers_partitioned;
+    alter table transfers_partitioned rename constraint transfers_pkey to transfers_partitioned_pkey;
+
+    create table transfers
+    (
+        id                     uuid        primary key,
+        account_origin_id      uuid        not null references accounts (id),
+        account_destination_id uuid        not null references accounts (id),
+        amount                 bigint      not null,
+        created_at             timestamptz not null,
+        updated_at             timestamptz not null default now()
+    );
+
+    insert into transfers select * from transfers_partitioned;
+
+    drop table transfers_partitioned;
+    drop function fn_create_transfers_partition(timestamptz);
+
+    create index on transfers (id);
+    create index on transfers (account_origin_id);
+    create index on transfers (account_destination_id);
+
+    -- the indexes of the previous migrations, which revert them.
+    create unique index transfers_legacy_id_created_at_idx on transfers (id, created_at);
+    create index transfers_legacy_account_origin_id_id_idx on transfers (account_origin_id, id);
+    create index transfers_legacy_account_destination_id_id_idx on transfers (account_destination_id, id);
+
+    create or replace trigger tg_transfers_updated_at
+        before update
+        on transfers
+        for each row
+    execute procedure fn_trigger_updated_at();
+
+    create or replace trigger tg_transfers_id
+        before insert
+        on transfers
+        for each row
+    execute procedure fn_trigger_transfer_id();
+
+commit;
@@ -0,0 +1,109 @@
+-- the check of the creation time was validated and the indexes of the legacy partition were created by the
+-- previous migrations, so the partitioning only changes the catalog.
+begin;
+
+    -- the transfers are partitioned by month of creation. The current table becomes the partition of the
+    -- transfers created until the end of the current month, so its rows are not copied. Its triggers are replaced
+    -- by the ones of the partitioned table, and its indexes by the ones created by the previous migrations, which
+    -- match the indexes of the partitioned table: they are adopted when it's attached instead of being built.
+    -- The primary key adopts its unique index, which doesn't scan the table as its columns are not null.
+    alter table transfers rename to transfers_legacy;
+    alter table transfers_legacy drop constraint transfers_pkey;
+    alter table transfers_legacy add constraint transfers_legacy_pkey primary key using index transfers_legacy_id_created_at_idx;
+    drop index transfers_id_idx;
+    drop index transfers_account_origin_id_idx;
+    drop index transfers_account_destination_id_idx;
+    drop trigger tg_transfers_updated_at on transfers_legacy;
+    drop trigger tg_transfers_id on transfers_legacy;
+
+    -- the primary key of a partitioned table must include the partition key, the ids are unique by transfer_ids.
+    -- The indexes are created before the partitions, so they are not built under the lock of the table.
+    create table transfers
+    (
+        id                     uuid        not null,
+        account_origin_id      uuid        not null references accounts (id),
+        account_destination_id uuid        not null references accounts (id),
+        amount                 bigint      not null,
+        created_at             timestamptz not null,
+        updated_at             timestamptz not null default now(),
+        primary key (id, created_at)
+    ) partition by range (created_at);
+
+    create index on transfers (account_origin_id, id);
+    create index on transfers (account_destination_id, id);
+
+    create or replace trigger tg_transfers_updated_at
+        before update
+        on transfers
+        for each row
+    execute procedure fn_trigger_updated_at();
+
+    create or replace trigger tg_transfers_id
+        before insert
+        on transfers
+        for each row
+    execute procedure fn_trigger_transfer_id();
+
+    -- holds the transfers of the months without a partition, so they are never rejected.
+    -- They are moved to their partition when it's created.
+    create table transfers_default partition of transfers default;
+
+    -- creates the partition of the month of the given time, in UTC, and returns its name.
+    -- Returns null if the partition already exists or the month is held by the legacy partition.
+    -- The partition is created apart and then attached, which doesn't block the writes on the transfers like
+    -- creating it as a partition does. The default partition is locked while its transfers of the month are
+    -- moved, so a transfer of the month inserted meanwhile doesn't make the attach fail.
+    create or replace function fn_create_transfers_partition(month_of timestamptz)
+        returns text
+        language plpgsql
+    as
+    $$
+    declare
+        month_start    timestamp   := date_trunc('month', month_of at time zone 'utc');
+        from_ts        timestamptz := month_start at time zone 'utc';
+        to_ts          timestamptz := (month_start + interval '1 month') at time zone 'utc';
+        partition_name text        := 'transfers_' || to_char(month_start, 'YYYY_MM');
+    begin
+        -- serializes the maintenance jobs of the instances.
+        perform pg_advisory_xact_lock(hashtext('fn_create_transfers_partition'));
+
+        if to_regclass(partition_name) is not null then
+            return null;
+        end if;
+
+        begin
+            lock table transfers_default in exclusive mode;
+
+            execute format('create table %I (like transfers including defaults)', partition_name);
+            execute format('insert into %I select * from transfers_default where created_at >= %L and created_at < %L',
+                           partition_name, from_ts, to_ts);
+            execute format('delete from transfers_default where created_at >= %L and created_at < %L', from_ts, to_ts);
+            execute format('alter table transfers attach partition %I for values from (%L) to (%L)',
+                           partition_name, from_ts, to_ts);
+        exception
+            -- the partition would overlap the legacy partition, the changes of the block are rolled back.
+            when invalid_object_definition then
+                return null;
+        end;
+
+        return partition_name;
+    end;
+    $$;
+
+    do
+    $$
+    declare
+        this_month timestamp := date_trunc('month', now() at time zone 'utc');
+    begin
+        execute format('alter table transfers attach partition transfers_legacy for values from (minvalue) to (%L)',
+                       (this_month + interval '1 month') at time zone 'utc');
+        alter table transfers_legacy drop constraint transfers_legacy_created_at_check;
+
+        for i in 1..3
+            loop
+                perform fn_create_transfers_partition((this_month + make_interval(months => i)) at time zone 'utc');
+            end loop;
+    end
+    $$;
+
+commit;
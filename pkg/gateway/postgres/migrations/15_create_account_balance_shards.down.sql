@@ -0,0 +1,12 @@
+begin;
+
+    -- moves the balance of the shards back to the accounts.
+    update accounts a
+    set balance = a.balance + s.balance
+    from (select account_id, sum(balance) as balance from account_balance_shards group by account_id) s
+    where a.id = s.account_id;
+
+    drop table if exists account_balance_shards;
+    alter table accounts drop column if exists balance_shards;
+
+commit;
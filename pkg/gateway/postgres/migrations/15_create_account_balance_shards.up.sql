@@ -0,0 +1,16 @@
+begin;
+
+    -- the balance of a hot account is split in shards, so concurrent credits update different rows.
+    -- The balance of the account is the one of its row plus the ones of its shards. The number of shards is
+    -- kept in the account, so a credit picks its shard without reading the shards the other credits update.
+    alter table accounts add column if not exists balance_shards int not null default 0 check (balance_shards >= 0);
+
+    create table if not exists account_balance_shards
+    (
+        account_id uuid   not null references accounts (id),
+        shard      int    not null,
+        balance    bigint not null default 0 check (balance >= 0),
+        primary key (account_id, shard)
+    );
+
+commit;
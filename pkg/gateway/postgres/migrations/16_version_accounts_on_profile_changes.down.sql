@@ -0,0 +1,11 @@
+begin;
+
+    drop trigger if exists tg_accounts_version on accounts;
+
+    create trigger tg_accounts_version
+    before update
+    on accounts
+    for each row
+    execute procedure fn_trigger_increment_version();
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+    -- the version of the account is incremented only when its profile changes. The balance changes with
+    -- every transfer, which must not conflict with the updates of the profile conditioned on the version.
+    drop trigger if exists tg_accounts_version on accounts;
+
+    create trigger tg_accounts_version
+    before update
+    on accounts
+    for each row
+    when (old.name is distinct from new.name)
+    execute procedure fn_trigger_increment_version();
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+    drop index if exists events_account_origin_id_idx;
+    drop index if exists events_account_destination_id_idx;
+    alter table events drop column if exists sequence;
+    create index if not exists events_account_origin_id_idx on events ((payload ->> 'account_origin_id'), id) where aggregate_type = 'transfer';
+    create index if not exists events_account_destination_id_idx on events ((payload ->> 'account_destination_id'), id) where aggregate_type = 'transfer';
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+    -- the events are listed and resumed in the order they are appended, which the ids don't follow:
+    -- they are generated by the publishers before the events reach the event store.
+    -- the existing events are numbered in the order of the table, the order they were appended in.
+    alter table events add column if not exists sequence bigserial not null;
+    create unique index if not exists events_sequence_key on events (sequence);
+
+    drop index if exists events_account_origin_id_idx;
+    drop index if exists events_account_destination_id_idx;
+    create index if not exists events_account_origin_id_idx on events ((payload ->> 'account_origin_id'), sequence) where aggregate_type = 'transfer';
+    create index if not exists events_account_destination_id_idx on events ((payload ->> 'account_destination_id'), sequence) where aggregate_type = 'transfer';
+
+commit;
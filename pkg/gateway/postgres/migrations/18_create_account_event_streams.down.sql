@@ -0,0 +1,28 @@
+begin;
+
+    drop trigger if exists tg_events_account_events on events;
+    drop function if exists fn_append_account_events;
+    drop table if exists account_events;
+    drop table if exists account_event_sequences;
+
+    create or replace function fn_notify_event()
+        returns trigger
+        language plpgsql
+    as
+    $$
+    begin
+        perform pg_notify('events', row_to_json(new)::text);
+        return null;
+    end;
+    $$;
+
+    create or replace trigger tg_events_notify
+        after insert
+        on events
+        for each row
+    execute procedure fn_notify_event();
+
+    create index if not exists events_account_origin_id_idx on events ((payload ->> 'account_origin_id'), sequence) where aggregate_type = 'transfer';
+    create index if not exists events_account_destination_id_idx on events ((payload ->> 'account_destination_id'), sequence) where aggregate_type = 'transfer';
+
+commit;
@@ -0,0 +1,106 @@
+begin;
+
+    -- the account event streams are resumed from a sequence of each account, taken by locking the row of the
+    -- account in account_event_sequences until the commit: the events of an account are numbered in the order
+    -- they are committed. The sequence of the events is taken when they are inserted, so a stream resuming from it
+    -- skips the events committed after a later one.
+    create table if not exists account_event_sequences
+    (
+        account_id uuid primary key,
+        sequence   bigint not null
+    );
+
+    -- balance is the balance of the account when the event was appended, streamed with the event.
+    create table if not exists account_events
+    (
+        account_id uuid   not null,
+        sequence   bigint not null,
+        event_id   uuid   not null references events (id),
+        balance    bigint not null,
+        primary key (account_id, sequence)
+    );
+
+    -- appends the transfer events to the streams of their accounts and notifies the listeners of the
+    -- account_events channel on commit, in the order of the streams.
+    -- the whole event is sent, so it must stay under the 8000 bytes limit of the notification payload.
+    create or replace function fn_append_account_events()
+        returns trigger
+        language plpgsql
+    as
+    $$
+    declare
+        stream_account_id uuid;
+        stream_sequence   bigint;
+        stream_balance    bigint;
+    begin
+        for stream_account_id in
+            select distinct account_id::uuid
+            from unnest(array [new.payload ->> 'account_origin_id', new.payload ->> 'account_destination_id']) account_id
+            where account_id is not null
+        loop
+            insert into account_event_sequences as s (account_id, sequence)
+            values (stream_account_id, 1)
+            on conflict (account_id) do update set sequence = s.sequence + 1
+            returning s.sequence into stream_sequence;
+
+            select a.balance + (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)
+            into stream_balance
+            from accounts a
+            where a.id = stream_account_id;
+
+            insert into account_events (account_id, sequence, event_id, balance)
+            values (stream_account_id, stream_sequence, new.id, coalesce(stream_balance, 0));
+
+            perform pg_notify('account_events', json_build_object(
+                'account_id', stream_account_id,
+                'sequence', stream_sequence,
+                'balance', coalesce(stream_balance, 0),
+                'event', row_to_json(new)
+            )::text);
+        end loop;
+
+        return null;
+    end;
+    $$;
+
+    -- the events appended meanwhile would be missing from the streams.
+    lock table events in share row exclusive mode;
+
+    create or replace trigger tg_events_account_events
+        after insert
+        on events
+        for each row
+        when (new.aggregate_type = 'transfer')
+    execute procedure fn_append_account_events();
+
+    -- the streams replace the notifications of the events and the indexes of their accounts.
+    drop trigger if exists tg_events_notify on events;
+    drop function if exists fn_notify_event;
+    drop index if exists events_account_origin_id_idx;
+    drop index if exists events_account_destination_id_idx;
+
+    -- the existing events are numbered in the order they were appended, with the current balance of the accounts.
+    insert into account_events (account_id, sequence, event_id, balance)
+    select e.account_id,
+           row_number() over (partition by e.account_id order by e.sequence),
+           e.id,
+           coalesce((select a.balance + (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)
+                     from accounts a
+                     where a.id = e.account_id), 0)
+    from (select (payload ->> 'account_origin_id')::uuid as account_id, sequence, id
+          from events
+          where aggregate_type = 'transfer'
+          union
+          select (payload ->> 'account_destination_id')::uuid, sequence, id
+          from events
+          where aggregate_type = 'transfer') e
+    where e.account_id is not null
+    on conflict do nothing;
+
+    insert into account_event_sequences (account_id, sequence)
+    select account_id, max(sequence)
+    from account_events
+    group by account_id
+    on conflict do nothing;
+
+commit;
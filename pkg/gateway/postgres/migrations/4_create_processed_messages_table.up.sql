@@ -0,0 +1,11 @@
+begin;
+
+    create table if not exists processed_messages
+    (
+        consumer     text        not null,
+        message_id   text        not null,
+        processed_at timestamptz not null default now(),
+        primary key (consumer, message_id)
+    );
+
+commit;
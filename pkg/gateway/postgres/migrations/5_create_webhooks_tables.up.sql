@@ -0,0 +1,48 @@
+begin;
+
+    create table if not exists webhook_subscriptions
+    (
+        id          uuid        primary key,
+        account_id  uuid        not null references accounts (id),
+        url         text        not null,
+        event_types text[]      not null,
+        secret      text        not null,
+        created_at  timestamptz not null,
+        updated_at  timestamptz not null default now()
+    );
+
+    create index on webhook_subscriptions (account_id);
+
+    create or replace trigger tg_webhook_subscriptions_updated_at
+        before update
+        on webhook_subscriptions
+        for each row
+    execute procedure fn_trigger_updated_at();
+
+    create table if not exists webhook_deliveries
+    (
+        id              uuid        primary key,
+        subscription_id uuid        not null references webhook_subscriptions (id) on delete cascade,
+        event_id        text        not null,
+        event_type      text        not null,
+        payload         jsonb       not null,
+        status          text        not null,
+        attempts        int         not null default 0,
+        response_status int         not null default 0,
+        last_error      text        not null default '',
+        next_attempt_at timestamptz not null,
+        delivered_at    timestamptz,
+        created_at      timestamptz not null,
+        updated_at      timestamptz not null default now()
+    );
+
+    create unique index on webhook_deliveries (subscription_id, event_id, event_type);
+    create index on webhook_deliveries (next_attempt_at) where status = 'pending';
+
+    create or replace trigger tg_webhook_deliveries_updated_at
+        before update
+        on webhook_deliveries
+        for each row
+    execute procedure fn_trigger_updated_at();
+
+commit;
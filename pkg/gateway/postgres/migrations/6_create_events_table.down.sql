@@ -0,0 +1,6 @@
+begin;
+
+    drop table if exists events;
+    drop function if exists fn_trigger_append_only;
+
+commit;
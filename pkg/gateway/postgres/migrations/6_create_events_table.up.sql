@@ -0,0 +1,32 @@
+begin;
+
+    create table if not exists events
+    (
+        id             uuid        primary key,
+        aggregate_type text        not null,
+        aggregate_id   uuid        not null,
+        routing_key    text        not null,
+        payload        jsonb       not null,
+        created_at     timestamptz not null
+    );
+
+    create index on events (created_at);
+    create index on events (aggregate_id);
+
+    create or replace function fn_trigger_append_only()
+        returns trigger
+        language plpgsql
+    as
+    $$
+    begin
+        raise exception 'table % is append-only', tg_table_name;
+    end;
+    $$;
+
+    create or replace trigger tg_events_append_only
+        before update or delete
+        on events
+        for each row
+    execute procedure fn_trigger_append_only();
+
+commit;
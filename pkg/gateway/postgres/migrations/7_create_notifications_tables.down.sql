@@ -0,0 +1,6 @@
+begin;
+
+    drop table if exists notifications;
+    drop table if exists notification_preferences;
+
+commit;
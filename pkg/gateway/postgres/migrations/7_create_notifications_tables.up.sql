@@ -0,0 +1,47 @@
+begin;
+
+    create table if not exists notification_preferences
+    (
+        account_id            uuid        primary key references accounts (id),
+        locale                text        not null,
+        channels              text[]      not null,
+        events                text[]      not null,
+        email                 text        not null default '',
+        phone                 text        not null default '',
+        large_transfer_amount bigint      not null,
+        created_at            timestamptz not null default now(),
+        updated_at            timestamptz not null default now()
+    );
+
+    create or replace trigger tg_notification_preferences_updated_at
+        before update
+        on notification_preferences
+        for each row
+    execute procedure fn_trigger_updated_at();
+
+    create table if not exists notifications
+    (
+        id         uuid        primary key,
+        account_id uuid        not null references accounts (id),
+        event_id   text        not null,
+        event      text        not null,
+        channel    text        not null,
+        recipient  text        not null,
+        subject    text        not null,
+        body       text        not null,
+        status     text        not null,
+        attempts   int         not null default 1,
+        sent_at    timestamptz,
+        created_at timestamptz not null,
+        updated_at timestamptz not null default now()
+    );
+
+    create unique index on notifications (account_id, event, event_id, channel);
+
+    create or replace trigger tg_notifications_updated_at
+        before update
+        on notifications
+        for each row
+    execute procedure fn_trigger_updated_at();
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+    drop trigger if exists tg_events_notify on events;
+    drop function if exists fn_notify_event;
+    drop index if exists events_account_origin_id_idx;
+    drop index if exists events_account_destination_id_idx;
+
+commit;
@@ -0,0 +1,26 @@
+begin;
+
+    -- notifies the listeners of the events channel on commit.
+    -- the whole event is sent, so it must stay under the 8000 bytes limit of the notification payload.
+    create or replace function fn_notify_event()
+        returns trigger
+        language plpgsql
+    as
+    $$
+    begin
+        perform pg_notify('events', row_to_json(new)::text);
+        return null;
+    end;
+    $$;
+
+    create or replace trigger tg_events_notify
+        after insert
+        on events
+        for each row
+    execute procedure fn_notify_event();
+
+    -- the account event streams resume from the events of the account transfers.
+    create index if not exists events_account_origin_id_idx on events ((payload ->> 'account_origin_id'), id) where aggregate_type = 'transfer';
+    create index if not exists events_account_destination_id_idx on events ((payload ->> 'account_destination_id'), id) where aggregate_type = 'transfer';
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+    drop trigger if exists tg_accounts_version on accounts;
+    drop function if exists fn_trigger_increment_version;
+    alter table accounts drop column if exists version;
+
+commit;
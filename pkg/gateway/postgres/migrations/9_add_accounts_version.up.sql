@@ -0,0 +1,24 @@
+begin;
+
+    -- the version of the account is incremented on every update. The updates that must not overwrite a
+    -- concurrent one are conditioned on the version read before them.
+    alter table accounts add column if not exists version bigint not null default 1;
+
+    create or replace function fn_trigger_increment_version()
+        returns trigger
+        language plpgsql
+    as
+    $$
+    begin
+        new.version = old.version + 1;
+    return new;
+    end;
+    $$;
+
+    create or replace trigger tg_accounts_version
+    before update
+    on accounts
+    for each row
+    execute procedure fn_trigger_increment_version();
+
+commit;
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/sqlc"
+)
+
+// SaveNotificationPreferences creates or replaces the notification preferences of an account.
+// It returns the preferences with the update time set.
+func (r Repository) SaveNotificationPreferences(ctx context.Context, p entities.NotificationPreferences) (entities.NotificationPreferences, error) {
+	channels := make([]string, 0, len(p.Channels))
+	for _, c := range p.Channels {
+		channels = append(channels, string(c))
+	}
+
+	events := make([]string, 0, len(p.Events))
+	for _, e := range p.Events {
+		events = append(events, string(e))
+	}
+
+	updatedAt, err := sqlc.New(r.conn.GetTxOrPool(ctx)).UpsertNotificationPreferences(ctx, sqlc.UpsertNotificationPreferencesParams{
+		AccountID:           p.AccountID,
+		Locale:              string(p.Locale),
+		Channels:            channels,
+		Events:              events,
+		Email:               p.Email,
+		Phone:               p.Phone,
+		LargeTransferAmount: int64(p.LargeTransferAmount),
+	})
+	if err != nil {
+		return entities.NotificationPreferences{}, fmt.Errorf("saving notification preferences: %w", err)
+	}
+
+	p.UpdatedAt = updatedAt
+
+	return p, nil
+}
+
+// GetNotificationPreferences fetches the notification preferences of an account.
+func (r Repository) GetNotificationPreferences(ctx context.Context, accountID uuid.UUID) (entities.NotificationPreferences, error) {
+	row, err := sqlc.New(r.conn.GetTxOrPool(ctx)).GetNotificationPreferences(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.NotificationPreferences{}, domain.NewError(domain.ErrNotFound, domain.CodeNotificationPreferencesNotFound, "notification preferences of account %s not exists", accountID)
+		}
+		return entities.NotificationPreferences{}, fmt.Errorf("getting notification preferences: %w", err)
+	}
+
+	channels := make([]entities.NotificationChannel, 0, len(row.Channels))
+	for _, c := range row.Channels {
+		channels = append(channels, entities.NotificationChannel(c))
+	}
+
+	events := make([]entities.NotificationEvent, 0, len(row.Events))
+	for _, e := range row.Events {
+		events = append(events, entities.NotificationEvent(e))
+	}
+
+	return entities.NotificationPreferences{
+		AccountID:           row.AccountID,
+		Locale:              entities.Locale(row.Locale),
+		Channels:            channels,
+		Events:              events,
+		Email:               row.Email,
+		Phone:               row.Phone,
+		LargeTransferAmount: int(row.LargeTransferAmount),
+		UpdatedAt:           row.UpdatedAt,
+	}, nil
+}
+
+// UpsertNotification inserts the notification, or increments the attempts of the one already
+// created for the same account, event and channel. It returns the stored notification.
+func (r Repository) UpsertNotification(ctx context.Context, n entities.Notification) (entities.Notification, error) {
+	row, err := sqlc.New(r.conn.GetTxOrPool(ctx)).UpsertNotification(ctx, sqlc.UpsertNotificationParams{
+		ID:        n.ID,
+		AccountID: n.AccountID,
+		EventID:   n.EventID,
+		Event:     string(n.Event),
+		Channel:   string(n.Channel),
+		Recipient: n.Recipient,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		Status:    string(n.Status),
+		CreatedAt: n.CreatedAt,
+	})
+	if err != nil {
+		return entities.Notification{}, fmt.Errorf("upserting notification: %w", err)
+	}
+
+	return entities.Notification{
+		ID:        row.ID,
+		AccountID: row.AccountID,
+		EventID:   row.EventID,
+		Event:     entities.NotificationEvent(row.Event),
+		Channel:   entities.NotificationChannel(row.Channel),
+		Recipient: row.Recipient,
+		Subject:   row.Subject,
+		Body:      row.Body,
+		Status:    entities.NotificationStatus(row.Status),
+		Attempts:  int(row.Attempts),
+		SentAt:    row.SentAt,
+		CreatedAt: row.CreatedAt,
+	}, nil
+}
+
+// MarkNotificationSent updates the notification status to sent.
+func (r Repository) MarkNotificationSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	err := sqlc.New(r.conn.GetTxOrPool(ctx)).UpdateNotificationSent(ctx, sqlc.UpdateNotificationSentParams{
+		SentAt: &sentAt,
+		ID:     id,
+	})
+	if err != nil {
+		return fmt.Errorf("updating notification %s: %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+func TestNotificationRepo_UpsertNotification(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewRepository(NewDB(t))
+	ctx := context.Background()
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	_, err := r.GetNotificationPreferences(ctx, account.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	n := entities.Notification{
+		ID:        uuid.Must(uuid.NewV7()),
+		AccountID: account.ID,
+		EventID:   "0192d6a2-0c3e-7a0e-9d2b-6f1f1a4b0c01",
+		Event:     entities.NotificationAccountCreated,
+		Channel:   entities.NotificationChannelPush,
+		Recipient: account.ID.String(),
+		Subject:   "Welcome to E-Corp",
+		Body:      "Hi, Elliot!",
+		Status:    entities.NotificationPending,
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+
+	// execute: first attempt
+	got, err := r.UpsertNotification(ctx, n)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Attempts)
+	assert.Equal(t, entities.NotificationPending, got.Status)
+
+	sentAt := time.Now().Truncate(time.Second)
+	require.NoError(t, r.MarkNotificationSent(ctx, got.ID, sentAt))
+
+	// execute: redelivery of the event
+	retry := n
+	retry.ID = uuid.Must(uuid.NewV7())
+	got, err = r.UpsertNotification(ctx, retry)
+	require.NoError(t, err)
+
+	// assert
+	assert.Equal(t, n.ID, got.ID)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Equal(t, entities.NotificationSent, got.Status)
+	require.NotNil(t, got.SentAt)
+	assert.True(t, sentAt.Equal(*got.SentAt))
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// PartitionModule runs the job creating the partitions of the transfers ahead of time.
+var PartitionModule = fx.Module("partitions",
+	fx.Invoke(func(ctx context.Context, lc fx.Lifecycle, cfg config.Config, pool *pgxpool.Pool) {
+		p := NewPartitioner(pool)
+		runCtx, cancel := context.WithCancel(ctx)
+
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				go p.Run(runCtx, cfg.DB.PartitionCheckInterval, cfg.DB.PartitionMonthsAhead)
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+)
+
+// Partitioner manages the monthly partitions of the transfers table.
+type Partitioner struct {
+	pool *pgxpool.Pool
+}
+
+func NewPartitioner(pool *pgxpool.Pool) Partitioner {
+	return Partitioner{pool: pool}
+}
+
+// TransferPartition is a partition of the transfers table, holding the transfers created in [From, To).
+type TransferPartition struct {
+	Name string
+	// From is zero for the legacy partition, which holds the transfers created before the partitioning.
+	From time.Time
+	To   time.Time
+}
+
+// ArchivedPartition describes a partition exported and detached by Archive.
+type ArchivedPartition struct {
+	TransferPartition
+	// Path is the gzipped CSV file the transfers were exported to.
+	Path string
+	Rows int64
+}
+
+// Run creates the partitions right away and then on every interval, until the context is canceled.
+func (p Partitioner) Run(ctx context.Context, interval time.Duration, monthsAhead int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := p.CreatePartitions(ctx, time.Now(), monthsAhead)
+		if err != nil {
+			logger.Error(ctx, "creating transfer partitions", zap.Error(err))
+		}
+
+		if len(created) > 0 {
+			logger.Info(ctx, "transfer partitions created", zap.Strings("partitions", created))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CreatePartitions creates the partitions of the month of now and of the months ahead that don't exist yet.
+// The transfers of these months held by the default partition are moved to their partition.
+// It returns the names of the partitions created.
+func (p Partitioner) CreatePartitions(ctx context.Context, now time.Time, monthsAhead int) ([]string, error) {
+	now = now.UTC()
+
+	var created []string
+	for i := range monthsAhead + 1 {
+		month := time.Date(now.Year(), now.Month()+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+
+		var name *string
+		err := p.pool.QueryRow(ctx, "select fn_create_transfers_partition($1)", month).Scan(&name)
+		if err != nil {
+			return created, fmt.Errorf("creating the partition of %s: %w", month.Format("2006-01"), err)
+		}
+
+		if name != nil {
+			created = append(created, *name)
+		}
+	}
+
+	return created, nil
+}
+
+// listPartitionsQuery lists the bounded partitions of the transfers. The bounds are parsed by postgres
+// from their definition, e.g. FOR VALUES FROM ('2024-01-01 00:00:00+00') TO ('2024-02-01 00:00:00+00'),
+// in which they are formatted with the settings of the session. The legacy partition starts from MINVALUE.
+const listPartitionsQuery = `select c.relname,
+	(regexp_match(pg_get_expr(c.relpartbound, c.oid), 'FROM \(''([^'']+)''\)'))[1]::timestamptz,
+	(regexp_match(pg_get_expr(c.relpartbound, c.oid), 'TO \(''([^'']+)''\)'))[1]::timestamptz as upper_bound
+from pg_inherits i
+join pg_class c on c.oid = i.inhrelid
+where i.inhparent = 'transfers'::regclass and pg_get_expr(c.relpartbound, c.oid) <> 'DEFAULT'
+order by upper_bound`
+
+// ListPartitions lists the partitions of the transfers in order of time, without the default partition.
+func (p Partitioner) ListPartitions(ctx context.Context) ([]TransferPartition, error) {
+	rows, err := p.pool.Query(ctx, listPartitionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("listing the transfer partitions: %w", err)
+	}
+
+	partitions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (TransferPartition, error) {
+		var (
+			part TransferPartition
+			from *time.Time
+		)
+		if err := row.Scan(&part.Name, &from, &part.To); err != nil {
+			return TransferPartition{}, err // nolint:wrapcheck
+		}
+
+		if from != nil {
+			part.From = *from
+		}
+
+		return part, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing the transfer partitions: %w", err)
+	}
+
+	return partitions, nil
+}
+
+// Archive exports the transfers of the partition to a gzipped CSV file in dir and detaches the partition.
+// The detached table is kept, so it can be checked against the file before being dropped with Drop.
+// The file is only created once fully written, a failed export leaves no file and the partition attached.
+func (p Partitioner) Archive(ctx context.Context, part TransferPartition, dir string) (ArchivedPartition, error) {
+	path := filepath.Join(dir, part.Name+".csv.gz")
+
+	rows, err := p.export(ctx, part.Name, path)
+	if err != nil {
+		return ArchivedPartition{}, fmt.Errorf("exporting partition %s: %w", part.Name, err)
+	}
+
+	_, err = p.pool.Exec(ctx, "alter table transfers detach partition "+pgx.Identifier{part.Name}.Sanitize())
+	if err != nil {
+		return ArchivedPartition{}, fmt.Errorf("detaching partition %s: %w", part.Name, err)
+	}
+
+	return ArchivedPartition{TransferPartition: part, Path: path, Rows: rows}, nil
+}
+
+// Drop drops the table of an archived partition.
+func (p Partitioner) Drop(ctx context.Context, name string) error {
+	var attached bool
+	err := p.pool.QueryRow(ctx, "select relispartition from pg_class where oid = $1::regclass", name).Scan(&attached)
+	if err != nil {
+		return fmt.Errorf("checking partition %s: %w", name, err)
+	}
+
+	if attached {
+		return fmt.Errorf("partition %s is still attached", name)
+	}
+
+	if _, err = p.pool.Exec(ctx, "drop table "+pgx.Identifier{name}.Sanitize()); err != nil {
+		return fmt.Errorf("dropping partition %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// export copies the rows of the table to a gzipped CSV file, written to a temporary file renamed when complete.
+func (p Partitioner) export(ctx context.Context, table, path string) (rows int64, err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("creating file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	conn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("acquiring connection: %w", err)
+	}
+	defer conn.Release()
+
+	gz := gzip.NewWriter(f)
+	tag, err := conn.Conn().PgConn().CopyTo(ctx, gz,
+		"copy "+pgx.Identifier{table}.Sanitize()+" to stdout with (format csv, header)")
+	if err != nil {
+		return 0, fmt.Errorf("copying rows: %w", err)
+	}
+
+	if err = errors.Join(gz.Close(), f.Sync(), f.Close()); err != nil {
+		return 0, fmt.Errorf("writing file: %w", err)
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		return 0, fmt.Errorf("renaming file: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,239 @@
+package postgres
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+)
+
+// partitionMigration is the version of the first migration partitioning the transfers.
+const partitionMigration = 10
+
+func TestPartitioner_MigratesUnpartitionedTransfers(t *testing.T) {
+	t.Parallel()
+
+	// setup: transfers made before the partitioning
+	ctx := context.Background()
+	pool := newPool(t)
+	m := NewMigrator(pool)
+	require.NoError(t, m.To(ctx, partitionMigration-1))
+
+	r := NewRepository(dbpool.NewConn(pool))
+	origin, destination := createPartitionAccounts(t, r)
+	transfers := []entities.Transfer{
+		newPartitionTransfer(origin.ID, destination.ID, time.Now().AddDate(-1, 0, 0)),
+		newPartitionTransfer(destination.ID, origin.ID, time.Now()),
+	}
+	for _, transfer := range transfers {
+		require.NoError(t, r.CreateTransfer(ctx, transfer))
+	}
+
+	// execute
+	require.NoError(t, m.Up(ctx))
+
+	// assert: the existing table is the partition of the transfers until the end of the month
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	partitions, err := NewPartitioner(pool).ListPartitions(ctx)
+	require.NoError(t, err)
+	require.Len(t, partitions, 4)
+	assert.Equal(t, "transfers_legacy", partitions[0].Name)
+	assert.True(t, partitions[0].From.IsZero())
+	assert.True(t, month.AddDate(0, 1, 0).Equal(partitions[0].To))
+	for i, part := range partitions[1:] {
+		from := month.AddDate(0, i+1, 0)
+		assert.Equal(t, "transfers_"+from.Format("2006_01"), part.Name)
+		assert.True(t, from.Equal(part.From))
+		assert.True(t, from.AddDate(0, 1, 0).Equal(part.To))
+	}
+
+	// assert: the legacy partition adopted the indexes built before the partitioning
+	rows, err := pool.Query(ctx, "select indexrelid::regclass::text from pg_index where indrelid = 'transfers_legacy'::regclass order by 1")
+	require.NoError(t, err)
+	indexes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"transfers_legacy_account_destination_id_id_idx",
+		"transfers_legacy_account_origin_id_id_idx",
+		"transfers_legacy_pkey",
+	}, indexes)
+
+	got, err := r.ListAccountTransfers(ctx, origin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Transfer{transfers[1], transfers[0]}, got)
+
+	// execute: revert the partitioning
+	require.NoError(t, m.To(ctx, partitionMigration-1))
+
+	// assert
+	got, err = r.ListAccountTransfers(ctx, origin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Transfer{transfers[1], transfers[0]}, got)
+}
+
+func TestPartitioner_CreatePartitions(t *testing.T) {
+	t.Parallel()
+
+	// setup: a transfer of a month without partition, held by the default partition
+	ctx := context.Background()
+	pool := newPartitionedPool(t)
+	r := NewRepository(dbpool.NewConn(pool))
+	p := NewPartitioner(pool)
+
+	origin, destination := createPartitionAccounts(t, r)
+	later := time.Now().AddDate(0, 6, 0)
+	transfer := newPartitionTransfer(origin.ID, destination.ID, later)
+	require.NoError(t, r.CreateTransfer(ctx, transfer))
+
+	// execute
+	created, err := p.CreatePartitions(ctx, later, 1)
+
+	// assert: the transfer was moved to its partition
+	require.NoError(t, err)
+	laterUTC := later.UTC()
+	month := time.Date(laterUTC.Year(), laterUTC.Month(), 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{
+		"transfers_" + month.Format("2006_01"),
+		"transfers_" + month.AddDate(0, 1, 0).Format("2006_01"),
+	}, created)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, "select count(*) from transfers_default").Scan(&count))
+	assert.Zero(t, count)
+	require.NoError(t, pool.QueryRow(ctx, "select count(*) from "+created[0]).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	got, err := r.ListAccountTransfers(ctx, destination.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Transfer{transfer}, got)
+
+	// execute: the partitions exist
+	created, err = p.CreatePartitions(ctx, later, 1)
+
+	// assert
+	require.NoError(t, err)
+	assert.Empty(t, created)
+}
+
+func TestPartitioner_Archive(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := context.Background()
+	pool := newPartitionedPool(t)
+	r := NewRepository(dbpool.NewConn(pool))
+	p := NewPartitioner(pool)
+	dir := t.TempDir()
+
+	origin, destination := createPartitionAccounts(t, r)
+	transfers := []entities.Transfer{
+		newPartitionTransfer(origin.ID, destination.ID, time.Now().AddDate(-2, 0, 0)),
+		newPartitionTransfer(destination.ID, origin.ID, time.Now().AddDate(-1, 0, 0)),
+	}
+	for _, transfer := range transfers {
+		require.NoError(t, r.CreateTransfer(ctx, transfer))
+	}
+
+	partitions, err := p.ListPartitions(ctx)
+	require.NoError(t, err)
+	legacy := partitions[0]
+
+	// execute
+	archived, err := p.Archive(ctx, legacy, dir)
+
+	// assert: exported with a header line and detached
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "transfers_legacy.csv.gz"), archived.Path)
+	assert.Equal(t, int64(2), archived.Rows)
+
+	f, err := os.Open(archived.Path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	records, err := csv.NewReader(gz).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "account_origin_id", "account_destination_id", "amount", "created_at", "updated_at"}, records[0])
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	partitions, err = p.ListPartitions(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, partitions, legacy)
+
+	got, err := r.ListAccountTransfers(ctx, origin.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// execute: drop an attached partition
+	err = p.Drop(ctx, partitions[0].Name)
+
+	// assert
+	assert.Error(t, err)
+
+	// execute
+	err = p.Drop(ctx, legacy.Name)
+
+	// assert
+	require.NoError(t, err)
+	var exists bool
+	require.NoError(t, pool.QueryRow(ctx, "select to_regclass('transfers_legacy') is not null").Scan(&exists))
+	assert.False(t, exists)
+}
+
+// newPartitionedPool returns the pool of a migrated database. The partitioner takes the pool, not a dbpool.Conn.
+func newPartitionedPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	pool := newPool(t)
+	require.NoError(t, NewMigrator(pool).Up(context.Background()))
+
+	return pool
+}
+
+func createPartitionAccounts(t *testing.T, r Repository) (entities.Account, entities.Account) {
+	t.Helper()
+
+	accounts := make([]entities.Account, 0, 2)
+	for _, document := range []vos.Document{"33344455566", "33344455567"} {
+		acc := entities.Account{
+			ID:        uuid.Must(uuid.NewV7()),
+			Name:      "Elliot",
+			Document:  document,
+			Secret:    "password",
+			CreatedAt: time.Now().Truncate(time.Second),
+		}
+		require.NoError(t, r.CreateAccount(context.Background(), acc))
+		accounts = append(accounts, acc)
+	}
+
+	return accounts[0], accounts[1]
+}
+
+func newPartitionTransfer(origin, destination uuid.UUID, createdAt time.Time) entities.Transfer {
+	return entities.Transfer{
+		ID:                   uuid.Must(uuid.NewV7()),
+		AccountOriginID:      origin,
+		AccountDestinationID: destination,
+		Amount:               10,
+		CreatedAt:            createdAt.Truncate(time.Second),
+	}
+}
@@ -89,7 +89,7 @@ func TestMain(m *testing.M) {
 
 // NewDB creates a new database named as a sanitized dbName. It returns a connection pool to this database.
 // It must be called after StartDockerContainer.
-func NewDB(t *testing.T) dbpool.Conn {
+func NewDB(t testing.TB) dbpool.Conn {
 	t.Helper()
 
 	ctx, err := logger.NewWithCtx(context.Background())
@@ -99,9 +99,21 @@ func NewDB(t *testing.T) dbpool.Conn {
 		return dbpool.Conn{}
 	}
 
+	pool := newPool(t)
+
+	err = NewMigrator(pool).Up(ctx)
+	require.NoError(t, err)
+
+	return dbpool.NewConn(pool)
+}
+
+// newPool creates a new database without the migrations and returns a connection pool to it.
+func newPool(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
 	dbName := fmt.Sprintf("db_%d", time.Now().UnixNano())
 
-	_, err = mainPool.Exec(context.Background(), "create database "+dbName)
+	_, err := mainPool.Exec(context.Background(), "create database "+dbName)
 	require.NoError(t, err)
 
 	connString := strings.Replace(mainPool.Config().ConnString(), mainPool.Config().ConnConfig.Database, dbName, 1)
@@ -111,14 +123,10 @@ func NewDB(t *testing.T) dbpool.Conn {
 	err = pool.Ping(context.Background())
 	require.NoError(t, err)
 
-	migrationPath := "migrations"
-	err = Migration(ctx, migrationPath, pool)
-	require.NoError(t, err)
-
 	t.Cleanup(func() {
 		pool.Close()
 		_, _ = pool.Exec(context.Background(), "drop database "+dbName)
 	})
 
-	return dbpool.NewConn(pool)
+	return pool
 }
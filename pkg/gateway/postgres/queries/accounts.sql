@@ -2,18 +2,29 @@
 insert into accounts (id, document_number, name, secret, balance, created_at)
 values (@id, @document_number, @name, @secret, @balance, @created_at);
 
--- name: GetAccount :one
-select *
+-- name: InsertAccounts :copyfrom
+insert into accounts (id, document_number, name, secret, balance, created_at)
+values (@id, @document_number, @name, @secret, @balance, @created_at);
+
+-- name: ListExistingDocuments :many
+select document_number
 from accounts
-where id = @id;
+where document_number = any(@documents::text[]);
+
+-- name: GetAccount :one
+-- the balance of the account is the one of its row plus shard_balance, the one of its shards.
+select sqlc.embed(a), (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)::bigint as shard_balance
+from accounts a
+where a.id = @id;
 
 -- name: GetAccountByDocument :one
-select *
-from accounts
-where document_number = @document_number;
+select sqlc.embed(a), (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)::bigint as shard_balance
+from accounts a
+where a.document_number = @document_number;
 
 -- name: ListAccounts :many
-select * from accounts a
+select sqlc.embed(a), (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)::bigint as shard_balance
+from accounts a
 where id = any(@ids::uuid[])
     and (@last_fetched_id::uuid = '00000000-0000-0000-0000-000000000000'  or id < @last_fetched_id::uuid)
 order by a.id desc
@@ -22,4 +33,60 @@ limit @page_size;
 -- name: UpdateAccountBalance :exec
 update accounts
 set balance = balance + @amount::int
-where id = @id;
\ No newline at end of file
+where id = @id;
+
+-- name: CreditAccountBalanceShard :execrows
+-- credits a random shard of the account, if it has shards. The shard is picked once by the cte,
+-- a random() in the where clause would be evaluated for every shard.
+with pick as (
+    select floor(random() * balance_shards)::int as shard
+    from accounts
+    where id = @id and balance_shards > 0
+)
+update account_balance_shards s
+set balance = s.balance + @amount::bigint
+from pick
+where s.account_id = @id and s.shard = pick.shard;
+
+-- name: SweepAccountBalance :exec
+-- moves the balance of the shards to the account and adds the amount to it.
+-- The shards and the account stay locked until the end of the transaction.
+with swept as (
+    update account_balance_shards
+    set balance = 0
+    where account_id = @id and balance > 0
+    returning balance
+)
+update accounts
+set balance = accounts.balance + (select coalesce(sum(swept.balance), 0) from swept) + @amount::bigint
+where id = @id;
+
+-- name: ResetAccountBalanceShards :execrows
+-- moves the balance of the shards to the account, deleting them, and sets the number of shards.
+with deleted as (
+    delete from account_balance_shards
+    where account_id = @id
+    returning balance
+)
+update accounts
+set balance        = accounts.balance + (select coalesce(sum(deleted.balance), 0) from deleted),
+    balance_shards = @shards::int
+where id = @id;
+
+-- name: InsertAccountBalanceShards :exec
+insert into account_balance_shards (account_id, shard)
+select sqlc.arg(account_id)::uuid, shard
+from generate_series(0, sqlc.arg(shards)::int - 1) as shard;
+
+-- name: GetAccountShardBalance :one
+select coalesce(sum(balance), 0)::bigint
+from account_balance_shards
+where account_id = @account_id;
+
+-- name: UpdateAccount :one
+-- updates the account at the expected version, or at any version if it's zero.
+update accounts
+set name = @name
+where id = @id
+    and (@expected_version::bigint = 0 or version = @expected_version::bigint)
+returning *;
@@ -0,0 +1,22 @@
+-- name: InsertEvent :exec
+insert into events (id, aggregate_type, aggregate_id, routing_key, payload, created_at)
+values (@id, @aggregate_type, @aggregate_id, @routing_key, @payload, @created_at);
+
+-- name: ListEvents :many
+select *
+from events
+where sequence > @after_sequence
+  and (@aggregate_id::uuid = '00000000-0000-0000-0000-000000000000' or aggregate_id = @aggregate_id)
+  and created_at >= @from_time
+  and (sqlc.narg('to_time')::timestamptz is null or created_at < sqlc.narg('to_time'))
+order by sequence
+limit @page_size;
+
+-- name: ListAccountEvents :many
+select ae.sequence as account_sequence, ae.balance, sqlc.embed(e)
+from account_events ae
+join events e on e.id = ae.event_id
+where ae.account_id = @account_id
+  and ae.sequence > @after_sequence
+order by ae.sequence
+limit @page_size;
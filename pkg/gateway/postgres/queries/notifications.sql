@@ -0,0 +1,29 @@
+-- name: UpsertNotificationPreferences :one
+insert into notification_preferences (account_id, locale, channels, events, email, phone, large_transfer_amount)
+values (@account_id, @locale, @channels, @events, @email, @phone, @large_transfer_amount)
+on conflict (account_id) do update
+set locale                = excluded.locale,
+    channels              = excluded.channels,
+    events                = excluded.events,
+    email                 = excluded.email,
+    phone                 = excluded.phone,
+    large_transfer_amount = excluded.large_transfer_amount
+returning updated_at;
+
+-- name: GetNotificationPreferences :one
+select *
+from notification_preferences
+where account_id = @account_id;
+
+-- name: UpsertNotification :one
+insert into notifications (id, account_id, event_id, event, channel, recipient, subject, body, status, created_at)
+values (@id, @account_id, @event_id, @event, @channel, @recipient, @subject, @body, @status, @created_at)
+on conflict (account_id, event, event_id, channel) do update
+set attempts = notifications.attempts + 1
+returning *;
+
+-- name: UpdateNotificationSent :exec
+update notifications
+set status  = 'sent',
+    sent_at = @sent_at
+where id = @id;
@@ -0,0 +1,4 @@
+-- name: InsertProcessedMessage :execrows
+insert into processed_messages (consumer, message_id)
+values (@consumer, @message_id)
+on conflict (consumer, message_id) do nothing;
\ No newline at end of file
@@ -3,7 +3,12 @@ insert into transfers(id, account_origin_id, account_destination_id, amount, cre
 values (@id, @account_origin_id, @account_destination_id, @amount, @created_at);
 
 -- name: ListAccountTransfers :many
-select *
-from transfers
-where account_origin_id = @account_id or account_destination_id = @account_id
+-- the union reads each side from its (account, id) index, an or of both columns needs a bitmap scan and a sort.
+select sent.*
+from transfers sent
+where sent.account_origin_id = @account_id
+union all
+select received.*
+from transfers received
+where received.account_destination_id = @account_id and received.account_origin_id <> @account_id
 order by id desc;
\ No newline at end of file
@@ -0,0 +1,80 @@
+-- name: InsertWebhookSubscription :exec
+insert into webhook_subscriptions (id, account_id, url, event_types, secret, created_at)
+values (@id, @account_id, @url, @event_types, @secret, @created_at);
+
+-- name: GetWebhookSubscription :one
+select *
+from webhook_subscriptions
+where id = @id;
+
+-- name: ListWebhookSubscriptions :many
+select *
+from webhook_subscriptions
+where account_id = @account_id
+order by id desc;
+
+-- name: ListWebhookSubscriptionsByEvent :many
+select *
+from webhook_subscriptions
+where account_id = @account_id
+    and @event_type::text = any(event_types)
+order by id;
+
+-- name: DeleteWebhookSubscription :execrows
+delete from webhook_subscriptions
+where id = @id and account_id = @account_id;
+
+-- name: InsertWebhookDelivery :execrows
+insert into webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+values (@id, @subscription_id, @event_id, @event_type, @payload, @status, @next_attempt_at, @created_at)
+on conflict (subscription_id, event_id, event_type) do nothing;
+
+-- name: GetWebhookDelivery :one
+select *
+from webhook_deliveries
+where id = @id;
+
+-- name: ListWebhookDeliveries :many
+select *
+from webhook_deliveries
+where subscription_id = @subscription_id
+order by id desc
+limit @page_size;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- claims the pending deliveries whose next attempt is due by moving it to the end of the lease, so the
+-- deliveries are attempted outside a transaction and the other workers skip them meanwhile.
+with due as (
+    select p.id
+    from webhook_deliveries p
+    where p.status = 'pending' and p.next_attempt_at <= @now::timestamptz
+    order by p.next_attempt_at
+    limit @max_items
+    for update skip locked
+)
+update webhook_deliveries d
+set next_attempt_at = @lease_until::timestamptz
+from due
+where d.id = due.id
+returning d.*;
+
+-- name: UpdateWebhookDelivery :exec
+update webhook_deliveries
+set status          = @status,
+    attempts        = @attempts,
+    response_status = @response_status,
+    last_error      = @last_error,
+    next_attempt_at = @next_attempt_at,
+    delivered_at    = @delivered_at
+where id = @id;
+
+-- name: ReplayWebhookDelivery :execrows
+-- schedules a new delivery unless it was attempted or claimed since it was read, which changes the compared columns.
+update webhook_deliveries
+set status          = 'pending',
+    attempts        = 0,
+    next_attempt_at = @next_attempt_at
+where id = @id
+  and status = @read_status
+  and attempts = @read_attempts
+  and next_attempt_at = @read_next_attempt_at::timestamptz;
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gorabbitmq "github.com/wagslane/go-rabbitmq"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase/mocks"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+)
+
+func TestRepository_Replicas(t *testing.T) {
+	t.Parallel()
+
+	// setup: the replica is another database, so the reads routed to it don't see the writes on the primary.
+	ctx := context.Background()
+	primary := newPool(t)
+	replica := newPool(t)
+	for _, pool := range []*pgxpool.Pool{primary, replica} {
+		require.NoError(t, NewMigrator(pool).Up(ctx))
+	}
+
+	replicas := dbpool.NewReplicas([]*pgxpool.Pool{replica}, time.Second)
+	r := NewRepository(dbpool.NewConn(primary).WithReplicas(replicas))
+
+	acc := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455566",
+		Secret:    "password",
+		Balance:   10,
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, acc))
+	list := usecase.ListAccountsInput{PageSize: 10}
+
+	// execute: the replica wasn't checked yet
+	out, err := r.ListAccounts(ctx, list)
+
+	// assert: read from the primary
+	require.NoError(t, err)
+	assert.Len(t, out.Accounts, 1)
+
+	// execute
+	replicas.CheckLag(ctx)
+	out, err = r.ListAccounts(ctx, list)
+
+	// assert: read from the replica
+	require.NoError(t, err)
+	assert.Empty(t, out.Accounts)
+
+	// execute: read your writes
+	out, err = r.ListAccounts(dbpool.ReadYourWrites(ctx), list)
+
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, out.Accounts, 1)
+
+	// execute: in a transaction
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		out, err = r.ListAccounts(ctx, list)
+		return err
+	})
+
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, out.Accounts, 1)
+
+	// execute: the writes
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, 5))
+
+	// assert: on the primary
+	balance, err := NewRepository(dbpool.NewConn(primary)).GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+}
+
+func TestRepository_Replicas_Consumers(t *testing.T) {
+	t.Parallel()
+
+	// setup: the events are consumed before the replica has the writes that produced them.
+	ctx := context.Background()
+	primary := newPool(t)
+	replica := newPool(t)
+	for _, pool := range []*pgxpool.Pool{primary, replica} {
+		require.NoError(t, NewMigrator(pool).Up(ctx))
+	}
+
+	replicas := dbpool.NewReplicas([]*pgxpool.Pool{replica}, time.Second)
+	r := NewRepository(dbpool.NewConn(primary).WithReplicas(replicas))
+
+	accounts := make([]entities.Account, 2)
+	for i, document := range []string{"33344455566", "33344455567"} {
+		accounts[i] = entities.Account{
+			ID:        uuid.Must(uuid.NewV7()),
+			Name:      "Elliot",
+			Document:  vos.Document(document),
+			Secret:    "password",
+			Balance:   10,
+			CreatedAt: time.Now().Truncate(time.Second),
+		}
+		require.NoError(t, r.CreateAccount(ctx, accounts[i]))
+	}
+
+	require.NoError(t, r.CreateWebhookSubscription(ctx, entities.WebhookSubscription{
+		ID:         uuid.Must(uuid.NewV7()),
+		AccountID:  accounts[1].ID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+		Secret:     "secret",
+		CreatedAt:  time.Now().Truncate(time.Second),
+	}))
+	transfer := entities.Transfer{
+		ID:                   uuid.Must(uuid.NewV7()),
+		AccountOriginID:      accounts[0].ID,
+		AccountDestinationID: accounts[1].ID,
+		Amount:               5,
+		CreatedAt:            time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateTransfer(ctx, transfer))
+
+	replicas.CheckLag(ctx)
+
+	notifications := usecase.NewSendNotificationsUC(r, &mocks.NotificationTemplatesMock{}, &mocks.NotificationSenderMock{}, &config.NotificationConfig{})
+	dispatch := usecase.NewDispatchWebhooksUC(r)
+
+	var dispatched usecase.DispatchTransferOutput
+	h := rabbitmq.Chain(func(ctx context.Context, d gorabbitmq.Delivery) gorabbitmq.Action {
+		if _, err := notifications.NotifyAccountCreation(ctx, usecase.NotifyAccountCreationInput{
+			EventID:   accounts[0].ID.String(),
+			AccountID: accounts[0].ID,
+		}); err != nil {
+			return gorabbitmq.NackDiscard
+		}
+
+		var err error
+		dispatched, err = dispatch.DispatchTransfer(ctx, usecase.DispatchTransferInput{EventID: transfer.ID.String(), Transfer: transfer})
+		if err != nil {
+			return gorabbitmq.NackRequeue
+		}
+
+		return gorabbitmq.Ack
+	}, rabbitmq.ReadYourWrites)
+
+	// execute: outside the consumers
+	_, err := notifications.NotifyAccountCreation(ctx, usecase.NotifyAccountCreationInput{AccountID: accounts[0].ID})
+
+	// assert: the replica lags
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute
+	action := h(ctx, gorabbitmq.Delivery{})
+
+	// assert: the consumers read from the primary
+	assert.Equal(t, gorabbitmq.Ack, action)
+	assert.Len(t, dispatched.Deliveries, 1)
+}
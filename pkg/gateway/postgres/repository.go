@@ -25,3 +25,7 @@ func (r Repository) CommitTX(ctx context.Context) error {
 func (r Repository) RollbackTX(ctx context.Context) error {
 	return r.conn.RollbackTX(ctx) // nolint:wrapcheck
 }
+
+func (r Repository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.conn.WithTx(ctx, fn) // nolint:wrapcheck
+}
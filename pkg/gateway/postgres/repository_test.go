@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/repotest"
+)
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	repotest.TestRepository(t, func(t *testing.T) repotest.Repository {
+		return NewRepository(NewDB(t))
+	})
+}
@@ -12,48 +12,105 @@ import (
 	uuid "github.com/gofrs/uuid/v5"
 )
 
+const CreditAccountBalanceShard = `-- name: CreditAccountBalanceShard :execrows
+with pick as (
+    select floor(random() * balance_shards)::int as shard
+    from accounts
+    where id = $2 and balance_shards > 0
+)
+update account_balance_shards s
+set balance = s.balance + $1::bigint
+from pick
+where s.account_id = $2 and s.shard = pick.shard
+`
+
+type CreditAccountBalanceShardParams struct {
+	Amount int64
+	ID     uuid.UUID
+}
+
+// credits a random shard of the account, if it has shards. The shard is picked once by the cte,
+// a random() in the where clause would be evaluated for every shard.
+func (q *Queries) CreditAccountBalanceShard(ctx context.Context, arg CreditAccountBalanceShardParams) (int64, error) {
+	result, err := q.db.Exec(ctx, CreditAccountBalanceShard, arg.Amount, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const GetAccount = `-- name: GetAccount :one
-select id, document_number, name, secret, balance, created_at, updated_at
-from accounts
-where id = $1
+select a.id, a.document_number, a.name, a.secret, a.balance, a.created_at, a.updated_at, a.version, a.balance_shards, (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)::bigint as shard_balance
+from accounts a
+where a.id = $1
 `
 
-func (q *Queries) GetAccount(ctx context.Context, id uuid.UUID) (Account, error) {
+type GetAccountRow struct {
+	Account      Account
+	ShardBalance int64
+}
+
+// the balance of the account is the one of its row plus shard_balance, the one of its shards.
+func (q *Queries) GetAccount(ctx context.Context, id uuid.UUID) (GetAccountRow, error) {
 	row := q.db.QueryRow(ctx, GetAccount, id)
-	var i Account
+	var i GetAccountRow
 	err := row.Scan(
-		&i.ID,
-		&i.DocumentNumber,
-		&i.Name,
-		&i.Secret,
-		&i.Balance,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.Account.ID,
+		&i.Account.DocumentNumber,
+		&i.Account.Name,
+		&i.Account.Secret,
+		&i.Account.Balance,
+		&i.Account.CreatedAt,
+		&i.Account.UpdatedAt,
+		&i.Account.Version,
+		&i.Account.BalanceShards,
+		&i.ShardBalance,
 	)
 	return i, err
 }
 
 const GetAccountByDocument = `-- name: GetAccountByDocument :one
-select id, document_number, name, secret, balance, created_at, updated_at
-from accounts
-where document_number = $1
+select a.id, a.document_number, a.name, a.secret, a.balance, a.created_at, a.updated_at, a.version, a.balance_shards, (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)::bigint as shard_balance
+from accounts a
+where a.document_number = $1
 `
 
-func (q *Queries) GetAccountByDocument(ctx context.Context, documentNumber string) (Account, error) {
+type GetAccountByDocumentRow struct {
+	Account      Account
+	ShardBalance int64
+}
+
+func (q *Queries) GetAccountByDocument(ctx context.Context, documentNumber string) (GetAccountByDocumentRow, error) {
 	row := q.db.QueryRow(ctx, GetAccountByDocument, documentNumber)
-	var i Account
+	var i GetAccountByDocumentRow
 	err := row.Scan(
-		&i.ID,
-		&i.DocumentNumber,
-		&i.Name,
-		&i.Secret,
-		&i.Balance,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.Account.ID,
+		&i.Account.DocumentNumber,
+		&i.Account.Name,
+		&i.Account.Secret,
+		&i.Account.Balance,
+		&i.Account.CreatedAt,
+		&i.Account.UpdatedAt,
+		&i.Account.Version,
+		&i.Account.BalanceShards,
+		&i.ShardBalance,
 	)
 	return i, err
 }
 
+const GetAccountShardBalance = `-- name: GetAccountShardBalance :one
+select coalesce(sum(balance), 0)::bigint
+from account_balance_shards
+where account_id = $1
+`
+
+func (q *Queries) GetAccountShardBalance(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, GetAccountShardBalance, accountID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const InsertAccount = `-- name: InsertAccount :exec
 insert into accounts (id, document_number, name, secret, balance, created_at)
 values ($1, $2, $3, $4, $5, $6)
@@ -80,8 +137,34 @@ func (q *Queries) InsertAccount(ctx context.Context, arg InsertAccountParams) er
 	return err
 }
 
+const InsertAccountBalanceShards = `-- name: InsertAccountBalanceShards :exec
+insert into account_balance_shards (account_id, shard)
+select $1::uuid, shard
+from generate_series(0, $2::int - 1) as shard
+`
+
+type InsertAccountBalanceShardsParams struct {
+	AccountID uuid.UUID
+	Shards    int32
+}
+
+func (q *Queries) InsertAccountBalanceShards(ctx context.Context, arg InsertAccountBalanceShardsParams) error {
+	_, err := q.db.Exec(ctx, InsertAccountBalanceShards, arg.AccountID, arg.Shards)
+	return err
+}
+
+type InsertAccountsParams struct {
+	ID             uuid.UUID
+	DocumentNumber string
+	Name           string
+	Secret         string
+	Balance        int64
+	CreatedAt      time.Time
+}
+
 const ListAccounts = `-- name: ListAccounts :many
-select id, document_number, name, secret, balance, created_at, updated_at from accounts a
+select a.id, a.document_number, a.name, a.secret, a.balance, a.created_at, a.updated_at, a.version, a.balance_shards, (select coalesce(sum(s.balance), 0) from account_balance_shards s where s.account_id = a.id)::bigint as shard_balance
+from accounts a
 where id = any($1::uuid[])
     and ($2::uuid = '00000000-0000-0000-0000-000000000000'  or id < $2::uuid)
 order by a.id desc
@@ -94,23 +177,31 @@ type ListAccountsParams struct {
 	PageSize      int32
 }
 
-func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+type ListAccountsRow struct {
+	Account      Account
+	ShardBalance int64
+}
+
+func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]ListAccountsRow, error) {
 	rows, err := q.db.Query(ctx, ListAccounts, arg.Ids, arg.LastFetchedID, arg.PageSize)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Account
+	var items []ListAccountsRow
 	for rows.Next() {
-		var i Account
+		var i ListAccountsRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.DocumentNumber,
-			&i.Name,
-			&i.Secret,
-			&i.Balance,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Account.ID,
+			&i.Account.DocumentNumber,
+			&i.Account.Name,
+			&i.Account.Secret,
+			&i.Account.Balance,
+			&i.Account.CreatedAt,
+			&i.Account.UpdatedAt,
+			&i.Account.Version,
+			&i.Account.BalanceShards,
+			&i.ShardBalance,
 		); err != nil {
 			return nil, err
 		}
@@ -122,6 +213,114 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
+const ListExistingDocuments = `-- name: ListExistingDocuments :many
+select document_number
+from accounts
+where document_number = any($1::text[])
+`
+
+func (q *Queries) ListExistingDocuments(ctx context.Context, documents []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, ListExistingDocuments, documents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var document_number string
+		if err := rows.Scan(&document_number); err != nil {
+			return nil, err
+		}
+		items = append(items, document_number)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ResetAccountBalanceShards = `-- name: ResetAccountBalanceShards :execrows
+with deleted as (
+    delete from account_balance_shards
+    where account_id = $2
+    returning balance
+)
+update accounts
+set balance        = accounts.balance + (select coalesce(sum(deleted.balance), 0) from deleted),
+    balance_shards = $1::int
+where id = $2
+`
+
+type ResetAccountBalanceShardsParams struct {
+	Shards int32
+	ID     uuid.UUID
+}
+
+// moves the balance of the shards to the account, deleting them, and sets the number of shards.
+func (q *Queries) ResetAccountBalanceShards(ctx context.Context, arg ResetAccountBalanceShardsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ResetAccountBalanceShards, arg.Shards, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const SweepAccountBalance = `-- name: SweepAccountBalance :exec
+with swept as (
+    update account_balance_shards
+    set balance = 0
+    where account_id = $2 and balance > 0
+    returning balance
+)
+update accounts
+set balance = accounts.balance + (select coalesce(sum(swept.balance), 0) from swept) + $1::bigint
+where id = $2
+`
+
+type SweepAccountBalanceParams struct {
+	Amount int64
+	ID     uuid.UUID
+}
+
+// moves the balance of the shards to the account and adds the amount to it.
+// The shards and the account stay locked until the end of the transaction.
+func (q *Queries) SweepAccountBalance(ctx context.Context, arg SweepAccountBalanceParams) error {
+	_, err := q.db.Exec(ctx, SweepAccountBalance, arg.Amount, arg.ID)
+	return err
+}
+
+const UpdateAccount = `-- name: UpdateAccount :one
+update accounts
+set name = $1
+where id = $2
+    and ($3::bigint = 0 or version = $3::bigint)
+returning id, document_number, name, secret, balance, created_at, updated_at, version, balance_shards
+`
+
+type UpdateAccountParams struct {
+	Name            string
+	ID              uuid.UUID
+	ExpectedVersion int64
+}
+
+// updates the account at the expected version, or at any version if it's zero.
+func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
+	row := q.db.QueryRow(ctx, UpdateAccount, arg.Name, arg.ID, arg.ExpectedVersion)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.DocumentNumber,
+		&i.Name,
+		&i.Secret,
+		&i.Balance,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.BalanceShards,
+	)
+	return i, err
+}
+
 const UpdateAccountBalance = `-- name: UpdateAccountBalance :exec
 update accounts
 set balance = balance + $1::int
@@ -0,0 +1,47 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: copyfrom.go
+
+package sqlc
+
+import (
+	"context"
+)
+
+// iteratorForInsertAccounts implements pgx.CopyFromSource.
+type iteratorForInsertAccounts struct {
+	rows                 []InsertAccountsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertAccounts) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertAccounts) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ID,
+		r.rows[0].DocumentNumber,
+		r.rows[0].Name,
+		r.rows[0].Secret,
+		r.rows[0].Balance,
+		r.rows[0].CreatedAt,
+	}, nil
+}
+
+func (r iteratorForInsertAccounts) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertAccounts(ctx context.Context, arg []InsertAccountsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"accounts"}, []string{"id", "document_number", "name", "secret", "balance", "created_at"}, &iteratorForInsertAccounts{rows: arg})
+}
@@ -0,0 +1,144 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: events.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid/v5"
+)
+
+const InsertEvent = `-- name: InsertEvent :exec
+insert into events (id, aggregate_type, aggregate_id, routing_key, payload, created_at)
+values ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertEventParams struct {
+	ID            uuid.UUID
+	AggregateType string
+	AggregateID   uuid.UUID
+	RoutingKey    string
+	Payload       []byte
+	CreatedAt     time.Time
+}
+
+func (q *Queries) InsertEvent(ctx context.Context, arg InsertEventParams) error {
+	_, err := q.db.Exec(ctx, InsertEvent,
+		arg.ID,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.RoutingKey,
+		arg.Payload,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const ListAccountEvents = `-- name: ListAccountEvents :many
+select ae.sequence as account_sequence, ae.balance, e.id, e.aggregate_type, e.aggregate_id, e.routing_key, e.payload, e.created_at, e.sequence
+from account_events ae
+join events e on e.id = ae.event_id
+where ae.account_id = $1
+  and ae.sequence > $2
+order by ae.sequence
+limit $3
+`
+
+type ListAccountEventsParams struct {
+	AccountID     uuid.UUID
+	AfterSequence int64
+	PageSize      int32
+}
+
+type ListAccountEventsRow struct {
+	AccountSequence int64
+	Balance         int64
+	Event           Event
+}
+
+func (q *Queries) ListAccountEvents(ctx context.Context, arg ListAccountEventsParams) ([]ListAccountEventsRow, error) {
+	rows, err := q.db.Query(ctx, ListAccountEvents, arg.AccountID, arg.AfterSequence, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAccountEventsRow
+	for rows.Next() {
+		var i ListAccountEventsRow
+		if err := rows.Scan(
+			&i.AccountSequence,
+			&i.Balance,
+			&i.Event.ID,
+			&i.Event.AggregateType,
+			&i.Event.AggregateID,
+			&i.Event.RoutingKey,
+			&i.Event.Payload,
+			&i.Event.CreatedAt,
+			&i.Event.Sequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListEvents = `-- name: ListEvents :many
+select id, aggregate_type, aggregate_id, routing_key, payload, created_at, sequence
+from events
+where sequence > $1
+  and ($2::uuid = '00000000-0000-0000-0000-000000000000' or aggregate_id = $2)
+  and created_at >= $3
+  and ($4::timestamptz is null or created_at < $4)
+order by sequence
+limit $5
+`
+
+type ListEventsParams struct {
+	AfterSequence int64
+	AggregateID   uuid.UUID
+	FromTime      time.Time
+	ToTime        *time.Time
+	PageSize      int32
+}
+
+func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]Event, error) {
+	rows, err := q.db.Query(ctx, ListEvents,
+		arg.AfterSequence,
+		arg.AggregateID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.RoutingKey,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.Sequence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -18,6 +18,70 @@ type Account struct {
 	Balance        int64
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	Version        int64
+	BalanceShards  int32
+}
+
+type AccountBalanceShard struct {
+	AccountID uuid.UUID
+	Shard     int32
+	Balance   int64
+}
+
+type AccountEvent struct {
+	AccountID uuid.UUID
+	Sequence  int64
+	EventID   uuid.UUID
+	Balance   int64
+}
+
+type AccountEventSequence struct {
+	AccountID uuid.UUID
+	Sequence  int64
+}
+
+type Event struct {
+	ID            uuid.UUID
+	AggregateType string
+	AggregateID   uuid.UUID
+	RoutingKey    string
+	Payload       []byte
+	CreatedAt     time.Time
+	Sequence      int64
+}
+
+type Notification struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	EventID   string
+	Event     string
+	Channel   string
+	Recipient string
+	Subject   string
+	Body      string
+	Status    string
+	Attempts  int32
+	SentAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type NotificationPreference struct {
+	AccountID           uuid.UUID
+	Locale              string
+	Channels            []string
+	Events              []string
+	Email               string
+	Phone               string
+	LargeTransferAmount int64
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type ProcessedMessage struct {
+	Consumer    string
+	MessageID   string
+	ProcessedAt time.Time
 }
 
 type Transfer struct {
@@ -28,3 +92,51 @@ type Transfer struct {
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 }
+
+type TransferID struct {
+	ID uuid.UUID
+}
+
+type TransfersDefault struct {
+	ID                   uuid.UUID
+	AccountOriginID      uuid.UUID
+	AccountDestinationID uuid.UUID
+	Amount               int64
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type TransfersLegacy struct {
+	ID                   uuid.UUID
+	AccountOriginID      uuid.UUID
+	AccountDestinationID uuid.UUID
+	Amount               int64
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	ResponseStatus int32
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	AccountID  uuid.UUID
+	Url        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
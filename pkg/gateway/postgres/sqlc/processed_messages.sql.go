@@ -0,0 +1,29 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: processed_messages.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const InsertProcessedMessage = `-- name: InsertProcessedMessage :execrows
+insert into processed_messages (consumer, message_id)
+values ($1, $2)
+on conflict (consumer, message_id) do nothing
+`
+
+type InsertProcessedMessageParams struct {
+	Consumer  string
+	MessageID string
+}
+
+func (q *Queries) InsertProcessedMessage(ctx context.Context, arg InsertProcessedMessageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, InsertProcessedMessage, arg.Consumer, arg.MessageID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -37,12 +37,17 @@ func (q *Queries) InsertTransfer(ctx context.Context, arg InsertTransferParams)
 }
 
 const ListAccountTransfers = `-- name: ListAccountTransfers :many
-select id, account_origin_id, account_destination_id, amount, created_at, updated_at
-from transfers
-where account_origin_id = $1 or account_destination_id = $1
+select sent.id, sent.account_origin_id, sent.account_destination_id, sent.amount, sent.created_at, sent.updated_at
+from transfers sent
+where sent.account_origin_id = $1
+union all
+select received.id, received.account_origin_id, received.account_destination_id, received.amount, received.created_at, received.updated_at
+from transfers received
+where received.account_destination_id = $1 and received.account_origin_id <> $1
 order by id desc
 `
 
+// the union reads each side from its (account, id) index, an or of both columns needs a bitmap scan and a sort.
 func (q *Queries) ListAccountTransfers(ctx context.Context, accountID uuid.UUID) ([]Transfer, error) {
 	rows, err := q.db.Query(ctx, ListAccountTransfers, accountID)
 	if err != nil {
@@ -2,15 +2,21 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 
+	"github.com/higordasneves/e-corp/pkg/domain"
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/gateway/postgres/sqlc"
 )
 
 // CreateTransfer inserts a transfer in the database.
+// Returns domain.ErrNotFound if the origin or destination account not exists.
 func (r Repository) CreateTransfer(ctx context.Context, transfer entities.Transfer) error {
 	err := sqlc.New(r.conn.GetTxOrPool(ctx)).InsertTransfer(ctx, sqlc.InsertTransferParams{
 		ID:                   uuid.FromStringOrNil(transfer.ID.String()),
@@ -20,6 +26,14 @@ func (r Repository) CreateTransfer(ctx context.Context, transfer entities.Transf
 		CreatedAt:            transfer.CreatedAt,
 	})
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			accountID := transfer.AccountOriginID
+			if strings.Contains(pgErr.ConstraintName, "destination") {
+				accountID = transfer.AccountDestinationID
+			}
+			err = domain.NewError(domain.ErrNotFound, domain.CodeAccountNotFound, "account %s not exists", accountID).WithCause(err)
+		}
 		return fmt.Errorf("inserting transfer with id %s: %w", transfer.ID.String(), err)
 	}
 
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+)
+
+func TestTxRunner_RetriesSerializationFailures(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	conn := NewDB(t)
+	r := NewRepository(conn)
+	ctx := context.Background()
+
+	acc := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455566",
+		Secret:    "password",
+		Balance:   100,
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, acc))
+
+	runner := dbpool.NewTxRunner(conn, config.DatabaseConfig{
+		TxMaxAttempts:    5,
+		TxRetryBaseDelay: time.Millisecond,
+		TxRetryMaxDelay:  10 * time.Millisecond,
+	}, dbpool.TxOptions{Name: "test_serialization", IsoLevel: pgx.Serializable})
+
+	// both transactions read the balance before any of them changes it,
+	// so the second one to update it fails and runs again.
+	var read sync.WaitGroup
+	read.Add(2)
+	var attempts atomic.Int32
+
+	debit := func() error {
+		first := true
+		return runner.RunTx(ctx, func(ctx context.Context) error {
+			attempts.Add(1)
+
+			if _, err := r.GetBalance(ctx, acc.ID); err != nil {
+				return err
+			}
+
+			if first {
+				first = false
+				read.Done()
+				read.Wait()
+			}
+
+			return r.UpdateBalance(ctx, acc.ID, -10)
+		})
+	}
+
+	// execute
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = debit()
+		}()
+	}
+	wg.Wait()
+
+	// assert
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Equal(t, float64(1), testutil.ToFloat64(dbpool.TxRetries.WithLabelValues("test_serialization", pgerrcode.SerializationFailure)))
+
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 80, balance)
+}
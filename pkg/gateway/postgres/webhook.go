@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/sqlc"
+)
+
+// CreateWebhookSubscription inserts a webhook subscription in the database.
+func (r Repository) CreateWebhookSubscription(ctx context.Context, sub entities.WebhookSubscription) error {
+	eventTypes := make([]string, 0, len(sub.EventTypes))
+	for _, e := range sub.EventTypes {
+		eventTypes = append(eventTypes, string(e))
+	}
+
+	err := sqlc.New(r.conn.GetTxOrPool(ctx)).InsertWebhookSubscription(ctx, sqlc.InsertWebhookSubscriptionParams{
+		ID:         sub.ID,
+		AccountID:  sub.AccountID,
+		Url:        sub.URL,
+		EventTypes: eventTypes,
+		Secret:     sub.Secret,
+		CreatedAt:  sub.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("inserting webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookSubscription fetches a webhook subscription by id.
+func (r Repository) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (entities.WebhookSubscription, error) {
+	row, err := sqlc.New(r.conn.GetTxOrPool(ctx)).GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.WebhookSubscription{}, domain.NewError(domain.ErrNotFound, domain.CodeWebhookNotFound, "webhook %s not exists", id)
+		}
+		return entities.WebhookSubscription{}, fmt.Errorf("getting webhook subscription: %w", err)
+	}
+
+	return parseSqlcWebhookSubscription(row), nil
+}
+
+// ListWebhookSubscriptions lists the webhook subscriptions of an account in descending order.
+func (r Repository) ListWebhookSubscriptions(ctx context.Context, accountID uuid.UUID) ([]entities.WebhookSubscription, error) {
+	rows, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ListWebhookSubscriptions(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook subscriptions for account %s: %w", accountID, err)
+	}
+
+	subs := make([]entities.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subs = append(subs, parseSqlcWebhookSubscription(row))
+	}
+
+	return subs, nil
+}
+
+// ListWebhookSubscriptionsByEvent lists the webhook subscriptions of an account subscribed to the event type.
+func (r Repository) ListWebhookSubscriptionsByEvent(ctx context.Context, accountID uuid.UUID, eventType entities.WebhookEventType) ([]entities.WebhookSubscription, error) {
+	rows, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ListWebhookSubscriptionsByEvent(ctx, sqlc.ListWebhookSubscriptionsByEventParams{
+		AccountID: accountID,
+		EventType: string(eventType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing %s webhook subscriptions for account %s: %w", eventType, accountID, err)
+	}
+
+	subs := make([]entities.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subs = append(subs, parseSqlcWebhookSubscription(row))
+	}
+
+	return subs, nil
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription of the account and its deliveries.
+// Returns domain.ErrNotFound if the account doesn't have the subscription.
+func (r Repository) DeleteWebhookSubscription(ctx context.Context, accountID, id uuid.UUID) error {
+	n, err := sqlc.New(r.conn.GetTxOrPool(ctx)).DeleteWebhookSubscription(ctx, sqlc.DeleteWebhookSubscriptionParams{
+		ID:        id,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting webhook subscription: %w", err)
+	}
+
+	if n == 0 {
+		return domain.NewError(domain.ErrNotFound, domain.CodeWebhookNotFound, "webhook %s not exists", id)
+	}
+
+	return nil
+}
+
+// CreateWebhookDelivery inserts a webhook delivery in the database and reports whether it was inserted.
+// A delivery of the same event to the same subscription is ignored.
+func (r Repository) CreateWebhookDelivery(ctx context.Context, d entities.WebhookDelivery) (bool, error) {
+	n, err := sqlc.New(r.conn.GetTxOrPool(ctx)).InsertWebhookDelivery(ctx, sqlc.InsertWebhookDeliveryParams{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("inserting webhook delivery: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+// GetWebhookDelivery fetches a webhook delivery by id.
+func (r Repository) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (entities.WebhookDelivery, error) {
+	row, err := sqlc.New(r.conn.GetTxOrPool(ctx)).GetWebhookDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.WebhookDelivery{}, domain.NewError(domain.ErrNotFound, domain.CodeWebhookDeliveryNotFound, "webhook delivery %s not exists", id)
+		}
+		return entities.WebhookDelivery{}, fmt.Errorf("getting webhook delivery: %w", err)
+	}
+
+	return parseSqlcWebhookDelivery(row), nil
+}
+
+// ListWebhookDeliveries lists the most recent deliveries of a webhook subscription.
+func (r Repository) ListWebhookDeliveries(ctx context.Context, subscriptionID uuid.UUID, pageSize int) ([]entities.WebhookDelivery, error) {
+	rows, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ListWebhookDeliveries(ctx, sqlc.ListWebhookDeliveriesParams{
+		SubscriptionID: subscriptionID,
+		PageSize:       int32(pageSize), //nolint:gosec
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook deliveries for subscription %s: %w", subscriptionID, err)
+	}
+
+	deliveries := make([]entities.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, parseSqlcWebhookDelivery(row))
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDueWebhookDeliveries claims the pending deliveries whose next attempt is due, in no particular order,
+// by moving their next attempt to leaseUntil. The claimed deliveries are skipped by concurrent workers until then,
+// when they are claimed again unless the attempt was recorded.
+func (r Repository) ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	rows, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ClaimDueWebhookDeliveries(ctx, sqlc.ClaimDueWebhookDeliveriesParams{
+		Now:        now,
+		LeaseUntil: leaseUntil,
+		MaxItems:   int32(limit), //nolint:gosec
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claiming due webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]entities.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, parseSqlcWebhookDelivery(row))
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery updates the status and the attempts log of a webhook delivery.
+func (r Repository) UpdateWebhookDelivery(ctx context.Context, d entities.WebhookDelivery) error {
+	err := sqlc.New(r.conn.GetTxOrPool(ctx)).UpdateWebhookDelivery(ctx, sqlc.UpdateWebhookDeliveryParams{
+		ID:             d.ID,
+		Status:         string(d.Status),
+		Attempts:       int32(d.Attempts),       //nolint:gosec
+		ResponseStatus: int32(d.ResponseStatus), //nolint:gosec
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("updating webhook delivery %s: %w", d.ID, err)
+	}
+
+	return nil
+}
+
+// ReplayWebhookDelivery schedules a new attempt of the delivery at nextAttemptAt and restarts its attempts,
+// unless it changed since d was read: its status, attempts and next attempt are compared in the update,
+// so an attempt recorded or claimed meanwhile isn't overwritten.
+// Returns domain.ErrConflict if the delivery changed and domain.ErrNotFound if it doesn't exist.
+func (r Repository) ReplayWebhookDelivery(ctx context.Context, d entities.WebhookDelivery, nextAttemptAt time.Time) error {
+	n, err := sqlc.New(r.conn.GetTxOrPool(ctx)).ReplayWebhookDelivery(ctx, sqlc.ReplayWebhookDeliveryParams{
+		ID:                d.ID,
+		NextAttemptAt:     nextAttemptAt,
+		ReadStatus:        string(d.Status),
+		ReadAttempts:      int32(d.Attempts), //nolint:gosec
+		ReadNextAttemptAt: d.NextAttemptAt,
+	})
+	if err != nil {
+		return fmt.Errorf("replaying webhook delivery %s: %w", d.ID, err)
+	}
+
+	if n == 0 {
+		if _, err := r.GetWebhookDelivery(dbpool.ReadYourWrites(ctx), d.ID); err != nil {
+			return err
+		}
+		return domain.NewError(domain.ErrConflict, domain.CodeWebhookDeliveryChanged, "webhook delivery %s changed since it was read", d.ID)
+	}
+
+	return nil
+}
+
+func parseSqlcWebhookSubscription(s sqlc.WebhookSubscription) entities.WebhookSubscription {
+	eventTypes := make([]entities.WebhookEventType, 0, len(s.EventTypes))
+	for _, e := range s.EventTypes {
+		eventTypes = append(eventTypes, entities.WebhookEventType(e))
+	}
+
+	return entities.WebhookSubscription{
+		ID:         s.ID,
+		AccountID:  s.AccountID,
+		URL:        s.Url,
+		EventTypes: eventTypes,
+		Secret:     s.Secret,
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+func parseSqlcWebhookDelivery(d sqlc.WebhookDelivery) entities.WebhookDelivery {
+	return entities.WebhookDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      entities.WebhookEventType(d.EventType),
+		Payload:        d.Payload,
+		Status:         entities.WebhookDeliveryStatus(d.Status),
+		Attempts:       int(d.Attempts),
+		ResponseStatus: int(d.ResponseStatus),
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
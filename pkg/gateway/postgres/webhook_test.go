@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+func TestWebhookRepo_Subscriptions(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewRepository(NewDB(t))
+	ctx := context.Background()
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	subs := []entities.WebhookSubscription{
+		{
+			ID:         uuid.Must(uuid.NewV7()),
+			AccountID:  account.ID,
+			URL:        "https://partner.example.com/sent",
+			EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferSent},
+			Secret:     "whsec_1",
+			CreatedAt:  time.Now().Truncate(time.Second),
+		},
+		{
+			ID:         uuid.Must(uuid.NewV7()),
+			AccountID:  account.ID,
+			URL:        "https://partner.example.com/all",
+			EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferSent, entities.WebhookEventTransferReceived},
+			Secret:     "whsec_2",
+			CreatedAt:  time.Now().Truncate(time.Second),
+		},
+	}
+	for _, sub := range subs {
+		require.NoError(t, r.CreateWebhookSubscription(ctx, sub))
+	}
+
+	// execute and assert: listing by account
+	got, err := r.ListWebhookSubscriptions(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.WebhookSubscription{subs[1], subs[0]}, got)
+
+	// execute and assert: listing by event type
+	got, err = r.ListWebhookSubscriptionsByEvent(ctx, account.ID, entities.WebhookEventTransferReceived)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.WebhookSubscription{subs[1]}, got)
+
+	// execute and assert: deleting from another account
+	err = r.DeleteWebhookSubscription(ctx, uuid.Must(uuid.NewV7()), subs[0].ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute and assert: deleting
+	require.NoError(t, r.DeleteWebhookSubscription(ctx, account.ID, subs[0].ID))
+	_, err = r.GetWebhookSubscription(ctx, subs[0].ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestWebhookRepo_Deliveries(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	r := NewRepository(NewDB(t))
+	ctx := context.Background()
+
+	account := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455567",
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateAccount(ctx, account))
+
+	sub := entities.WebhookSubscription{
+		ID:         uuid.Must(uuid.NewV7()),
+		AccountID:  account.ID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferSent},
+		Secret:     "whsec_1",
+		CreatedAt:  time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, r.CreateWebhookSubscription(ctx, sub))
+
+	now := time.Now().Truncate(time.Second)
+	due := entities.WebhookDelivery{
+		ID:             uuid.Must(uuid.NewV7()),
+		SubscriptionID: sub.ID,
+		EventID:        "event_1",
+		EventType:      entities.WebhookEventTransferSent,
+		Payload:        []byte(`{"id": "event_1"}`),
+		Status:         entities.WebhookDeliveryPending,
+		NextAttemptAt:  now.Add(-time.Minute),
+		CreatedAt:      now,
+	}
+	notDue := due
+	notDue.ID = uuid.Must(uuid.NewV7())
+	notDue.EventID = "event_2"
+	notDue.Payload = []byte(`{"id": "event_2"}`)
+	notDue.NextAttemptAt = now.Add(time.Hour)
+
+	inserted, err := r.CreateWebhookDelivery(ctx, due)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	inserted, err = r.CreateWebhookDelivery(ctx, notDue)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	// execute and assert: the same event is not scheduled twice
+	duplicated := due
+	duplicated.ID = uuid.Must(uuid.NewV7())
+	inserted, err = r.CreateWebhookDelivery(ctx, duplicated)
+	require.NoError(t, err)
+	assert.False(t, inserted)
+	_, err = r.GetWebhookDelivery(ctx, duplicated.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute and assert: claiming due deliveries
+	got, err := r.ClaimDueWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, due.ID, got[0].ID)
+
+	// execute and assert: updating
+	due.Status = entities.WebhookDeliverySucceeded
+	due.Attempts = 1
+	due.ResponseStatus = 200
+	due.DeliveredAt = &now
+	require.NoError(t, r.UpdateWebhookDelivery(ctx, due))
+
+	got, err = r.ListWebhookDeliveries(ctx, sub.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, notDue.ID, got[0].ID)
+	assert.Equal(t, entities.WebhookDeliverySucceeded, got[1].Status)
+	assert.Equal(t, 200, got[1].ResponseStatus)
+}
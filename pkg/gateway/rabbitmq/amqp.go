@@ -0,0 +1,230 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/wagslane/go-rabbitmq"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/metrics"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// AMQPBroker is the Broker backed by a RabbitMQ server.
+type AMQPBroker struct {
+	conn       *rabbitmq.Conn
+	publisher  *rabbitmq.Publisher
+	url        string
+	exchange   string
+	state      *ConnState
+	retryDelay time.Duration
+	maxRetries int
+
+	mu        sync.Mutex
+	closed    bool
+	consumers []Consumer
+}
+
+func NewAMQPBroker(ctx context.Context, cfg config.RabbitMQConfig) (*AMQPBroker, error) {
+	state := NewConnState()
+	conn, err := NewConn(ctx, cfg.URL(), state)
+	if err != nil {
+		return nil, fmt.Errorf("creating rabbit conn: %w", err)
+	}
+
+	publisher, err := rabbitmq.NewPublisher(
+		conn,
+		rabbitmq.WithPublisherOptionsLogging,
+		rabbitmq.WithPublisherOptionsExchangeName(cfg.Exchange),
+		rabbitmq.WithPublisherOptionsExchangeDeclare,
+		rabbitmq.WithPublisherOptionsExchangeDurable,
+		rabbitmq.WithPublisherOptionsExchangeKind("topic"),
+		rabbitmq.WithPublisherOptionsConfirm,
+	)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("rabbitmq.NewPublisher: %w", err)
+	}
+
+	publisher.NotifyReturn(func(r rabbitmq.Return) {
+		logger.Error(ctx, "rabbitmq notify received",
+			zap.String("message_id", r.MessageId),
+			zap.String("body", string(r.Body)),
+		)
+	})
+
+	publisher.NotifyPublish(func(c rabbitmq.Confirmation) {
+		logger.Info(ctx, "rabbitmq confirmation received",
+			zap.Any("confirmation", c.Confirmation),
+		)
+
+		result := "ack"
+		if !c.Ack {
+			result = "nack"
+		}
+		metrics.BrokerConfirmed.WithLabelValues(result).Inc()
+	})
+
+	return &AMQPBroker{
+		conn:       conn,
+		publisher:  publisher,
+		url:        cfg.URL(),
+		exchange:   cfg.Exchange,
+		state:      state,
+		retryDelay: cfg.RetryDelay,
+		maxRetries: cfg.MaxRetries,
+	}, nil
+}
+
+func (b *AMQPBroker) Publish(ctx context.Context, msg Message) error {
+	opts := []func(*rabbitmq.PublishOptions){
+		rabbitmq.WithPublishOptionsContentType(msg.ContentType),
+		rabbitmq.WithPublishOptionsMessageID(msg.ID),
+		rabbitmq.WithPublishOptionsExchange(b.exchange),
+	}
+	if len(msg.Headers) > 0 {
+		opts = append(opts, rabbitmq.WithPublishOptionsHeaders(msg.Headers))
+	}
+
+	err := b.publisher.PublishWithContext(ctx, msg.Body, []string{msg.RoutingKey}, opts...)
+	observePublish(msg.RoutingKey, err)
+	if err != nil {
+		return fmt.Errorf("publising message: %w", err)
+	}
+
+	return nil
+}
+
+// Ping reports the state of the managed connection without dialing the server: it fails while the connection
+// is down and reconnecting, or after the broker is closed.
+// A connection blocked by the server is still reported as up, the publisher fails the messages meanwhile.
+func (b *AMQPBroker) Ping(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	closed := b.closed
+	b.mu.Unlock()
+	if closed {
+		return ErrBrokerClosed
+	}
+
+	if err := b.state.Err(); err != nil {
+		return fmt.Errorf("rabbitmq connection down: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe declares the queue along with its retry and dead-letter queues.
+// The messages nacked with requeue are published to the retry queue, where they expire after the retry delay
+// and are dead-lettered back to the queue. The discarded messages and the ones out of retries are dead-lettered
+// to the dead-letter queue.
+func (b *AMQPBroker) Subscribe(ctx context.Context, queue string, routingKeys []string, h Handler) error {
+	if err := b.declareRetryQueues(queue); err != nil {
+		return fmt.Errorf("declaring retry queues of %s: %w", queue, err)
+	}
+
+	consumer, err := NewQueueConsumer(ctx, b.conn, b.exchange, queue, routingKeys...)
+	if err != nil {
+		return fmt.Errorf("creating consumer of %s: %w", queue, err)
+	}
+
+	h = retry(b.maxRetries, func(ctx context.Context, d rabbitmq.Delivery) error {
+		return b.publishRetry(ctx, queue, d)
+	}, instrument(queue, h))
+
+	b.mu.Lock()
+	b.consumers = append(b.consumers, consumer)
+	b.mu.Unlock()
+
+	// the consumer blocks while running.
+	go func() {
+		if err := consumer.Run(ctx, h); err != nil {
+			logger.Error(ctx, "running consumer", zap.String("queue", queue), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// declareRetryQueues declares the retry and dead-letter queues of the queue.
+// go-rabbitmq only declares the queues it consumes, so they are declared on a connection of their own.
+// The retry delay is set by message, so changing it doesn't change the arguments of the declared queue.
+func (b *AMQPBroker) declareRetryQueues(queue string) error {
+	conn, err := amqp.Dial(b.url)
+	if err != nil {
+		return fmt.Errorf("dialing rabbitmq: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("opening channel: %w", err)
+	}
+	defer ch.Close()
+
+	_, err = ch.QueueDeclare(RetryQueue(queue), true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+	})
+	if err != nil {
+		return fmt.Errorf("declaring retry queue: %w", err)
+	}
+
+	_, err = ch.QueueDeclare(DeadLetterQueue(queue), true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("declaring dead-letter queue: %w", err)
+	}
+
+	return nil
+}
+
+// publishRetry publishes the message to the retry queue of the queue through the default exchange,
+// waiting for the confirmation so the message is only acknowledged once the retry is stored.
+func (b *AMQPBroker) publishRetry(ctx context.Context, queue string, d rabbitmq.Delivery) error {
+	confirms, err := b.publisher.PublishWithDeferredConfirmWithContext(ctx, d.Body, []string{RetryQueue(queue)},
+		rabbitmq.WithPublishOptionsExchange(""),
+		rabbitmq.WithPublishOptionsContentType(d.ContentType),
+		rabbitmq.WithPublishOptionsMessageID(d.MessageId),
+		rabbitmq.WithPublishOptionsTimestamp(d.Timestamp),
+		rabbitmq.WithPublishOptionsHeaders(rabbitmq.Table(d.Headers)),
+		rabbitmq.WithPublishOptionsExpiration(strconv.FormatInt(b.retryDelay.Milliseconds(), 10)),
+		rabbitmq.WithPublishOptionsPersistentDelivery,
+	)
+	if err != nil {
+		return fmt.Errorf("publishing to retry queue: %w", err)
+	}
+
+	for _, c := range confirms {
+		ok, err := c.WaitContext(ctx)
+		if err != nil {
+			return fmt.Errorf("waiting retry confirmation: %w", err)
+		}
+		if !ok {
+			return errors.New("retry not confirmed")
+		}
+	}
+
+	return nil
+}
+
+func (b *AMQPBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, c := range b.consumers {
+		c.C.Close()
+	}
+	b.publisher.Close()
+	b.conn.Close()
+}
@@ -0,0 +1,88 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wagslane/go-rabbitmq"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/metrics"
+)
+
+const (
+	// DriverRabbitMQ selects the broker backed by a RabbitMQ server.
+	DriverRabbitMQ = "rabbitmq"
+	// DriverMemory selects the in-process broker.
+	DriverMemory = "memory"
+)
+
+// Broker publishes messages to a topic exchange and consumes them from the queues bound to it.
+type Broker interface {
+	// Publish sends the message to the exchange with its routing key.
+	Publish(ctx context.Context, msg Message) error
+	// Subscribe binds the queue to the routing keys and starts dispatching its messages to the handler.
+	// It doesn't block, the handler runs until the broker is closed.
+	Subscribe(ctx context.Context, queue string, routingKeys []string, h Handler) error
+	// Ping checks whether the broker can accept messages.
+	Ping(ctx context.Context) error
+	// Close stops the consumers and releases the broker resources.
+	Close()
+}
+
+// Message is a message published to the exchange.
+type Message struct {
+	ID          string
+	RoutingKey  string
+	ContentType string
+	Headers     map[string]any
+	Body        []byte
+}
+
+// RunConsumers tells the consumer modules whether they must run in the process.
+type RunConsumers bool
+
+// NewBroker creates the Broker selected by the configured driver.
+func NewBroker(ctx context.Context, cfg config.RabbitMQConfig) (Broker, error) {
+	switch cfg.Driver {
+	case DriverRabbitMQ:
+		return NewAMQPBroker(ctx, cfg)
+	case DriverMemory:
+		return NewMemoryBroker(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown broker driver %q", cfg.Driver)
+	}
+}
+
+// instrument counts the messages consumed from the queue by the action returned by the handler.
+func instrument(queue string, h Handler) Handler {
+	return func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+		action := h(ctx, d)
+		metrics.BrokerConsumed.WithLabelValues(queue, actionLabel(action)).Inc()
+
+		return action
+	}
+}
+
+// observePublish counts the published message by the result of the publishing.
+func observePublish(routingKey string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+
+	metrics.BrokerPublished.WithLabelValues(routingKey, result).Inc()
+}
+
+func actionLabel(a rabbitmq.Action) string {
+	switch a {
+	case rabbitmq.Ack:
+		return "ack"
+	case rabbitmq.NackDiscard:
+		return "nack_discard"
+	case rabbitmq.NackRequeue:
+		return "nack_requeue"
+	default:
+		return "manual"
+	}
+}
@@ -2,15 +2,27 @@ package rabbitmq
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"sync"
+	"time"
 
+	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/wagslane/go-rabbitmq"
 )
 
-func NewConn(ctx context.Context, url string) (*rabbitmq.Conn, error) {
+// dialTimeout is the maximum time to open a connection to the server.
+const dialTimeout = 30 * time.Second
+
+// errNotConnected is reported by the ConnState before the first connection is opened.
+var errNotConnected = errors.New("not connected")
+
+func NewConn(ctx context.Context, url string, state *ConnState) (*rabbitmq.Conn, error) {
 	conn, err := rabbitmq.NewConn(
 		url,
 		rabbitmq.WithConnectionOptionsLogging,
+		rabbitmq.WithConnectionOptionsConfig(rabbitmq.Config{Dial: state.dial}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("rabbitmq.NewConn: %w", err)
@@ -18,3 +30,75 @@ func NewConn(ctx context.Context, url string) (*rabbitmq.Conn, error) {
 
 	return conn, nil
 }
+
+// ConnState tracks the state of the network connection of a managed connection, which doesn't expose it
+// while it is reconnecting. The connection is down from the moment it fails to read or write until
+// it is dialed again.
+type ConnState struct {
+	mu      sync.Mutex
+	current net.Conn
+	err     error
+}
+
+func NewConnState() *ConnState {
+	return &ConnState{err: errNotConnected}
+}
+
+// Err returns nil while the connection is up, or the error that took it down.
+func (s *ConnState) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func (s *ConnState) dial(network, addr string) (net.Conn, error) {
+	c, err := amqp.DefaultDial(dialTimeout)(network, addr)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.current, s.err = nil, fmt.Errorf("dialing: %w", err)
+		return nil, err
+	}
+
+	tracked := &trackedConn{Conn: c, state: s}
+	s.current, s.err = tracked, nil
+
+	return tracked, nil
+}
+
+// fail marks the connection as down, unless it was already replaced by a new one.
+func (s *ConnState) fail(c net.Conn, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current == c {
+		s.err = err
+	}
+}
+
+// trackedConn reports its read and write errors to the ConnState.
+type trackedConn struct {
+	net.Conn
+	state *ConnState
+}
+
+func (c *trackedConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err != nil {
+		c.state.fail(c, fmt.Errorf("reading: %w", err))
+	}
+
+	return n, err
+}
+
+func (c *trackedConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if err != nil {
+		c.state.fail(c, fmt.Errorf("writing: %w", err))
+	}
+
+	return n, err
+}
@@ -0,0 +1,122 @@
+package rabbitmq
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnState(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- c
+		}
+	}()
+
+	state := NewConnState()
+	assert.ErrorIs(t, state.Err(), errNotConnected)
+
+	// execute: the connection is dialed
+	first, err := state.dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+
+	// assert
+	assert.NoError(t, state.Err())
+
+	// execute: the server drops the connection
+	require.NoError(t, (<-accepted).Close())
+	_, err = first.Read(make([]byte, 1))
+	require.Error(t, err)
+
+	// assert
+	assert.ErrorContains(t, state.Err(), "reading")
+
+	// execute: the connection is dialed again
+	second, err := state.dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer second.Close()
+
+	// assert: the errors of the replaced connection are ignored
+	assert.NoError(t, state.Err())
+	require.NoError(t, first.Close())
+	_, err = first.Write([]byte{1})
+	require.Error(t, err)
+	assert.NoError(t, state.Err())
+
+	// execute: the server is unreachable
+	require.NoError(t, ln.Close())
+	_, err = state.dial("tcp", ln.Addr().String())
+	require.Error(t, err)
+
+	// assert
+	assert.ErrorContains(t, state.Err(), "dialing")
+}
+
+func TestAMQPBroker_Ping(t *testing.T) {
+	t.Parallel()
+
+	up := NewConnState()
+	up.err = nil
+
+	tests := []struct {
+		name    string
+		broker  *AMQPBroker
+		ctx     func() context.Context
+		wantErr error
+	}{
+		{
+			name:   "connection up",
+			broker: &AMQPBroker{state: up},
+			ctx:    context.Background,
+		},
+		{
+			name:    "connection down",
+			broker:  &AMQPBroker{state: NewConnState()},
+			ctx:     context.Background,
+			wantErr: errNotConnected,
+		},
+		{
+			name:    "closed broker",
+			broker:  &AMQPBroker{state: up, closed: true},
+			ctx:     context.Background,
+			wantErr: ErrBrokerClosed,
+		},
+		{
+			name:   "context done",
+			broker: &AMQPBroker{state: up},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			wantErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			err := tt.broker.Ping(tt.ctx())
+
+			// assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/wagslane/go-rabbitmq"
 	"go.uber.org/zap"
 
-	"github.com/higordasneves/e-corp/pkg/gateway/config"
 	"github.com/higordasneves/e-corp/utils/logger"
 )
 
@@ -15,17 +14,26 @@ type Consumer struct {
 	C *rabbitmq.Consumer
 }
 
-func NewConsumer(ctx context.Context, conn *rabbitmq.Conn, config config.RabbitMQConfig) (Consumer, error) {
-	consumer, err := rabbitmq.NewConsumer(
-		conn,
-		config.Queue,
-		rabbitmq.WithConsumerOptionsRoutingKey(config.Bind),
+// NewQueueConsumer creates a consumer of the durable queue bound to the routing keys of the topic exchange.
+// The discarded messages are dead-lettered to the DeadLetterQueue of the queue.
+// A queue declared before without the dead-letter arguments must be deleted, RabbitMQ refuses to change them.
+func NewQueueConsumer(ctx context.Context, conn *rabbitmq.Conn, exchange, queue string, routingKeys ...string) (Consumer, error) {
+	opts := []func(*rabbitmq.ConsumerOptions){
 		rabbitmq.WithConsumerOptionsQueueDurable,
-		rabbitmq.WithConsumerOptionsExchangeName(config.Exchange),
+		rabbitmq.WithConsumerOptionsQueueArgs(rabbitmq.Table{
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": DeadLetterQueue(queue),
+		}),
+		rabbitmq.WithConsumerOptionsExchangeName(exchange),
 		rabbitmq.WithConsumerOptionsExchangeDeclare,
 		rabbitmq.WithConsumerOptionsExchangeKind("topic"),
 		rabbitmq.WithConsumerOptionsExchangeDurable,
-	)
+	}
+	for _, key := range routingKeys {
+		opts = append(opts, rabbitmq.WithConsumerOptionsRoutingKey(key))
+	}
+
+	consumer, err := rabbitmq.NewConsumer(conn, queue, opts...)
 	if err != nil {
 		return Consumer{}, fmt.Errorf("creating consumer: %w", err)
 	}
@@ -35,14 +43,10 @@ func NewConsumer(ctx context.Context, conn *rabbitmq.Conn, config config.RabbitM
 	}, nil
 }
 
-func (c Consumer) Run(ctx context.Context) error {
+// Run starts consuming the queue, dispatching every delivery to the handler.
+func (c Consumer) Run(ctx context.Context, h Handler) error {
 	err := c.C.Run(func(d rabbitmq.Delivery) rabbitmq.Action {
-		logger.Info(ctx, "rabbitmq msg consumed",
-			zap.Any("message_id", d.MessageId),
-			zap.String("body", string(d.Body)),
-		)
-
-		return rabbitmq.Ack
+		return h(ctx, d)
 	})
 	if err != nil {
 		logger.Error(ctx, "running consumer", zap.Error(err))
@@ -4,94 +4,66 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/wagslane/go-rabbitmq"
 	"go.uber.org/fx"
-	"go.uber.org/zap"
 
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
-	"github.com/higordasneves/e-corp/utils/logger"
+	"github.com/higordasneves/e-corp/pkg/gateway/health"
 )
 
-var ModuleConn = fx.Module("rabbitmq-conn",
+// ModuleBroker provides the Broker selected by the configured driver.
+var ModuleBroker = fx.Module("rabbitmq-broker",
 	fx.Provide(
 		fx.Annotate(
-			func(ctx context.Context, lc fx.Lifecycle, cfg config.Config) (*rabbitmq.Conn, error) {
-				conn, err := NewConn(ctx, cfg.MQ.URL())
+			func(ctx context.Context, lc fx.Lifecycle, cfg config.Config) (Broker, error) {
+				broker, err := NewBroker(ctx, cfg.MQ)
 				if err != nil {
-					return nil, fmt.Errorf("creating rabbit conn: %w", err)
+					return nil, fmt.Errorf("creating broker: %w", err)
 				}
 
 				lc.Append(fx.Hook{
 					OnStop: func(ctx context.Context) error {
-						conn.Close()
+						broker.Close()
 						return nil
 					},
 				})
 
-				return conn, nil
+				return broker, nil
 			},
 		),
+		fx.Annotate(
+			func(broker Broker) health.Check {
+				return health.Check{Name: "broker", Probe: broker.Ping}
+			},
+			fx.ResultTags(health.Group),
+		),
 	),
 )
 
 var ModulePub = fx.Module("rabbitmq-pub",
 	fx.Provide(
 		fx.Annotate(
-			func(ctx context.Context, lc fx.Lifecycle, cfg config.Config, conn *rabbitmq.Conn) (Publisher, error) {
-				pub, err := NewPublisher(ctx, conn, cfg.MQ)
-				if err != nil {
-					return Publisher{}, fmt.Errorf("creating rabbit publisher: %w", err)
-				}
-
-				lc.Append(fx.Hook{
-					OnStart: func(ctx context.Context) error {
-						pub.P.NotifyReturn(func(r rabbitmq.Return) {
-							logger.Error(ctx, "rabbitmq notify received",
-								zap.String("message_id", r.MessageId),
-								zap.String("body", string(r.Body)),
-							)
-						})
-
-						pub.P.NotifyPublish(func(c rabbitmq.Confirmation) {
-							logger.Info(ctx, "rabbitmq confirmation received",
-								zap.Any("confirmation", c.Confirmation),
-							)
-						})
-						return nil
-					},
-				})
-
-				lc.Append(fx.Hook{
-					OnStop: func(ctx context.Context) error {
-						pub.P.Close()
-						return nil
-					},
-				})
-
-				return pub, nil
+			func(cfg config.Config, broker Broker, store EventStore) Publisher {
+				return NewPublisher(broker, store, cfg.MQ)
 			},
 		),
 	),
 )
 
+// ModuleSub consumes the account creation events.
 var ModuleSub = fx.Module("rabbitmq-sub",
-	fx.Provide(
-		fx.Annotate(
-			func(ctx context.Context, lc fx.Lifecycle, cfg config.Config, conn *rabbitmq.Conn) (Consumer, error) {
-				consumer, err := NewConsumer(ctx, conn, cfg.MQ)
-				if err != nil {
-					return Consumer{}, fmt.Errorf("creating rabbit consumer: %w", err)
-				}
+	fx.Invoke(
+		func(ctx context.Context, lc fx.Lifecycle, run RunConsumers, cfg config.Config, broker Broker, store InboxStore) {
+			if !run {
+				return
+			}
 
-				lc.Append(fx.Hook{
-					OnStop: func(ctx context.Context) error {
-						consumer.C.Close()
-						return nil
-					},
-				})
+			h := Chain(LogHandler, Trace, Correlate, Inbox(cfg.MQ.Queue, store))
 
-				return consumer, nil
-			},
-		),
+			lc.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					return broker.Subscribe(ctx, cfg.MQ.Queue, []string{cfg.MQ.Bind}, h)
+				},
+			})
+		},
 	),
 )
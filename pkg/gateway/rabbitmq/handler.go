@@ -0,0 +1,67 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/wagslane/go-rabbitmq"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/pkg/gateway/tracing"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Handler processes a message delivered to a Consumer.
+type Handler func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action
+
+// Middleware wraps a Handler with additional behavior.
+type Middleware func(next Handler) Handler
+
+// Chain wraps h with the middlewares. The first middleware is the outermost one.
+func Chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+
+	return h
+}
+
+// HeaderRequestID is the message header carrying the id of the request that originated the message.
+const HeaderRequestID = "x-request-id"
+
+// Correlate associates the request id of the message header with the context and adds it,
+// the message id, the routing key and the trace ids to the context logger.
+func Correlate(next Handler) Handler {
+	return func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+		fields := []zap.Field{
+			zap.String("message_id", d.MessageId),
+			zap.String("routing_key", d.RoutingKey),
+		}
+		if id, ok := d.Headers[HeaderRequestID].(string); ok && id != "" {
+			ctx = apictx.WithRequestID(ctx, id)
+			fields = append(fields, zap.String("request_id", id))
+		}
+
+		fields = append(fields, tracing.LogFields(ctx)...)
+
+		return next(logger.With(ctx, fields...), d)
+	}
+}
+
+// ReadYourWrites runs the handler with a context whose reads go to the primary database.
+// The events are consumed right after the commit that produced them, which the replicas may not have yet.
+func ReadYourWrites(next Handler) Handler {
+	return func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+		return next(dbpool.ReadYourWrites(ctx), d)
+	}
+}
+
+// LogHandler logs the consumed message and acknowledges it.
+func LogHandler(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+	logger.Info(ctx, "rabbitmq msg consumed",
+		zap.String("body", string(d.Body)),
+	)
+
+	return rabbitmq.Ack
+}
@@ -0,0 +1,70 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/wagslane/go-rabbitmq"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// InboxStore records the messages processed by each consumer.
+type InboxStore interface {
+	// MarkMessageProcessed returns false if the message was already processed by the consumer.
+	MarkMessageProcessed(ctx context.Context, consumer, messageID string) (bool, error)
+
+	BeginTX(ctx context.Context) (context.Context, error)
+	CommitTX(ctx context.Context) error
+	RollbackTX(ctx context.Context) error
+}
+
+// Inbox makes the handler idempotent for the given consumer name.
+// The message ID is recorded in the same transaction as the handler's work, which is
+// carried by the context, so the record is only committed if the handler acknowledges the message.
+// Deliveries whose ID was already recorded are acknowledged without calling the handler.
+func Inbox(consumer string, store InboxStore) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			if d.MessageId == "" {
+				logger.Error(ctx, "rabbitmq msg without id, skipping deduplication",
+					zap.String("consumer", consumer),
+				)
+				return next(ctx, d)
+			}
+
+			txCtx, err := store.BeginTX(ctx)
+			if err != nil {
+				logger.Error(ctx, "starting inbox transaction", zap.Error(err))
+				return rabbitmq.NackRequeue
+			}
+			defer store.RollbackTX(txCtx) // nolint:errcheck
+
+			first, err := store.MarkMessageProcessed(txCtx, consumer, d.MessageId)
+			if err != nil {
+				logger.Error(txCtx, "recording processed message", zap.Error(err))
+				return rabbitmq.NackRequeue
+			}
+
+			if !first {
+				logger.Info(txCtx, "rabbitmq duplicated msg skipped",
+					zap.String("consumer", consumer),
+					zap.String("message_id", d.MessageId),
+				)
+				return rabbitmq.Ack
+			}
+
+			action := next(txCtx, d)
+			if action != rabbitmq.Ack {
+				return action
+			}
+
+			if err = store.CommitTX(txCtx); err != nil {
+				logger.Error(ctx, "committing inbox transaction", zap.Error(err))
+				return rabbitmq.NackRequeue
+			}
+
+			return rabbitmq.Ack
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/wagslane/go-rabbitmq"
+)
+
+type txKey struct{}
+
+// fakeInboxStore keeps the processed messages in memory, staging them until the transaction is committed.
+type fakeInboxStore struct {
+	mu        sync.Mutex
+	processed map[string]bool
+	staged    []string
+	err       error
+	beginErr  error
+}
+
+func (s *fakeInboxStore) MarkMessageProcessed(_ context.Context, consumer, messageID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return false, s.err
+	}
+
+	key := consumer + "/" + messageID
+	if s.processed[key] {
+		return false, nil
+	}
+	s.staged = append(s.staged, key)
+
+	return true, nil
+}
+
+func (s *fakeInboxStore) BeginTX(ctx context.Context) (context.Context, error) {
+	if s.beginErr != nil {
+		return nil, s.beginErr
+	}
+
+	return context.WithValue(ctx, txKey{}, true), nil
+}
+
+func (s *fakeInboxStore) CommitTX(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range s.staged {
+		s.processed[key] = true
+	}
+	s.staged = nil
+
+	return nil
+}
+
+func (s *fakeInboxStore) RollbackTX(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.staged = nil
+
+	return nil
+}
+
+func TestInbox(t *testing.T) {
+	t.Parallel()
+
+	delivery := func(id string) rabbitmq.Delivery {
+		return rabbitmq.Delivery{Delivery: amqp.Delivery{MessageId: id}}
+	}
+
+	t.Run("duplicated deliveries are acknowledged without calling the handler", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		store := &fakeInboxStore{processed: map[string]bool{}}
+		var calls int
+		h := Inbox("consumer", store)(func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			calls++
+			assert.Equal(t, true, ctx.Value(txKey{}), "handler must run inside the inbox transaction")
+			return rabbitmq.Ack
+		})
+
+		// execute
+		assert.Equal(t, rabbitmq.Ack, h(context.Background(), delivery("1")))
+		assert.Equal(t, rabbitmq.Ack, h(context.Background(), delivery("1")))
+		assert.Equal(t, rabbitmq.Ack, h(context.Background(), delivery("2")))
+
+		// assert
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("message is not recorded when the handler fails", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		store := &fakeInboxStore{processed: map[string]bool{}}
+		action := rabbitmq.NackRequeue
+		var calls int
+		h := Inbox("consumer", store)(func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			calls++
+			return action
+		})
+
+		// execute
+		assert.Equal(t, rabbitmq.NackRequeue, h(context.Background(), delivery("1")))
+		action = rabbitmq.Ack
+		assert.Equal(t, rabbitmq.Ack, h(context.Background(), delivery("1")))
+
+		// assert
+		assert.Equal(t, 2, calls)
+		assert.True(t, store.processed["consumer/1"])
+	})
+
+	t.Run("messages without id are not deduplicated", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		store := &fakeInboxStore{processed: map[string]bool{}}
+		var calls int
+		h := Inbox("consumer", store)(func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			calls++
+			return rabbitmq.Ack
+		})
+
+		// execute
+		h(context.Background(), delivery(""))
+		h(context.Background(), delivery(""))
+
+		// assert
+		assert.Equal(t, 2, calls)
+		assert.Empty(t, store.processed)
+	})
+
+	t.Run("store failure requeues the message", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		store := &fakeInboxStore{processed: map[string]bool{}, err: errors.New("connection refused")}
+		h := Inbox("consumer", store)(func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			t.Fatal("handler must not be called")
+			return rabbitmq.Ack
+		})
+
+		// execute and assert
+		assert.Equal(t, rabbitmq.NackRequeue, h(context.Background(), delivery("1")))
+	})
+
+	t.Run("transaction failure requeues the message", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		store := &fakeInboxStore{processed: map[string]bool{}, beginErr: errors.New("connection refused")}
+		h := Inbox("consumer", store)(func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			t.Fatal("handler must not be called")
+			return rabbitmq.Ack
+		})
+
+		// execute and assert
+		assert.Equal(t, rabbitmq.NackRequeue, h(context.Background(), delivery("1")))
+		assert.Empty(t, store.processed)
+	})
+}
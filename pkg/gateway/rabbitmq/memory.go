@@ -0,0 +1,260 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/wagslane/go-rabbitmq"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+// ErrBrokerClosed is returned when publishing to a closed broker.
+var ErrBrokerClosed = errors.New("broker closed")
+
+// MemoryBroker is an in-process Broker with the routing semantics of a topic exchange.
+// As in RabbitMQ, a message is copied to every queue with a matching binding and dropped
+// if there is none. The messages nacked with requeue are delivered again after the retry delay
+// and the discarded ones are moved to the dead-letter queue, as the AMQPBroker does.
+// Nothing is persisted, so it is meant for local development and tests.
+type MemoryBroker struct {
+	mu         sync.Mutex
+	queues     map[string]*memoryQueue
+	closed     bool
+	wg         sync.WaitGroup
+	retryDelay time.Duration
+	maxRetries int
+}
+
+func NewMemoryBroker(cfg config.RabbitMQConfig) *MemoryBroker {
+	return &MemoryBroker{
+		queues:     make(map[string]*memoryQueue),
+		retryDelay: cfg.RetryDelay,
+		maxRetries: cfg.MaxRetries,
+	}
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, msg Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		observePublish(msg.RoutingKey, ErrBrokerClosed)
+		return ErrBrokerClosed
+	}
+
+	d := rabbitmq.Delivery{
+		Delivery: amqp.Delivery{
+			Headers:     amqp.Table(msg.Headers),
+			ContentType: msg.ContentType,
+			MessageId:   msg.ID,
+			Timestamp:   time.Now(),
+			RoutingKey:  msg.RoutingKey,
+			Body:        msg.Body,
+		},
+	}
+
+	for _, q := range b.queues {
+		if q.matches(msg.RoutingKey) {
+			q.push(d)
+		}
+	}
+	observePublish(msg.RoutingKey, nil)
+
+	return nil
+}
+
+// Ping fails after the broker is closed.
+func (b *MemoryBroker) Ping(_ context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	return nil
+}
+
+// Subscribe declares the queue and its dead-letter queue if they don't exist and adds the routing keys to its bindings.
+// Subscribing to the same queue more than once creates competing consumers.
+func (b *MemoryBroker) Subscribe(ctx context.Context, queue string, routingKeys []string, h Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	q := b.declare(queue)
+	q.bind(routingKeys...)
+	dead := b.declare(DeadLetterQueue(queue))
+
+	h = retry(b.maxRetries, func(_ context.Context, d rabbitmq.Delivery) error {
+		time.AfterFunc(b.retryDelay, func() { q.push(d) })
+		return nil
+	}, instrument(queue, h))
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		q.consume(ctx, h, dead)
+	}()
+
+	return nil
+}
+
+// declare returns the queue, creating it if it doesn't exist. It must be called holding the lock.
+func (b *MemoryBroker) declare(queue string) *memoryQueue {
+	q, ok := b.queues[queue]
+	if !ok {
+		q = newMemoryQueue()
+		b.queues[queue] = q
+	}
+
+	return q
+}
+
+// Close stops the consumers, waiting for the messages being handled. The pending messages are dropped.
+func (b *MemoryBroker) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, q := range b.queues {
+		q.close()
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+type memoryQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	bindings []string
+	msgs     []rabbitmq.Delivery
+	closed   bool
+}
+
+func newMemoryQueue() *memoryQueue {
+	q := &memoryQueue{}
+	q.cond = sync.NewCond(&q.mu)
+
+	return q
+}
+
+func (q *memoryQueue) bind(routingKeys ...string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, key := range routingKeys {
+		if !slices.Contains(q.bindings, key) {
+			q.bindings = append(q.bindings, key)
+		}
+	}
+}
+
+func (q *memoryQueue) matches(routingKey string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, pattern := range q.bindings {
+		if MatchTopic(pattern, routingKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (q *memoryQueue) push(d rabbitmq.Delivery) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.msgs = append(q.msgs, d)
+	q.cond.Signal()
+}
+
+// pop blocks until there is a message or the queue is closed.
+func (q *memoryQueue) pop() (rabbitmq.Delivery, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.msgs) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return rabbitmq.Delivery{}, false
+	}
+
+	d := q.msgs[0]
+	q.msgs = q.msgs[1:]
+
+	return d, true
+}
+
+// consume dispatches the messages to the handler, moving the discarded ones to the dead-letter queue.
+// The messages nacked with requeue are pushed back as they are, the handler delays them.
+func (q *memoryQueue) consume(ctx context.Context, h Handler, dead *memoryQueue) {
+	for {
+		d, ok := q.pop()
+		if !ok {
+			return
+		}
+
+		switch h(ctx, d) {
+		case rabbitmq.NackDiscard:
+			dead.push(d)
+		case rabbitmq.NackRequeue:
+			d.Redelivered = true
+			q.push(d)
+		}
+	}
+}
+
+func (q *memoryQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.msgs = nil
+	q.cond.Broadcast()
+}
+
+// MatchTopic reports whether the routing key matches the binding pattern of a topic exchange.
+// Both are lists of words delimited by dots, where in the pattern "*" substitutes exactly
+// one word and "#" substitutes zero or more words.
+func MatchTopic(pattern, routingKey string) bool {
+	return matchWords(strings.Split(pattern, "."), strings.Split(routingKey, "."))
+}
+
+func matchWords(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(key); i++ {
+			if matchWords(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(key) > 0 && matchWords(pattern[1:], key[1:])
+	default:
+		return len(key) > 0 && pattern[0] == key[0] && matchWords(pattern[1:], key[1:])
+	}
+}
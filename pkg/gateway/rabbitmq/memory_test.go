@@ -0,0 +1,208 @@
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagslane/go-rabbitmq"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+func TestMatchTopic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern    string
+		routingKey string
+		want       bool
+	}{
+		{pattern: "ecorp.accountCreation", routingKey: "ecorp.accountCreation", want: true},
+		{pattern: "ecorp.accountCreation", routingKey: "ecorp.transferCreation", want: false},
+		{pattern: "ecorp.*", routingKey: "ecorp.accountCreation", want: true},
+		{pattern: "ecorp.*", routingKey: "ecorp", want: false},
+		{pattern: "ecorp.*", routingKey: "ecorp.account.creation", want: false},
+		{pattern: "*.accountCreation", routingKey: "ecorp.accountCreation", want: true},
+		{pattern: "ecorp.#", routingKey: "ecorp", want: true},
+		{pattern: "ecorp.#", routingKey: "ecorp.account.creation", want: true},
+		{pattern: "ecorp.#", routingKey: "other.account", want: false},
+		{pattern: "#", routingKey: "ecorp.account.creation", want: true},
+		{pattern: "#.creation", routingKey: "ecorp.account.creation", want: true},
+		{pattern: "ecorp.#.creation", routingKey: "ecorp.creation", want: true},
+		{pattern: "ecorp.#.creation", routingKey: "ecorp.account.deletion", want: false},
+		{pattern: "*.#.*", routingKey: "ecorp", want: false},
+		{pattern: "*.#.*", routingKey: "ecorp.account", want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.pattern+" "+tt.routingKey, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, MatchTopic(tt.pattern, tt.routingKey))
+		})
+	}
+}
+
+func TestMemoryBroker(t *testing.T) {
+	t.Parallel()
+
+	// collect returns a handler sending the deliveries to the channel.
+	collect := func(ch chan<- rabbitmq.Delivery) Handler {
+		return func(_ context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			ch <- d
+			return rabbitmq.Ack
+		}
+	}
+
+	receive := func(t *testing.T, ch <-chan rabbitmq.Delivery) rabbitmq.Delivery {
+		t.Helper()
+
+		select {
+		case d := <-ch:
+			return d
+		case <-time.After(time.Second):
+			require.FailNow(t, "message not delivered")
+			return rabbitmq.Delivery{}
+		}
+	}
+
+	t.Run("messages are copied to every queue with a matching binding", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		ctx := context.Background()
+		b := NewMemoryBroker(config.RabbitMQConfig{})
+		t.Cleanup(b.Close)
+
+		accounts, all, transfers := make(chan rabbitmq.Delivery, 1), make(chan rabbitmq.Delivery, 2), make(chan rabbitmq.Delivery, 1)
+		require.NoError(t, b.Subscribe(ctx, "accounts", []string{"ecorp.accountCreation"}, collect(accounts)))
+		require.NoError(t, b.Subscribe(ctx, "all", []string{"ecorp.#"}, collect(all)))
+		require.NoError(t, b.Subscribe(ctx, "transfers", []string{"ecorp.transferCreation"}, collect(transfers)))
+
+		// execute
+		err := b.Publish(ctx, Message{
+			ID:          "msg-1",
+			RoutingKey:  "ecorp.accountCreation",
+			ContentType: "application/json",
+			Headers:     map[string]any{"x-request-id": "req-1"},
+			Body:        []byte(`{}`),
+		})
+		require.NoError(t, err)
+
+		// assert
+		d := receive(t, accounts)
+		assert.Equal(t, "msg-1", d.MessageId)
+		assert.Equal(t, "ecorp.accountCreation", d.RoutingKey)
+		assert.Equal(t, "application/json", d.ContentType)
+		assert.Equal(t, "req-1", d.Headers["x-request-id"])
+		assert.Equal(t, []byte(`{}`), d.Body)
+
+		assert.Equal(t, "msg-1", receive(t, all).MessageId)
+		assert.Empty(t, transfers)
+	})
+
+	t.Run("messages nacked with requeue are delivered again after the retry delay", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		ctx := context.Background()
+		b := NewMemoryBroker(config.RabbitMQConfig{RetryDelay: 50 * time.Millisecond, MaxRetries: 3})
+		t.Cleanup(b.Close)
+
+		ch := make(chan rabbitmq.Delivery, 2)
+		err := b.Subscribe(ctx, "queue", []string{"key.*"}, func(_ context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			ch <- d
+			if Retries(d) == 0 {
+				return rabbitmq.NackRequeue
+			}
+			return rabbitmq.Ack
+		})
+		require.NoError(t, err)
+
+		// execute
+		require.NoError(t, b.Publish(ctx, Message{ID: "msg-1", RoutingKey: "key.a"}))
+
+		// assert
+		first := receive(t, ch)
+		assert.Equal(t, 0, Retries(first))
+		retried := receive(t, ch)
+		assert.Equal(t, 1, Retries(retried))
+		assert.Equal(t, "msg-1", retried.MessageId)
+		assert.Equal(t, "key.a", retried.RoutingKey)
+		assert.GreaterOrEqual(t, time.Since(first.Timestamp), 50*time.Millisecond)
+	})
+
+	t.Run("messages out of retries are moved to the dead-letter queue", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		ctx := context.Background()
+		b := NewMemoryBroker(config.RabbitMQConfig{RetryDelay: time.Millisecond, MaxRetries: 2})
+		t.Cleanup(b.Close)
+
+		ch := make(chan rabbitmq.Delivery, 3)
+		err := b.Subscribe(ctx, "queue", []string{"key"}, func(_ context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			ch <- d
+			return rabbitmq.NackRequeue
+		})
+		require.NoError(t, err)
+
+		dead := make(chan rabbitmq.Delivery, 1)
+		require.NoError(t, b.Subscribe(ctx, DeadLetterQueue("queue"), nil, collect(dead)))
+
+		// execute
+		require.NoError(t, b.Publish(ctx, Message{ID: "msg-1", RoutingKey: "key"}))
+
+		// assert: delivered once and retried twice
+		for i := range 3 {
+			assert.Equal(t, i, Retries(receive(t, ch)))
+		}
+		d := receive(t, dead)
+		assert.Equal(t, "msg-1", d.MessageId)
+		assert.Equal(t, 2, Retries(d))
+		assert.Empty(t, ch)
+	})
+
+	t.Run("discarded messages are moved to the dead-letter queue", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		ctx := context.Background()
+		b := NewMemoryBroker(config.RabbitMQConfig{MaxRetries: 2})
+		t.Cleanup(b.Close)
+
+		err := b.Subscribe(ctx, "queue", []string{"key"}, func(_ context.Context, _ rabbitmq.Delivery) rabbitmq.Action {
+			return rabbitmq.NackDiscard
+		})
+		require.NoError(t, err)
+
+		dead := make(chan rabbitmq.Delivery, 1)
+		require.NoError(t, b.Subscribe(ctx, DeadLetterQueue("queue"), nil, collect(dead)))
+
+		// execute
+		require.NoError(t, b.Publish(ctx, Message{ID: "msg-1", RoutingKey: "key"}))
+
+		// assert
+		d := receive(t, dead)
+		assert.Equal(t, "msg-1", d.MessageId)
+		assert.Equal(t, 0, Retries(d))
+	})
+
+	t.Run("publishing to a closed broker fails", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		b := NewMemoryBroker(config.RabbitMQConfig{})
+		b.Close()
+
+		// execute
+		err := b.Publish(context.Background(), Message{RoutingKey: "key"})
+
+		// assert
+		assert.ErrorIs(t, err, ErrBrokerClosed)
+	})
+}
@@ -6,58 +6,104 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/wagslane/go-rabbitmq"
+	"github.com/gofrs/uuid/v5"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/higordasneves/e-corp/pkg/domain/entities"
 	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/utils/apictx"
 )
 
-type Publisher struct {
-	P               *rabbitmq.Publisher
-	exchange        string
-	accCreationBind string
+// EventStore records the published events, so they can be replayed.
+type EventStore interface {
+	AppendEvent(ctx context.Context, e entities.Event) error
 }
 
-func NewPublisher(ctx context.Context, conn *rabbitmq.Conn, config config.RabbitMQConfig) (Publisher, error) {
-	publisher, err := rabbitmq.NewPublisher(
-		conn,
-		rabbitmq.WithPublisherOptionsLogging,
-		rabbitmq.WithPublisherOptionsExchangeName(config.Exchange),
-		rabbitmq.WithPublisherOptionsExchangeDeclare,
-		rabbitmq.WithPublisherOptionsExchangeDurable,
-		rabbitmq.WithPublisherOptionsExchangeKind("topic"),
-		rabbitmq.WithPublisherOptionsConfirm,
-	)
-	if err != nil {
-		return Publisher{}, fmt.Errorf("rabbitmq.NewPublisher: %w", err)
-	}
+type Publisher struct {
+	B                    Broker
+	S                    EventStore
+	accCreationBind      string
+	transferCreationBind string
+}
 
+func NewPublisher(b Broker, s EventStore, config config.RabbitMQConfig) Publisher {
 	return Publisher{
-		P:               publisher,
-		exchange:        config.Exchange,
-		accCreationBind: config.Bind,
-	}, nil
+		B:                    b,
+		S:                    s,
+		accCreationBind:      config.Bind,
+		transferCreationBind: config.TransferBind,
+	}
 }
 
-func (p Publisher) publish(ctx context.Context, body any, routingKey string) error {
+// publish appends the event to the event store before publishing it, so the event store
+// holds every event that may have reached the consumers.
+func (p Publisher) publish(ctx context.Context, aggregateType entities.EventAggregateType, aggregateID uuid.UUID, body any, routingKey string) error {
 	b, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("marshiling msg: %w", err)
 	}
 
-	err = p.P.PublishWithContext(ctx,
-		b,
-		[]string{routingKey},
-		rabbitmq.WithPublishOptionsContentType("application/json"),
-		rabbitmq.WithPublishOptionsExchange(p.exchange),
-	)
+	event := entities.Event{
+		ID:            uuid.Must(uuid.NewV7()),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		RoutingKey:    routingKey,
+		Payload:       b,
+		CreatedAt:     time.Now(),
+	}
+
+	err = p.S.AppendEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf("appending event: %w", err)
+	}
+
+	msg := Message{
+		ID:          event.ID.String(),
+		RoutingKey:  routingKey,
+		ContentType: "application/json",
+		Body:        b,
+	}
+	if id := apictx.RequestID(ctx); id != "" {
+		msg.Headers = map[string]any{HeaderRequestID: id}
+	}
+
+	ctx, span := startPublishSpan(ctx, &msg)
+	defer span.End()
+
+	err = p.B.Publish(ctx, msg)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("publising message: %w", err)
 	}
 
 	return nil
 }
 
+// RepublishEvent publishes a recorded event again to the routing key, keeping its message ID.
+// The original routing key is sent in the x-replayed-from header.
+func (p Publisher) RepublishEvent(ctx context.Context, event entities.Event, routingKey string) error {
+	msg := Message{
+		ID:          event.ID.String(),
+		RoutingKey:  routingKey,
+		ContentType: "application/json",
+		Headers:     map[string]any{"x-replayed-from": event.RoutingKey},
+		Body:        event.Payload,
+	}
+
+	ctx, span := startPublishSpan(ctx, &msg)
+	defer span.End()
+
+	err := p.B.Publish(ctx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("republishing event %s: %w", event.ID, err)
+	}
+
+	return nil
+}
+
 func (p Publisher) NotifyAccountCreation(ctx context.Context, account entities.Account) error {
 	msg := struct {
 		AccountID string    `json:"account_id"`
@@ -67,10 +113,19 @@ func (p Publisher) NotifyAccountCreation(ctx context.Context, account entities.A
 		CreatedAt: account.CreatedAt,
 	}
 
-	err := p.publish(ctx, msg, p.accCreationBind)
+	err := p.publish(ctx, entities.EventAggregateAccount, account.ID, msg, p.accCreationBind)
 	if err != nil {
 		return fmt.Errorf("notifying account creation: %w", err)
 	}
 
 	return nil
 }
+
+func (p Publisher) NotifyTransferCreation(ctx context.Context, transfer entities.Transfer) error {
+	err := p.publish(ctx, entities.EventAggregateTransfer, transfer.ID, transfer, p.transferCreationBind)
+	if err != nil {
+		return fmt.Errorf("notifying transfer creation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagslane/go-rabbitmq"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/utils/apictx"
+)
+
+type fakeEventStore struct {
+	events []entities.Event
+	err    error
+}
+
+func (s *fakeEventStore) AppendEvent(_ context.Context, e entities.Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, e)
+
+	return nil
+}
+
+func TestPublisher(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.RabbitMQConfig{Bind: "ecorp.accountCreation", TransferBind: "ecorp.transferCreation"}
+	account := entities.Account{ID: uuid.Must(uuid.NewV7()), CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("published events are appended to the event store and can be replayed", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		ctx := context.Background()
+		b := NewMemoryBroker(config.RabbitMQConfig{})
+		t.Cleanup(b.Close)
+
+		ch := make(chan rabbitmq.Delivery, 2)
+		collect := func(_ context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			ch <- d
+			return rabbitmq.Ack
+		}
+		require.NoError(t, b.Subscribe(ctx, "accounts", []string{cfg.Bind}, collect))
+		require.NoError(t, b.Subscribe(ctx, "projection", []string{"ecorp.replay.projection"}, collect))
+
+		store := &fakeEventStore{}
+		p := NewPublisher(b, store, cfg)
+
+		// execute
+		require.NoError(t, p.NotifyAccountCreation(ctx, account))
+
+		// assert
+		require.Len(t, store.events, 1)
+		event := store.events[0]
+		assert.Equal(t, entities.EventAggregateAccount, event.AggregateType)
+		assert.Equal(t, account.ID, event.AggregateID)
+		assert.Equal(t, cfg.Bind, event.RoutingKey)
+		assert.JSONEq(t, `{"account_id":"`+account.ID.String()+`","created_at":"2024-01-01T00:00:00Z"}`, string(event.Payload))
+
+		d := <-ch
+		assert.Equal(t, event.ID.String(), d.MessageId)
+		assert.Equal(t, event.Payload, d.Body)
+
+		// execute: replay
+		require.NoError(t, p.RepublishEvent(ctx, event, "ecorp.replay.projection"))
+
+		// assert
+		d = <-ch
+		assert.Equal(t, event.ID.String(), d.MessageId)
+		assert.Equal(t, "ecorp.replay.projection", d.RoutingKey)
+		assert.Equal(t, cfg.Bind, d.Headers["x-replayed-from"])
+		assert.Len(t, store.events, 1)
+	})
+
+	t.Run("events not appended to the event store are not published", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		ctx := context.Background()
+		b := NewMemoryBroker(config.RabbitMQConfig{})
+		t.Cleanup(b.Close)
+
+		published := make(chan rabbitmq.Delivery, 1)
+		require.NoError(t, b.Subscribe(ctx, "accounts", []string{"#"}, func(_ context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+			published <- d
+			return rabbitmq.Ack
+		}))
+
+		p := NewPublisher(b, &fakeEventStore{err: errors.New("connection refused")}, cfg)
+
+		// execute
+		err := p.NotifyAccountCreation(ctx, account)
+
+		// assert
+		assert.Error(t, err)
+		b.Close()
+		assert.Empty(t, published)
+	})
+	t.Run("the request id of the context is propagated to the consumers", func(t *testing.T) {
+		t.Parallel()
+
+		// setup
+		ctx := apictx.WithRequestID(context.Background(), "req-123")
+		b := NewMemoryBroker(config.RabbitMQConfig{})
+		t.Cleanup(b.Close)
+
+		ids := make(chan string, 1)
+		h := Chain(func(ctx context.Context, _ rabbitmq.Delivery) rabbitmq.Action {
+			ids <- apictx.RequestID(ctx)
+			return rabbitmq.Ack
+		}, Correlate)
+		require.NoError(t, b.Subscribe(context.Background(), "accounts", []string{cfg.Bind}, h))
+
+		p := NewPublisher(b, &fakeEventStore{}, cfg)
+
+		// execute
+		require.NoError(t, p.NotifyAccountCreation(ctx, account))
+
+		// assert
+		assert.Equal(t, "req-123", <-ids)
+	})
+}
@@ -0,0 +1,84 @@
+package rabbitmq
+
+import (
+	"context"
+	"maps"
+
+	"github.com/wagslane/go-rabbitmq"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+const (
+	// HeaderRetries is the message header counting the times a message nacked with requeue was delivered again.
+	HeaderRetries = "x-retries"
+	// headerRetryRoutingKey keeps the routing key of a message delivered again, which is routed to
+	// its queue by the queue name.
+	headerRetryRoutingKey = "x-retry-routing-key"
+)
+
+// RetryQueue is the queue holding the messages of the queue nacked with requeue until they are delivered again.
+func RetryQueue(queue string) string {
+	return queue + ".retry"
+}
+
+// DeadLetterQueue is the queue keeping the messages of the queue that were discarded or ran out of retries.
+func DeadLetterQueue(queue string) string {
+	return queue + ".dead"
+}
+
+// Retries returns the number of times the message was delivered again after a nack with requeue.
+func Retries(d rabbitmq.Delivery) int {
+	switch n := d.Headers[HeaderRetries].(type) {
+	case int:
+		return n
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+// retry delays the redelivery of the messages nacked with requeue by the handler, which RabbitMQ would deliver
+// again right away. The message is passed to schedule with its retries incremented and acknowledged,
+// and discarded to the dead-letter queue after maxRetries.
+// The message is nacked with requeue if it can't be scheduled, e.g. while the connection is down.
+func retry(maxRetries int, schedule func(ctx context.Context, d rabbitmq.Delivery) error, h Handler) Handler {
+	return func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+		if key, ok := d.Headers[headerRetryRoutingKey].(string); ok {
+			d.RoutingKey = key
+		}
+
+		action := h(ctx, d)
+		if action != rabbitmq.NackRequeue {
+			return action
+		}
+
+		retries := Retries(d)
+		if retries >= maxRetries {
+			logger.Error(ctx, "rabbitmq msg out of retries, dead-lettering",
+				zap.String("message_id", d.MessageId),
+				zap.Int("retries", retries),
+			)
+			return rabbitmq.NackDiscard
+		}
+
+		headers := maps.Clone(d.Headers)
+		if headers == nil {
+			headers = make(map[string]any)
+		}
+		headers[HeaderRetries] = retries + 1
+		headers[headerRetryRoutingKey] = d.RoutingKey
+		d.Headers = headers
+
+		if err := schedule(ctx, d); err != nil {
+			logger.Error(ctx, "scheduling rabbitmq msg retry", zap.Error(err), zap.String("message_id", d.MessageId))
+			return rabbitmq.NackRequeue
+		}
+
+		return rabbitmq.Ack
+	}
+}
@@ -0,0 +1,83 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/wagslane/go-rabbitmq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the messages. It uses the global tracer provider.
+var tracer = otel.Tracer("github.com/higordasneves/e-corp/pkg/gateway/rabbitmq")
+
+// headerCarrier adapts the message headers to a propagation.TextMapCarrier,
+// so the W3C trace context travels with the message.
+type headerCarrier map[string]any
+
+func (c headerCarrier) Get(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// startPublishSpan starts a producer span for the message and injects its trace context in the headers.
+func startPublishSpan(ctx context.Context, msg *Message) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, msg.RoutingKey+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitmq,
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingRabbitmqDestinationRoutingKey(msg.RoutingKey),
+			semconv.MessagingMessageID(msg.ID),
+		),
+	)
+
+	if msg.Headers == nil {
+		msg.Headers = make(map[string]any)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(msg.Headers))
+
+	return ctx, span
+}
+
+// Trace continues the trace of the message headers with a consumer span around the handler.
+// Messages that are not acknowledged mark the span as failed.
+func Trace(next Handler) Handler {
+	return func(ctx context.Context, d rabbitmq.Delivery) rabbitmq.Action {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(d.Headers))
+		ctx, span := tracer.Start(ctx, d.RoutingKey+" process",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystemRabbitmq,
+				semconv.MessagingOperationTypeDeliver,
+				semconv.MessagingRabbitmqDestinationRoutingKey(d.RoutingKey),
+				semconv.MessagingMessageID(d.MessageId),
+			),
+		)
+		defer span.End()
+
+		action := next(ctx, d)
+		span.SetAttributes(attribute.String("messaging.rabbitmq.action", actionLabel(action)))
+		if action == rabbitmq.NackDiscard || action == rabbitmq.NackRequeue {
+			span.SetStatus(codes.Error, "message not acknowledged")
+		}
+
+		return action
+	}
+}
@@ -0,0 +1,68 @@
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagslane/go-rabbitmq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+// TestTrace is not parallel because it replaces the global tracer provider.
+func TestTrace(t *testing.T) {
+	// setup
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevTP, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	cfg := config.RabbitMQConfig{Bind: "ecorp.accountCreation"}
+	b := NewMemoryBroker(config.RabbitMQConfig{})
+	t.Cleanup(b.Close)
+
+	traces := make(chan trace.SpanContext, 1)
+	h := Chain(func(ctx context.Context, _ rabbitmq.Delivery) rabbitmq.Action {
+		traces <- trace.SpanContextFromContext(ctx)
+		return rabbitmq.NackDiscard
+	}, Trace)
+	require.NoError(t, b.Subscribe(context.Background(), "accounts", []string{cfg.Bind}, h))
+
+	p := NewPublisher(b, &fakeEventStore{}, cfg)
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+
+	// execute
+	err := p.NotifyAccountCreation(ctx, entities.Account{ID: uuid.Must(uuid.NewV7()), CreatedAt: time.Now()})
+	parent.End()
+
+	// assert
+	require.NoError(t, err)
+	consumed := <-traces
+	assert.Equal(t, parent.SpanContext().TraceID(), consumed.TraceID())
+
+	require.Eventually(t, func() bool { return len(recorder.Ended()) == 3 }, time.Second, 10*time.Millisecond)
+	kinds := make(map[trace.SpanKind]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		kinds[s.SpanKind()] = s
+	}
+	require.Contains(t, kinds, trace.SpanKindProducer)
+	require.Contains(t, kinds, trace.SpanKindConsumer)
+	assert.Equal(t, cfg.Bind+" publish", kinds[trace.SpanKindProducer].Name())
+	assert.Equal(t, kinds[trace.SpanKindProducer].SpanContext().SpanID(), kinds[trace.SpanKindConsumer].Parent().SpanID())
+	assert.Equal(t, "Error", kinds[trace.SpanKindConsumer].Status().Code.String())
+}
@@ -0,0 +1,478 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+)
+
+func testCreateAccount(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  "33344455566",
+		Secret:    "password",
+		Balance:   10,
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+
+	// execute
+	err := r.CreateAccount(ctx, acc)
+
+	// assert: at the first version
+	require.NoError(t, err)
+	got, err := r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	expected := acc
+	expected.Version = 1
+	assert.Equal(t, expected, got)
+
+	// execute: same document
+	other := acc
+	other.ID = uuid.Must(uuid.NewV7())
+	err = r.CreateAccount(ctx, other)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// execute: same id
+	other = acc
+	other.Document = "33344455567"
+	err = r.CreateAccount(ctx, other)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// execute: negative balance
+	other = acc
+	other.ID = uuid.Must(uuid.NewV7())
+	other.Document = "33344455568"
+	other.Balance = -1
+	err = r.CreateAccount(ctx, other)
+
+	// assert
+	assert.Error(t, err)
+	_, err = r.GetAccount(ctx, other.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testCreateAccounts(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	existing := createAccount(t, r, "33344455566", 0)
+	accounts := make([]entities.Account, 0, 2)
+	for _, document := range []vos.Document{"33344455567", "33344455568"} {
+		accounts = append(accounts, entities.Account{
+			ID:        uuid.Must(uuid.NewV7()),
+			Name:      "Elliot",
+			Document:  document,
+			Secret:    "password",
+			CreatedAt: time.Now().Truncate(time.Second),
+		})
+	}
+
+	// execute: one of the documents exists
+	conflicting := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Darlene",
+		Document:  existing.Document,
+		Secret:    "password",
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+	err := r.CreateAccounts(ctx, append(slices.Clone(accounts), conflicting))
+
+	// assert: none was created
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	_, err = r.GetAccount(ctx, accounts[0].ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute
+	err = r.CreateAccounts(ctx, accounts)
+
+	// assert: at the first version
+	require.NoError(t, err)
+	for _, acc := range accounts {
+		got, err := r.GetAccount(ctx, acc.ID)
+		require.NoError(t, err)
+		acc.Version = 1
+		assert.Equal(t, acc, got)
+	}
+
+	// execute
+	got, err := r.ListExistingDocuments(ctx, []vos.Document{existing.Document, accounts[1].Document, "33344455569"})
+
+	// assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []vos.Document{existing.Document, accounts[1].Document}, got)
+}
+
+func testGetAccount(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 15)
+	unknownID := uuid.Must(uuid.NewV7())
+
+	// execute
+	got, err := r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, acc, got)
+
+	got, err = r.GetAccountByDocument(ctx, acc.Document)
+	require.NoError(t, err)
+	assert.Equal(t, acc, got)
+
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+
+	// execute: unknown account
+	_, err = r.GetAccount(ctx, unknownID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = r.GetAccountByDocument(ctx, "33344455567")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = r.GetBalance(ctx, unknownID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testListAccounts(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	accounts := []entities.Account{
+		createAccount(t, r, "33344455566", 0),
+		createAccount(t, r, "33344455567", 0),
+		createAccount(t, r, "33344455568", 0),
+	}
+	createAccount(t, r, "33344455569", 0)
+	ids := []uuid.UUID{accounts[0].ID, accounts[1].ID, accounts[2].ID, uuid.Must(uuid.NewV7())}
+
+	// execute: first page
+	got, err := r.ListAccounts(ctx, usecase.ListAccountsInput{IDs: ids, PageSize: 2})
+	require.NoError(t, err)
+
+	// assert: in descending order
+	assert.Equal(t, []entities.Account{accounts[2], accounts[1]}, got.Accounts)
+	require.NotNil(t, got.NextPage)
+	assert.Equal(t, usecase.ListAccountsInput{IDs: ids, LastFetchedID: accounts[1].ID, PageSize: 2}, *got.NextPage)
+
+	// execute: last page
+	got, err = r.ListAccounts(ctx, *got.NextPage)
+	require.NoError(t, err)
+
+	// assert
+	assert.Equal(t, []entities.Account{accounts[0]}, got.Accounts)
+	assert.Nil(t, got.NextPage)
+
+	// execute: no account
+	got, err = r.ListAccounts(ctx, usecase.ListAccountsInput{IDs: []uuid.UUID{uuid.Must(uuid.NewV7())}, PageSize: 2})
+	require.NoError(t, err)
+
+	// assert
+	assert.Empty(t, got.Accounts)
+	assert.Nil(t, got.NextPage)
+}
+
+func testUpdateBalance(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	// execute
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, 5))
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, -15))
+
+	// assert
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+
+	// execute: negative balance
+	err = r.UpdateBalance(ctx, acc.ID, -1)
+
+	// assert: the balance is kept
+	assert.Error(t, err)
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+
+	// execute: unknown account
+	err = r.UpdateBalance(ctx, uuid.Must(uuid.NewV7()), 1)
+
+	// assert: nothing is updated
+	assert.NoError(t, err)
+
+	// assert: the balance is not part of the version
+	got, err := r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, acc.Version, got.Version)
+}
+
+func testBalanceShards(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	// execute
+	require.NoError(t, r.SetBalanceShards(ctx, acc.ID, 4))
+	sharded, err := r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	for range 8 {
+		require.NoError(t, r.UpdateBalance(ctx, acc.ID, 5))
+	}
+
+	// assert: the balance includes the shards, which are credited without changing the account.
+	// The version is kept, so the representations of the account tell the balances apart by the balance itself.
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 50, balance)
+
+	got, err := r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 50, got.Balance)
+	assert.Equal(t, sharded.Version, got.Version)
+	assert.NotEqual(t, sharded.Balance, got.Balance)
+
+	got, err = r.GetAccountByDocument(ctx, acc.Document)
+	require.NoError(t, err)
+	assert.Equal(t, 50, got.Balance)
+
+	// execute: debit more than the balance held by the account
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, -45))
+
+	// assert: the shards were swept
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, balance)
+
+	// execute: negative balance
+	err = r.UpdateBalance(ctx, acc.ID, -6)
+
+	// assert: the balance is kept
+	assert.Error(t, err)
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, balance)
+
+	// execute: turn the sharding off
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, 3))
+	require.NoError(t, r.SetBalanceShards(ctx, acc.ID, 0))
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, 1))
+
+	// assert: the balance of the shards moved to the account
+	got, err = r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 9, got.Balance)
+	assert.Equal(t, acc.Version, got.Version)
+
+	// execute: unknown account
+	err = r.SetBalanceShards(ctx, uuid.Must(uuid.NewV7()), 4)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute: negative number of shards
+	err = r.SetBalanceShards(ctx, acc.ID, -1)
+
+	// assert
+	assert.Error(t, err)
+}
+
+func testBalanceShardsConcurrently(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+	require.NoError(t, r.SetBalanceShards(ctx, acc.ID, 4))
+
+	// execute: credits and debits
+	var wg sync.WaitGroup
+	errs := make([]error, 30)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			amount := 2
+			if i%3 == 0 {
+				amount = -1
+			}
+			errs[i] = r.UpdateBalance(ctx, acc.ID, amount)
+		}()
+	}
+	wg.Wait()
+
+	// assert: no update is lost, the debits that found no balance failed
+	expected := 0
+	for i, err := range errs {
+		switch {
+		case i%3 != 0:
+			require.NoError(t, err)
+			expected += 2
+		case err == nil:
+			expected--
+		}
+	}
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, balance)
+}
+
+func testUpdateAccount(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	// execute
+	update := acc
+	update.Name = "Elliot Alderson"
+	update.Balance = 1000
+	got, err := r.UpdateAccount(ctx, update, acc.Version)
+
+	// assert: only the name is updated
+	require.NoError(t, err)
+	expected := acc
+	expected.Name = "Elliot Alderson"
+	expected.Version = acc.Version + 1
+	assert.Equal(t, expected, got)
+
+	stored, err := r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, stored)
+
+	// execute: the version was read before the last update
+	update.Name = "Mr. Robot"
+	_, err = r.UpdateAccount(ctx, update, acc.Version)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	stored, err = r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, expected, stored)
+
+	// execute: the balance changed since the version was read
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, 5))
+	update.Name = "Elliot"
+	got, err = r.UpdateAccount(ctx, update, expected.Version)
+
+	// assert
+	require.NoError(t, err)
+	expected.Name = "Elliot"
+	expected.Balance += 5
+	expected.Version++
+	assert.Equal(t, expected, got)
+
+	// execute: the name doesn't change
+	got, err = r.UpdateAccount(ctx, update, expected.Version)
+
+	// assert: the version is kept
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+
+	// execute: any version
+	update.Name = "Mr. Robot"
+	got, err = r.UpdateAccount(ctx, update, 0)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "Mr. Robot", got.Name)
+	assert.Greater(t, got.Version, expected.Version)
+
+	// execute: unknown account
+	update.ID = uuid.Must(uuid.NewV7())
+	_, err = r.UpdateAccount(ctx, update, 1)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute: unknown account at any version
+	_, err = r.UpdateAccount(ctx, update, 0)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testUpdateAccountConcurrently(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+
+	// execute: updates from the same version
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			update := acc
+			update.Name = fmt.Sprintf("Elliot %d", i)
+			_, errs[i] = r.UpdateAccount(ctx, update, acc.Version)
+		}()
+	}
+	wg.Wait()
+
+	// assert: only one succeeds
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	got, err := r.GetAccount(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, acc.Version+1, got.Version)
+}
+
+func testUpdateBalanceConcurrently(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+
+	// execute
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = r.UpdateBalance(ctx, acc.ID, 1)
+		}()
+	}
+	wg.Wait()
+
+	// assert: no update is lost
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, len(errs), balance)
+}
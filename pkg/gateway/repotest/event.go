@@ -0,0 +1,200 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+func testAppendEvent(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	e := entities.Event{
+		ID:            uuid.Must(uuid.NewV7()),
+		AggregateType: entities.EventAggregateAccount,
+		AggregateID:   uuid.Must(uuid.NewV4()),
+		RoutingKey:    "ecorp.accountCreation",
+		Payload:       []byte(`{"id": "1"}`),
+		CreatedAt:     time.Now().Truncate(time.Second),
+	}
+
+	// execute
+	err := r.AppendEvent(ctx, e)
+
+	// assert: payloads are compared as JSON, postgres normalizes them
+	require.NoError(t, err)
+	got, err := r.ListEvents(ctx, entities.EventFilter{}, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.JSONEq(t, string(e.Payload), string(got[0].Payload))
+	assert.Positive(t, got[0].Sequence)
+	got[0].Payload, got[0].Sequence = e.Payload, 0
+	assert.Equal(t, e, got[0])
+
+	// execute: same id
+	err = r.AppendEvent(ctx, e)
+	assert.Error(t, err)
+
+	// execute: invalid payload
+	e.ID = uuid.Must(uuid.NewV7())
+	e.Payload = []byte(`{`)
+	err = r.AppendEvent(ctx, e)
+	assert.Error(t, err)
+}
+
+func testListEvents(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	accountID := uuid.Must(uuid.NewV4())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	events := []entities.Event{
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateAccount,
+			AggregateID:   accountID,
+			RoutingKey:    "ecorp.accountCreation",
+			Payload:       []byte(`{"account_id": "1"}`),
+			CreatedAt:     start,
+		},
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   uuid.Must(uuid.NewV4()),
+			RoutingKey:    "ecorp.transferCreation",
+			Payload:       []byte(`{"id": "2"}`),
+			CreatedAt:     start.Add(time.Hour),
+		},
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateAccount,
+			AggregateID:   accountID,
+			RoutingKey:    "ecorp.accountCreation",
+			Payload:       []byte(`{"account_id": "3"}`),
+			CreatedAt:     start.Add(2 * time.Hour),
+		},
+	}
+	// appended out of the order of their ids, they are listed in the order they were appended.
+	for _, i := range []int{2, 0, 1} {
+		require.NoError(t, r.AppendEvent(ctx, events[i]))
+	}
+
+	// execute: all the events
+	all, err := r.ListEvents(ctx, entities.EventFilter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[2].ID, events[0].ID, events[1].ID}, eventIDs(all))
+
+	// execute: next page
+	got, err := r.ListEvents(ctx, entities.EventFilter{}, all[0].Sequence, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[0].ID}, eventIDs(got))
+
+	// execute: by aggregate
+	got, err = r.ListEvents(ctx, entities.EventFilter{AggregateID: accountID}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[2].ID, events[0].ID}, eventIDs(got))
+
+	// execute: by time range, the upper bound is exclusive
+	got, err = r.ListEvents(ctx, entities.EventFilter{From: start.Add(time.Hour), To: start.Add(2 * time.Hour)}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, eventIDs(events[1:2]), eventIDs(got))
+
+	// execute: no event
+	got, err = r.ListEvents(ctx, entities.EventFilter{}, all[2].Sequence, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func testListAccountEvents(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	accountID := createAccount(t, r, "33344455566", 100).ID
+	otherID := uuid.Must(uuid.NewV7())
+
+	transferEvent := func(originID, destinationID uuid.UUID) entities.Event {
+		return entities.Event{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   uuid.Must(uuid.NewV7()),
+			RoutingKey:    "ecorp.transferCreation",
+			Payload:       []byte(fmt.Sprintf(`{"account_origin_id": %q, "account_destination_id": %q, "amount": 1}`, originID, destinationID)),
+			CreatedAt:     time.Now().Truncate(time.Second),
+		}
+	}
+
+	events := []entities.Event{
+		transferEvent(accountID, otherID),
+		{
+			ID:            uuid.Must(uuid.NewV7()),
+			AggregateType: entities.EventAggregateAccount,
+			AggregateID:   accountID,
+			RoutingKey:    "ecorp.accountCreation",
+			Payload:       []byte(fmt.Sprintf(`{"account_origin_id": %q}`, accountID)),
+			CreatedAt:     time.Now().Truncate(time.Second),
+		},
+		transferEvent(otherID, uuid.Must(uuid.NewV7())),
+		transferEvent(otherID, accountID),
+		transferEvent(accountID, otherID),
+	}
+	for i, e := range events {
+		require.NoError(t, r.AppendEvent(ctx, e))
+
+		// the balance changes before the last event is appended.
+		if i == 3 {
+			require.NoError(t, r.UpdateBalance(ctx, accountID, 5))
+		}
+	}
+
+	// execute: only the transfers of the account, numbered in the stream of the account
+	all, err := r.ListAccountEvents(ctx, accountID, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[0].ID, events[3].ID, events[4].ID}, accountEventIDs(all))
+	for i, e := range all {
+		assert.Equal(t, accountID, e.AccountID)
+		assert.Equal(t, int64(i+1), e.Sequence)
+	}
+
+	// assert: the events carry the balance of the account when they were appended
+	assert.Equal(t, []int{100, 100, 105}, []int{all[0].Balance, all[1].Balance, all[2].Balance})
+	assert.JSONEq(t, string(events[0].Payload), string(all[0].Event.Payload))
+
+	// execute: next page
+	got, err := r.ListAccountEvents(ctx, accountID, all[0].Sequence, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[3].ID}, accountEventIDs(got))
+
+	// execute: the other account has its own stream, without balance as it doesn't exist
+	got, err = r.ListAccountEvents(ctx, otherID, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{events[0].ID, events[2].ID, events[3].ID, events[4].ID}, accountEventIDs(got))
+	assert.Equal(t, int64(4), got[3].Sequence)
+	assert.Zero(t, got[3].Balance)
+}
+
+func eventIDs(events []entities.Event) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	return ids
+}
+
+func accountEventIDs(events []entities.AccountEvent) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.Event.ID)
+	}
+
+	return ids
+}
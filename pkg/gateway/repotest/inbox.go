@@ -0,0 +1,26 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMarkMessageProcessed(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// execute
+	first, err := r.MarkMessageProcessed(ctx, "webhooks", "message-1")
+	require.NoError(t, err)
+	again, err := r.MarkMessageProcessed(ctx, "webhooks", "message-1")
+	require.NoError(t, err)
+	otherConsumer, err := r.MarkMessageProcessed(ctx, "notifications", "message-1")
+	require.NoError(t, err)
+
+	// assert
+	assert.True(t, first)
+	assert.False(t, again)
+	assert.True(t, otherConsumer)
+}
@@ -0,0 +1,129 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+func testNotificationPreferences(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+
+	_, err := r.GetNotificationPreferences(ctx, acc.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	p := entities.NotificationPreferences{
+		AccountID:           acc.ID,
+		Locale:              entities.LocaleEn,
+		Channels:            []entities.NotificationChannel{entities.NotificationChannelEmail},
+		Events:              []entities.NotificationEvent{entities.NotificationTransferReceived},
+		Email:               "elliot@ecorp.com",
+		LargeTransferAmount: 1000,
+	}
+
+	// execute
+	saved, err := r.SaveNotificationPreferences(ctx, p)
+
+	// assert
+	require.NoError(t, err)
+	assert.False(t, saved.UpdatedAt.IsZero())
+	got, err := r.GetNotificationPreferences(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, got)
+
+	// execute: replace
+	p.Locale = entities.LocalePtBR
+	p.Channels = []entities.NotificationChannel{entities.NotificationChannelSMS, entities.NotificationChannelPush}
+	p.Events = []entities.NotificationEvent{}
+	p.Phone = "+5511999999999"
+	p.LargeTransferAmount = 1 << 40
+	saved, err = r.SaveNotificationPreferences(ctx, p)
+
+	// assert
+	require.NoError(t, err)
+	got, err = r.GetNotificationPreferences(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, got)
+
+	// execute: unknown account
+	p.AccountID = uuid.Must(uuid.NewV7())
+	_, err = r.SaveNotificationPreferences(ctx, p)
+	assert.Error(t, err)
+}
+
+func testNotifications(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+
+	n := entities.Notification{
+		ID:        uuid.Must(uuid.NewV7()),
+		AccountID: acc.ID,
+		EventID:   uuid.Must(uuid.NewV7()).String(),
+		Event:     entities.NotificationTransferReceived,
+		Channel:   entities.NotificationChannelEmail,
+		Recipient: "elliot@ecorp.com",
+		Subject:   "Transfer received",
+		Body:      "You received 10",
+		Status:    entities.NotificationPending,
+		CreatedAt: time.Now().Truncate(time.Second),
+	}
+
+	// execute
+	got, err := r.UpsertNotification(ctx, n)
+
+	// assert: first attempt
+	require.NoError(t, err)
+	expected := n
+	expected.Attempts = 1
+	assert.Equal(t, expected, got)
+
+	// execute: same event and channel
+	retry := n
+	retry.ID = uuid.Must(uuid.NewV7())
+	retry.Body = "ignored"
+	got, err = r.UpsertNotification(ctx, retry)
+
+	// assert: the stored one with one more attempt
+	require.NoError(t, err)
+	expected.Attempts = 2
+	assert.Equal(t, expected, got)
+
+	// execute: another channel
+	sms := n
+	sms.ID = uuid.Must(uuid.NewV7())
+	sms.Channel = entities.NotificationChannelSMS
+	got, err = r.UpsertNotification(ctx, sms)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, sms.ID, got.ID)
+	assert.Equal(t, 1, got.Attempts)
+
+	// execute: sent
+	sentAt := time.Now().Truncate(time.Second)
+	require.NoError(t, r.MarkNotificationSent(ctx, n.ID, sentAt))
+	got, err = r.UpsertNotification(ctx, n)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, entities.NotificationSent, got.Status)
+	assert.Equal(t, &sentAt, got.SentAt)
+
+	// execute: unknown account
+	n.ID = uuid.Must(uuid.NewV7())
+	n.AccountID = uuid.Must(uuid.NewV7())
+	_, err = r.UpsertNotification(ctx, n)
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+// Package repotest is the contract of the repositories of the use cases.
+// Every implementation runs the suite in its tests, so they all behave the same.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+)
+
+// Repository is implemented by the repositories of the use cases.
+type Repository interface {
+	usecase.CreateAccountUCRepository
+	usecase.ImportAccountsUCRepository
+	usecase.GetAccountBalanceUCRepository
+	usecase.ListAccountsUCRepository
+	usecase.UpdateAccountUCRepository
+	usecase.AuthUCRepository
+	usecase.ListAccountEventsUCRepository
+	usecase.ReplayEventsUCRepository
+	usecase.TransferUCRepository
+	usecase.ListAccountTransfersUCRepository
+	usecase.CreateWebhookUCRepository
+	usecase.DeleteWebhookUCRepository
+	usecase.ListWebhooksUCRepository
+	usecase.DispatchWebhooksUCRepository
+	usecase.DeliverWebhooksUCRepository
+	usecase.ListWebhookDeliveriesUCRepository
+	usecase.ReplayWebhookDeliveryUCRepository
+	usecase.GetNotificationPreferencesUCRepository
+	usecase.UpdateNotificationPreferencesUCRepository
+	usecase.SendNotificationsUCRepository
+	rabbitmq.InboxStore
+	rabbitmq.EventStore
+
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	SetBalanceShards(ctx context.Context, id uuid.UUID, shards int) error
+}
+
+// TestRepository runs the contract suite against the repositories returned by newRepository.
+// Every test runs in parallel on its own empty repository.
+func TestRepository(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, r Repository)
+	}{
+		{name: "CreateAccount", test: testCreateAccount},
+		{name: "CreateAccounts", test: testCreateAccounts},
+		{name: "GetAccount", test: testGetAccount},
+		{name: "ListAccounts", test: testListAccounts},
+		{name: "UpdateBalance", test: testUpdateBalance},
+		{name: "UpdateBalance concurrently", test: testUpdateBalanceConcurrently},
+		{name: "BalanceShards", test: testBalanceShards},
+		{name: "BalanceShards concurrently", test: testBalanceShardsConcurrently},
+		{name: "UpdateAccount", test: testUpdateAccount},
+		{name: "UpdateAccount concurrently", test: testUpdateAccountConcurrently},
+		{name: "Transfers", test: testTransfers},
+		{name: "Transfer keeps the versions", test: testTransferKeepsVersions},
+		{name: "AppendEvent", test: testAppendEvent},
+		{name: "ListEvents", test: testListEvents},
+		{name: "ListAccountEvents", test: testListAccountEvents},
+		{name: "MarkMessageProcessed", test: testMarkMessageProcessed},
+		{name: "WebhookSubscriptions", test: testWebhookSubscriptions},
+		{name: "DeleteWebhookSubscription", test: testDeleteWebhookSubscription},
+		{name: "WebhookDeliveries", test: testWebhookDeliveries},
+		{name: "ClaimDueWebhookDeliveries", test: testClaimDueWebhookDeliveries},
+		{name: "ReplayWebhookDelivery", test: testReplayWebhookDelivery},
+		{name: "NotificationPreferences", test: testNotificationPreferences},
+		{name: "Notifications", test: testNotifications},
+		{name: "Transaction commit", test: testTxCommit},
+		{name: "Transaction rollback", test: testTxRollback},
+		{name: "Transaction not found", test: testTxNotFound},
+		{name: "Transactions concurrently", test: testTxConcurrently},
+		{name: "Savepoint rollback", test: testSavepointRollback},
+		{name: "Savepoint commit", test: testSavepointCommit},
+		{name: "WithTx", test: testWithTx},
+		{name: "WithTx nested", test: testWithTxNested},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.test(t, newRepository(t))
+		})
+	}
+}
+
+// createAccount inserts an account with the document and balance. It's returned as stored, at the first version.
+func createAccount(t *testing.T, r Repository, document string, balance int) entities.Account {
+	t.Helper()
+
+	acc := entities.Account{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "Elliot",
+		Document:  vos.Document(document),
+		Secret:    "password",
+		Balance:   balance,
+		CreatedAt: time.Now().Truncate(time.Second),
+		Version:   1,
+	}
+	require.NoError(t, r.CreateAccount(context.Background(), acc))
+
+	return acc
+}
@@ -0,0 +1,122 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+func testTransfers(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	origin := createAccount(t, r, "33344455566", 0)
+	destination := createAccount(t, r, "33344455567", 0)
+	other := createAccount(t, r, "33344455568", 0)
+
+	transfers := []entities.Transfer{
+		{
+			ID:                   uuid.Must(uuid.NewV7()),
+			AccountOriginID:      origin.ID,
+			AccountDestinationID: destination.ID,
+			Amount:               10,
+			CreatedAt:            time.Now().Truncate(time.Second),
+		},
+		{
+			ID:                   uuid.Must(uuid.NewV7()),
+			AccountOriginID:      destination.ID,
+			AccountDestinationID: origin.ID,
+			Amount:               5,
+			CreatedAt:            time.Now().Truncate(time.Second),
+		},
+		{
+			ID:                   uuid.Must(uuid.NewV7()),
+			AccountOriginID:      destination.ID,
+			AccountDestinationID: other.ID,
+			Amount:               1,
+			CreatedAt:            time.Now().Truncate(time.Second),
+		},
+	}
+
+	// execute
+	for _, transfer := range transfers {
+		require.NoError(t, r.CreateTransfer(ctx, transfer))
+	}
+
+	// assert: sent and received, in descending order
+	got, err := r.ListAccountTransfers(ctx, origin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Transfer{transfers[1], transfers[0]}, got)
+
+	got, err = r.ListAccountTransfers(ctx, uuid.Must(uuid.NewV7()))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// execute: same id
+	err = r.CreateTransfer(ctx, transfers[0])
+	assert.Error(t, err)
+
+	// execute: same id, created in another month
+	replayed := transfers[0]
+	replayed.CreatedAt = replayed.CreatedAt.AddDate(0, -1, 0)
+	err = r.CreateTransfer(ctx, replayed)
+	assert.Error(t, err)
+
+	// execute: unknown account
+	err = r.CreateTransfer(ctx, entities.Transfer{
+		ID:                   uuid.Must(uuid.NewV7()),
+		AccountOriginID:      origin.ID,
+		AccountDestinationID: uuid.Must(uuid.NewV7()),
+		Amount:               1,
+		CreatedAt:            time.Now().Truncate(time.Second),
+	})
+	assert.Error(t, err)
+}
+
+func testTransferKeepsVersions(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	origin := createAccount(t, r, "33344455566", 10)
+	destination := createAccount(t, r, "33344455567", 0)
+
+	// execute: a transfer, as the use case makes it
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		transfer := entities.Transfer{
+			ID:                   uuid.Must(uuid.NewV7()),
+			AccountOriginID:      origin.ID,
+			AccountDestinationID: destination.ID,
+			Amount:               4,
+			CreatedAt:            time.Now().Truncate(time.Second),
+		}
+		if err := r.CreateTransfer(ctx, transfer); err != nil {
+			return err
+		}
+		if err := r.UpdateBalance(ctx, origin.ID, -transfer.Amount); err != nil {
+			return err
+		}
+		return r.UpdateBalance(ctx, destination.ID, transfer.Amount)
+	})
+	require.NoError(t, err)
+
+	// assert: the balances changed, the versions didn't
+	for _, acc := range []entities.Account{origin, destination} {
+		got, err := r.GetAccount(ctx, acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, acc.Version, got.Version)
+	}
+
+	// execute: the profile is updated at the version read before the transfer
+	update := destination
+	update.Name = "Darlene Alderson"
+	_, err = r.UpdateAccount(ctx, update, destination.Version)
+
+	// assert
+	assert.NoError(t, err)
+}
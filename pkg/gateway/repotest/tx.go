@@ -0,0 +1,281 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+)
+
+func testTxCommit(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	txCtx, err := r.BeginTX(ctx)
+	require.NoError(t, err)
+
+	// execute
+	require.NoError(t, r.UpdateBalance(txCtx, acc.ID, 5))
+
+	// assert: the changes are only seen inside the transaction until the commit
+	balance, err := r.GetBalance(txCtx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, balance)
+
+	// execute
+	require.NoError(t, r.CommitTX(txCtx))
+
+	// assert
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+
+	// execute: the transaction is over
+	assert.Error(t, r.CommitTX(txCtx))
+	assert.Error(t, r.RollbackTX(txCtx))
+	assert.Error(t, r.UpdateBalance(txCtx, acc.ID, 5))
+}
+
+func testTxRollback(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	txCtx, err := r.BeginTX(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, r.UpdateBalance(txCtx, acc.ID, -10))
+	created := acc
+	created.ID = uuid.Must(uuid.NewV7())
+	created.Document = "33344455567"
+	require.NoError(t, r.CreateAccount(txCtx, created))
+
+	// execute
+	require.NoError(t, r.RollbackTX(txCtx))
+
+	// assert: nothing was changed
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, balance)
+
+	_, err = r.GetAccount(ctx, created.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute: the transaction is over
+	assert.Error(t, r.RollbackTX(txCtx))
+	assert.Error(t, r.CommitTX(txCtx))
+}
+
+func testTxNotFound(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// execute
+	commitErr := r.CommitTX(ctx)
+	rollbackErr := r.RollbackTX(ctx)
+
+	// assert
+	assert.EqualError(t, commitErr, "transaction not found")
+	assert.EqualError(t, rollbackErr, "transaction not found")
+}
+
+func testTxConcurrently(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	origin := createAccount(t, r, "33344455566", 100)
+	destination := createAccount(t, r, "33344455567", 0)
+
+	// execute: concurrent transfers
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			txCtx, err := r.BeginTX(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer r.RollbackTX(txCtx) // nolint:errcheck
+
+			if errs[i] = r.UpdateBalance(txCtx, origin.ID, -10); errs[i] != nil {
+				return
+			}
+			if errs[i] = r.UpdateBalance(txCtx, destination.ID, 10); errs[i] != nil {
+				return
+			}
+			errs[i] = r.CommitTX(txCtx)
+		}()
+	}
+	wg.Wait()
+
+	// assert: no update is lost
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	balance, err := r.GetBalance(ctx, origin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+
+	balance, err = r.GetBalance(ctx, destination.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 100, balance)
+}
+
+func testSavepointRollback(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	txCtx, err := r.BeginTX(ctx)
+	require.NoError(t, err)
+	defer r.RollbackTX(txCtx) // nolint:errcheck
+	require.NoError(t, r.UpdateBalance(txCtx, acc.ID, 5))
+
+	// execute
+	savepointCtx, err := r.BeginTX(txCtx)
+	require.NoError(t, err)
+	require.NoError(t, r.UpdateBalance(savepointCtx, acc.ID, 100))
+	require.NoError(t, r.RollbackTX(savepointCtx))
+
+	// assert: only the changes after the savepoint are discarded
+	balance, err := r.GetBalance(txCtx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+
+	require.NoError(t, r.CommitTX(txCtx))
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+}
+
+func testSavepointCommit(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	txCtx, err := r.BeginTX(ctx)
+	require.NoError(t, err)
+
+	// execute
+	savepointCtx, err := r.BeginTX(txCtx)
+	require.NoError(t, err)
+	require.NoError(t, r.UpdateBalance(savepointCtx, acc.ID, 100))
+	require.NoError(t, r.CommitTX(savepointCtx))
+
+	// assert: the released savepoint is part of the transaction
+	balance, err := r.GetBalance(txCtx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 110, balance)
+
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, balance)
+
+	// execute: the transaction is rolled back
+	require.NoError(t, r.RollbackTX(txCtx))
+
+	// assert
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, balance)
+	assert.Error(t, r.CommitTX(savepointCtx))
+}
+
+func testWithTx(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+	errFailed := errors.New("failed")
+
+	// execute: fn succeeds
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		return r.UpdateBalance(ctx, acc.ID, 5)
+	})
+
+	// assert: committed
+	require.NoError(t, err)
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+
+	// execute: fn fails
+	err = r.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.UpdateBalance(ctx, acc.ID, 5); err != nil {
+			return err
+		}
+		return errFailed
+	})
+
+	// assert: rolled back
+	assert.ErrorIs(t, err, errFailed)
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+
+	// execute: fn panics
+	assert.PanicsWithValue(t, "failed", func() {
+		_ = r.WithTx(ctx, func(ctx context.Context) error {
+			if err := r.UpdateBalance(ctx, acc.ID, 5); err != nil {
+				return err
+			}
+			panic("failed")
+		})
+	})
+
+	// assert: rolled back, and the next transaction doesn't wait for it
+	balance, err = r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, balance)
+	require.NoError(t, r.UpdateBalance(ctx, acc.ID, 5))
+}
+
+func testWithTxNested(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 10)
+
+	// execute: the inner transaction fails on a constraint of the database
+	var innerErr error
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.UpdateBalance(ctx, acc.ID, 5); err != nil {
+			return err
+		}
+
+		innerErr = r.WithTx(ctx, func(ctx context.Context) error {
+			if err := r.UpdateBalance(ctx, acc.ID, 100); err != nil {
+				return err
+			}
+			return r.UpdateBalance(ctx, acc.ID, -1000)
+		})
+
+		return r.UpdateBalance(ctx, acc.ID, 1)
+	})
+
+	// assert: the outer transaction goes on without the changes of the inner one
+	require.NoError(t, err)
+	assert.Error(t, innerErr)
+	balance, err := r.GetBalance(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 16, balance)
+}
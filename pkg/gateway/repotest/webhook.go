@@ -0,0 +1,326 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain"
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+func testWebhookSubscriptions(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+	other := createAccount(t, r, "33344455567", 0)
+
+	subs := []entities.WebhookSubscription{
+		newWebhookSubscription(acc.ID, entities.WebhookEventTransferSent, entities.WebhookEventTransferReceived),
+		newWebhookSubscription(other.ID, entities.WebhookEventTransferReceived),
+		newWebhookSubscription(acc.ID, entities.WebhookEventTransferReceived),
+		newWebhookSubscription(acc.ID, entities.WebhookEventTransferSent),
+	}
+
+	// execute
+	for _, sub := range subs {
+		require.NoError(t, r.CreateWebhookSubscription(ctx, sub))
+	}
+
+	// assert
+	got, err := r.GetWebhookSubscription(ctx, subs[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, subs[0], got)
+
+	_, err = r.GetWebhookSubscription(ctx, uuid.Must(uuid.NewV7()))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// assert: in descending order
+	list, err := r.ListWebhookSubscriptions(ctx, acc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.WebhookSubscription{subs[3], subs[2], subs[0]}, list)
+
+	// assert: in ascending order
+	list, err = r.ListWebhookSubscriptionsByEvent(ctx, acc.ID, entities.WebhookEventTransferReceived)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.WebhookSubscription{subs[0], subs[2]}, list)
+
+	list, err = r.ListWebhookSubscriptions(ctx, uuid.Must(uuid.NewV7()))
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// execute: same id
+	err = r.CreateWebhookSubscription(ctx, subs[0])
+	assert.Error(t, err)
+
+	// execute: unknown account
+	err = r.CreateWebhookSubscription(ctx, newWebhookSubscription(uuid.Must(uuid.NewV7()), entities.WebhookEventTransferSent))
+	assert.Error(t, err)
+}
+
+func testDeleteWebhookSubscription(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+	other := createAccount(t, r, "33344455567", 0)
+
+	sub := newWebhookSubscription(acc.ID, entities.WebhookEventTransferSent)
+	require.NoError(t, r.CreateWebhookSubscription(ctx, sub))
+	d := newWebhookDelivery(sub.ID, time.Now())
+	createWebhookDelivery(t, r, d)
+
+	// execute: subscription of another account
+	err := r.DeleteWebhookSubscription(ctx, other.ID, sub.ID)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = r.GetWebhookSubscription(ctx, sub.ID)
+	require.NoError(t, err)
+
+	// execute
+	err = r.DeleteWebhookSubscription(ctx, acc.ID, sub.ID)
+
+	// assert: the deliveries are deleted with the subscription
+	require.NoError(t, err)
+	_, err = r.GetWebhookSubscription(ctx, sub.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = r.GetWebhookDelivery(ctx, d.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute: already deleted
+	err = r.DeleteWebhookSubscription(ctx, acc.ID, sub.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func testWebhookDeliveries(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+	sub := newWebhookSubscription(acc.ID, entities.WebhookEventTransferSent)
+	require.NoError(t, r.CreateWebhookSubscription(ctx, sub))
+
+	deliveries := []entities.WebhookDelivery{
+		newWebhookDelivery(sub.ID, time.Now()),
+		newWebhookDelivery(sub.ID, time.Now()),
+		newWebhookDelivery(sub.ID, time.Now()),
+	}
+
+	// execute
+	for _, d := range deliveries {
+		inserted, err := r.CreateWebhookDelivery(ctx, d)
+		require.NoError(t, err)
+		assert.True(t, inserted)
+	}
+
+	// assert: the attempts log is empty
+	got, err := r.GetWebhookDelivery(ctx, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(deliveries[0].Payload), string(got.Payload))
+	assert.Equal(t, entities.WebhookDeliveryPending, got.Status)
+	assert.Zero(t, got.Attempts)
+	assert.Zero(t, got.ResponseStatus)
+	assert.Empty(t, got.LastError)
+	assert.Nil(t, got.DeliveredAt)
+	assert.Equal(t, deliveries[0].CreatedAt, got.CreatedAt)
+
+	_, err = r.GetWebhookDelivery(ctx, uuid.Must(uuid.NewV7()))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute: same event
+	duplicate := deliveries[0]
+	duplicate.ID = uuid.Must(uuid.NewV7())
+	inserted, err := r.CreateWebhookDelivery(ctx, duplicate)
+
+	// assert: ignored
+	require.NoError(t, err)
+	assert.False(t, inserted)
+	_, err = r.GetWebhookDelivery(ctx, duplicate.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// execute: unknown subscription
+	_, err = r.CreateWebhookDelivery(ctx, newWebhookDelivery(uuid.Must(uuid.NewV7()), time.Now()))
+	assert.Error(t, err)
+
+	// execute: most recent first
+	list, err := r.ListWebhookDeliveries(ctx, sub.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{deliveries[2].ID, deliveries[1].ID}, deliveryIDs(list))
+
+	// execute: update
+	deliveredAt := time.Now().Truncate(time.Second)
+	d := got
+	d.Status = entities.WebhookDeliverySucceeded
+	d.Attempts = 1
+	d.ResponseStatus = 200
+	d.LastError = "timeout"
+	d.NextAttemptAt = deliveredAt
+	d.DeliveredAt = &deliveredAt
+	require.NoError(t, r.UpdateWebhookDelivery(ctx, d))
+
+	// assert
+	got, err = r.GetWebhookDelivery(ctx, d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, d, got)
+
+	// execute: unknown delivery
+	err = r.UpdateWebhookDelivery(ctx, newWebhookDelivery(sub.ID, time.Now()))
+	assert.NoError(t, err)
+}
+
+func testClaimDueWebhookDeliveries(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup
+	acc := createAccount(t, r, "33344455566", 0)
+	sub := newWebhookSubscription(acc.ID, entities.WebhookEventTransferSent)
+	require.NoError(t, r.CreateWebhookSubscription(ctx, sub))
+
+	now := time.Now().Truncate(time.Second)
+	deliveries := []entities.WebhookDelivery{
+		newWebhookDelivery(sub.ID, now.Add(-time.Minute)),
+		newWebhookDelivery(sub.ID, now.Add(-time.Hour)),
+		newWebhookDelivery(sub.ID, now),
+		newWebhookDelivery(sub.ID, now.Add(time.Second)),
+		newWebhookDelivery(sub.ID, now.Add(-2*time.Hour)),
+	}
+	for _, d := range deliveries {
+		createWebhookDelivery(t, r, d)
+	}
+
+	finished, err := r.GetWebhookDelivery(ctx, deliveries[4].ID)
+	require.NoError(t, err)
+	finished.Status = entities.WebhookDeliveryFailed
+	require.NoError(t, r.UpdateWebhookDelivery(ctx, finished))
+
+	// execute
+	leaseUntil := now.Add(time.Minute)
+	got, err := r.ClaimDueWebhookDeliveries(ctx, now, leaseUntil, 2)
+
+	// assert: the pending ones with the earliest next attempt
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{deliveries[1].ID, deliveries[0].ID}, deliveryIDs(got))
+	for _, d := range got {
+		assert.True(t, leaseUntil.Equal(d.NextAttemptAt))
+	}
+
+	// execute: the claimed ones are skipped until the end of the lease
+	got, err = r.ClaimDueWebhookDeliveries(ctx, now, leaseUntil, 10)
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{deliveries[2].ID}, deliveryIDs(got))
+
+	// execute: the lease ended without recording the attempts
+	got, err = r.ClaimDueWebhookDeliveries(ctx, leaseUntil, leaseUntil.Add(time.Minute), 10)
+
+	// assert: the deliveries are claimed again, with the one due by then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{deliveries[0].ID, deliveries[1].ID, deliveries[2].ID, deliveries[3].ID}, deliveryIDs(got))
+}
+
+func testReplayWebhookDelivery(t *testing.T, r Repository) {
+	ctx := context.Background()
+
+	// setup: a delivery that failed its attempts
+	acc := createAccount(t, r, "33344455566", 0)
+	sub := newWebhookSubscription(acc.ID, entities.WebhookEventTransferSent)
+	require.NoError(t, r.CreateWebhookSubscription(ctx, sub))
+
+	now := time.Now().Truncate(time.Second)
+	d := newWebhookDelivery(sub.ID, now)
+	createWebhookDelivery(t, r, d)
+
+	failed, err := r.GetWebhookDelivery(ctx, d.ID)
+	require.NoError(t, err)
+	failed.Status = entities.WebhookDeliveryFailed
+	failed.Attempts = 3
+	require.NoError(t, r.UpdateWebhookDelivery(ctx, failed))
+
+	read, err := r.GetWebhookDelivery(ctx, d.ID)
+	require.NoError(t, err)
+
+	// execute
+	err = r.ReplayWebhookDelivery(ctx, read, now.Add(time.Minute))
+
+	// assert: a new delivery is scheduled
+	require.NoError(t, err)
+	got, err := r.GetWebhookDelivery(ctx, d.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliveryPending, got.Status)
+	assert.Zero(t, got.Attempts)
+	assert.True(t, now.Add(time.Minute).Equal(got.NextAttemptAt))
+
+	// execute: replayed again from the delivery read before the first replay
+	err = r.ReplayWebhookDelivery(ctx, read, now)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// execute: claimed by a worker since it was read
+	read = got
+	_, err = r.ClaimDueWebhookDeliveries(ctx, now.Add(time.Minute), now.Add(2*time.Minute), 10)
+	require.NoError(t, err)
+	err = r.ReplayWebhookDelivery(ctx, read, now)
+
+	// assert: the lease is kept
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	got, err = r.GetWebhookDelivery(ctx, d.ID)
+	require.NoError(t, err)
+	assert.True(t, now.Add(2*time.Minute).Equal(got.NextAttemptAt))
+
+	// execute: unknown delivery
+	err = r.ReplayWebhookDelivery(ctx, newWebhookDelivery(sub.ID, now), now)
+
+	// assert
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func newWebhookSubscription(accountID uuid.UUID, eventTypes ...entities.WebhookEventType) entities.WebhookSubscription {
+	return entities.WebhookSubscription{
+		ID:         uuid.Must(uuid.NewV7()),
+		AccountID:  accountID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: eventTypes,
+		Secret:     "secret",
+		CreatedAt:  time.Now().Truncate(time.Second),
+	}
+}
+
+func newWebhookDelivery(subscriptionID uuid.UUID, nextAttemptAt time.Time) entities.WebhookDelivery {
+	return entities.WebhookDelivery{
+		ID:             uuid.Must(uuid.NewV7()),
+		SubscriptionID: subscriptionID,
+		EventID:        uuid.Must(uuid.NewV7()).String(),
+		EventType:      entities.WebhookEventTransferSent,
+		Payload:        []byte(`{"amount": 1}`),
+		Status:         entities.WebhookDeliveryPending,
+		Attempts:       2,
+		LastError:      "ignored on creation",
+		NextAttemptAt:  nextAttemptAt,
+		CreatedAt:      time.Now().Truncate(time.Second),
+	}
+}
+
+func createWebhookDelivery(t *testing.T, r Repository, d entities.WebhookDelivery) {
+	t.Helper()
+
+	inserted, err := r.CreateWebhookDelivery(context.Background(), d)
+	require.NoError(t, err)
+	require.True(t, inserted)
+}
+
+func deliveryIDs(deliveries []entities.WebhookDelivery) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(deliveries))
+	for _, d := range deliveries {
+		ids = append(ids, d.ID)
+	}
+
+	return ids
+}
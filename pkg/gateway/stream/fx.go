@@ -0,0 +1,53 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/controller"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+)
+
+// Module provides the Hub of the account event streams, fed by the notifications of the event store.
+var Module = fx.Module("stream",
+	fx.Provide(
+		fx.Annotate(
+			func(cfg config.Config) *Hub {
+				return NewHub(cfg.Stream.BufferSize)
+			},
+			fx.As(fx.Self()),
+			fx.As(new(controller.EventSubscriber)),
+		),
+	),
+	fx.Invoke(
+		func(lc fx.Lifecycle, hub *Hub, pool *pgxpool.Pool) {
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+
+			lc.Append(fx.Hook{
+				OnStart: func(startCtx context.Context) error {
+					go func() {
+						defer close(done)
+						postgres.NewEventListener(pool).Listen(ctx, hub.Publish, hub.CloseAll)
+					}()
+
+					return nil
+				},
+				OnStop: func(stopCtx context.Context) error {
+					cancel()
+					hub.CloseAll()
+
+					select {
+					case <-done:
+					case <-stopCtx.Done():
+					}
+
+					return nil
+				},
+			})
+		},
+	),
+)
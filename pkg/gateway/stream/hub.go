@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+)
+
+// Hub fans out the events of the account streams to the subscribers of the accounts.
+// The subscribers are indexed by account, so an event is only handed to the subscribers that receive it,
+// no matter how many are connected.
+type Hub struct {
+	bufferSize int
+
+	mu   sync.RWMutex
+	subs map[uuid.UUID]map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan entities.AccountEvent
+	once   sync.Once
+}
+
+func (s *subscriber) close() {
+	s.once.Do(func() { close(s.events) })
+}
+
+// NewHub returns a Hub buffering up to bufferSize events per subscriber.
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		bufferSize: bufferSize,
+		subs:       make(map[uuid.UUID]map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe returns the channel receiving the events of the account and the function that cancels the subscription.
+// The channel is closed when the subscription is canceled, when the subscriber doesn't keep up with the events
+// or when events may have been lost; the subscriber must then resume from the event store.
+func (h *Hub) Subscribe(accountID uuid.UUID) (<-chan entities.AccountEvent, func()) {
+	s := &subscriber{events: make(chan entities.AccountEvent, h.bufferSize)}
+
+	h.mu.Lock()
+	if h.subs[accountID] == nil {
+		h.subs[accountID] = make(map[*subscriber]struct{})
+	}
+	h.subs[accountID][s] = struct{}{}
+	h.mu.Unlock()
+
+	return s.events, func() { h.remove(accountID, s) }
+}
+
+// Publish hands the event to the subscribers of its account.
+func (h *Hub) Publish(e entities.AccountEvent) {
+	var slow []*subscriber
+	h.mu.RLock()
+	for s := range h.subs[e.AccountID] {
+		select {
+		case s.events <- e:
+		default:
+			slow = append(slow, s)
+		}
+	}
+	h.mu.RUnlock()
+
+	// a subscriber with a full buffer is dropped instead of blocking the others.
+	for _, s := range slow {
+		h.remove(e.AccountID, s)
+	}
+}
+
+// CloseAll closes the channels of all subscribers, which is used when events may have been lost.
+func (h *Hub) CloseAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for accountID, subs := range h.subs {
+		for s := range subs {
+			s.close()
+		}
+		delete(h.subs, accountID)
+	}
+}
+
+// Subscribers returns the number of subscriptions.
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var n int
+	for _, subs := range h.subs {
+		n += len(subs)
+	}
+
+	return n
+}
+
+func (h *Hub) remove(accountID uuid.UUID, s *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subs[accountID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[s]; !ok {
+		return
+	}
+
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(h.subs, accountID)
+	}
+	s.close()
+}
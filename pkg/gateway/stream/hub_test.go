@@ -0,0 +1,158 @@
+package stream_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/stream"
+)
+
+func transferEvent(t *testing.T, accountID uuid.UUID, sequence int64) entities.AccountEvent {
+	t.Helper()
+
+	id := uuid.Must(uuid.NewV7())
+	payload, err := json.Marshal(entities.Transfer{
+		ID:                   id,
+		AccountOriginID:      accountID,
+		AccountDestinationID: uuid.Must(uuid.NewV7()),
+		Amount:               100,
+	})
+	require.NoError(t, err)
+
+	return entities.AccountEvent{
+		AccountID: accountID,
+		Sequence:  sequence,
+		Event: entities.Event{
+			ID:            id,
+			AggregateType: entities.EventAggregateTransfer,
+			AggregateID:   id,
+			Payload:       payload,
+		},
+	}
+}
+
+// received drains the events buffered in the channel.
+func received(events <-chan entities.AccountEvent) []entities.AccountEvent {
+	var got []entities.AccountEvent
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return got
+			}
+			got = append(got, e)
+		default:
+			return got
+		}
+	}
+}
+
+func closed(events <-chan entities.AccountEvent) bool {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func TestHub_Publish(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	origin := uuid.Must(uuid.NewV7())
+	destination := uuid.Must(uuid.NewV7())
+	other := uuid.Must(uuid.NewV7())
+
+	hub := stream.NewHub(10)
+	originEvents, cancelOrigin := hub.Subscribe(origin)
+	defer cancelOrigin()
+	destinationEvents, cancelDestination := hub.Subscribe(destination)
+	defer cancelDestination()
+	otherEvents, cancelOther := hub.Subscribe(other)
+	defer cancelOther()
+
+	// the transfer is appended to the streams of both accounts.
+	sent := transferEvent(t, origin, 7)
+	receipt := sent
+	receipt.AccountID, receipt.Sequence = destination, 3
+
+	// execute
+	hub.Publish(sent)
+	hub.Publish(receipt)
+
+	// assert
+	assert.Equal(t, []entities.AccountEvent{sent}, received(originEvents))
+	assert.Equal(t, []entities.AccountEvent{receipt}, received(destinationEvents))
+	assert.Empty(t, received(otherEvents))
+	assert.Equal(t, 3, hub.Subscribers())
+}
+
+func TestHub_Subscribe_Cancel(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	accountID := uuid.Must(uuid.NewV7())
+	hub := stream.NewHub(10)
+	events, cancel := hub.Subscribe(accountID)
+	remaining, cancelRemaining := hub.Subscribe(accountID)
+	defer cancelRemaining()
+
+	// execute
+	cancel()
+	cancel()
+	hub.Publish(transferEvent(t, accountID, 1))
+
+	// assert
+	assert.True(t, closed(events))
+	assert.Len(t, received(remaining), 1)
+	assert.Equal(t, 1, hub.Subscribers())
+}
+
+func TestHub_Publish_SlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	accountID := uuid.Must(uuid.NewV7())
+	hub := stream.NewHub(1)
+	slow, cancelSlow := hub.Subscribe(accountID)
+	defer cancelSlow()
+
+	// execute
+	hub.Publish(transferEvent(t, accountID, 1))
+	hub.Publish(transferEvent(t, accountID, 2))
+
+	// assert
+	// the buffered event is still delivered before the channel is closed.
+	assert.Len(t, received(slow), 1)
+	assert.True(t, closed(slow))
+	assert.Equal(t, 0, hub.Subscribers())
+}
+
+func TestHub_CloseAll(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	hub := stream.NewHub(10)
+	first, cancelFirst := hub.Subscribe(uuid.Must(uuid.NewV7()))
+	second, cancelSecond := hub.Subscribe(uuid.Must(uuid.NewV7()))
+
+	// execute
+	hub.CloseAll()
+	cancelFirst()
+	cancelSecond()
+
+	// assert
+	assert.True(t, closed(first))
+	assert.True(t, closed(second))
+	assert.Equal(t, 0, hub.Subscribers())
+}
@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+// Module registers the global tracer provider. The binaries must supply their ServiceName.
+var Module = fx.Module("tracing",
+	fx.Invoke(
+		func(ctx context.Context, lc fx.Lifecycle, cfg config.Config, name ServiceName) error {
+			tp, err := NewTracerProvider(ctx, cfg.Tracing, name)
+			if err != nil {
+				return fmt.Errorf("creating tracer provider: %w", err)
+			}
+
+			Register(tp)
+
+			lc.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return tp.Shutdown(ctx)
+				},
+			})
+
+			return nil
+		},
+	),
+)
@@ -0,0 +1,65 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/tracing"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     config.TracingConfig
+		wantErr bool
+	}{
+		{name: "no exporter", cfg: config.TracingConfig{Exporter: tracing.ExporterNone, SampleRatio: 1}},
+		{name: "stdout exporter", cfg: config.TracingConfig{Exporter: tracing.ExporterStdout, SampleRatio: 1}},
+		{name: "unknown exporter", cfg: config.TracingConfig{Exporter: "jaeger"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			tp, err := tracing.NewTracerProvider(context.Background(), tt.cfg, "test")
+
+			// assert
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, tp.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	// execute
+	fields := tracing.LogFields(ctx)
+
+	// assert
+	require.Len(t, fields, 2)
+	assert.Equal(t, "trace_id", fields[0].Key)
+	assert.Equal(t, span.SpanContext().TraceID().String(), fields[0].String)
+	assert.Equal(t, "span_id", fields[1].Key)
+	assert.Equal(t, span.SpanContext().SpanID().String(), fields[1].String)
+	assert.Nil(t, tracing.LogFields(context.Background()))
+}
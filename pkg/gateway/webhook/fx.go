@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/fx"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+)
+
+// Module consumes the transfer events to schedule the webhook deliveries and runs the delivery worker.
+var Module = fx.Module("webhook",
+	fx.Provide(NewSender),
+	fx.Invoke(
+		func(ctx context.Context, lc fx.Lifecycle, run rabbitmq.RunConsumers, cfg config.Config, broker rabbitmq.Broker, r postgres.Repository, s Sender) {
+			if !run {
+				return
+			}
+
+			h := rabbitmq.Chain(
+				TransferHandler(usecase.NewDispatchWebhooksUC(r)),
+				rabbitmq.Trace,
+				rabbitmq.Correlate,
+				rabbitmq.ReadYourWrites,
+				rabbitmq.Inbox(cfg.MQ.WebhookQueue, r),
+			)
+			worker := NewWorker(usecase.NewDeliverWebhooksUC(r, s, &cfg.Webhook), cfg.Webhook.PollInterval)
+
+			lc.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					err := broker.Subscribe(ctx, cfg.MQ.WebhookQueue, []string{cfg.MQ.TransferBind}, h)
+					if err != nil {
+						return fmt.Errorf("subscribing webhook consumer: %w", err)
+					}
+
+					go worker.Run(ctx)
+
+					return nil
+				},
+			})
+		},
+	),
+)
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	gorabbitmq "github.com/wagslane/go-rabbitmq"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// TransferHandler schedules the webhook deliveries of the transfer creation events.
+// The events that fail to be dispatched are nacked with requeue, so the broker delivers them again after
+// the retry delay until they run out of retries.
+func TransferHandler(uc usecase.DispatchWebhooksUC) rabbitmq.Handler {
+	return func(ctx context.Context, d gorabbitmq.Delivery) gorabbitmq.Action {
+		var transfer entities.Transfer
+		if err := json.Unmarshal(d.Body, &transfer); err != nil {
+			logger.Error(ctx, "decoding transfer event", zap.Error(err), zap.String("message_id", d.MessageId))
+			return gorabbitmq.NackDiscard
+		}
+
+		output, err := uc.DispatchTransfer(ctx, usecase.DispatchTransferInput{
+			EventID:  transfer.ID.String(),
+			Transfer: transfer,
+		})
+		if err != nil {
+			logger.Error(ctx, "dispatching transfer webhooks",
+				zap.Error(err),
+				zap.String("message_id", d.MessageId),
+				zap.Int("retries", rabbitmq.Retries(d)),
+			)
+			return gorabbitmq.NackRequeue
+		}
+
+		logger.Info(ctx, "transfer webhooks dispatched",
+			zap.String("transfer_id", transfer.ID.String()),
+			zap.Int("deliveries", len(output.Deliveries)),
+		)
+
+		return gorabbitmq.Ack
+	}
+}
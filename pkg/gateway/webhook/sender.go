@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+)
+
+const (
+	// HeaderEvent contains the event type of the payload.
+	HeaderEvent = "X-Ecorp-Event"
+	// HeaderDelivery contains the delivery id. It is kept between the attempts of a delivery.
+	HeaderDelivery = "X-Ecorp-Delivery"
+	// HeaderTimestamp contains the unix time in which the payload was signed.
+	HeaderTimestamp = "X-Ecorp-Timestamp"
+	// HeaderSignature contains the HMAC-SHA256 signature of the timestamp and the payload.
+	HeaderSignature = "X-Ecorp-Signature"
+)
+
+var (
+	// ErrNotAccepted occurs when the receiver responds with a non 2xx status code.
+	ErrNotAccepted = errors.New("webhook not accepted by the receiver")
+	// ErrInvalidSignature occurs when the signature doesn't match the payload.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrTimestampTolerance occurs when the payload was signed outside the tolerance window.
+	ErrTimestampTolerance = errors.New("webhook timestamp outside the tolerance")
+	// ErrForbiddenAddress occurs when the url of the subscription resolves to an address that is not public.
+	ErrForbiddenAddress = errors.New("webhook address is not public")
+)
+
+// Sender delivers signed webhook payloads over HTTP.
+type Sender struct {
+	Client *http.Client
+}
+
+// NewSender returns a sender that only connects to public addresses. The addresses are checked when connecting,
+// after the names are resolved, so a name can't be pointed to an internal address after the subscription is created.
+// The proxies are not used, since they would connect on the behalf of the sender.
+func NewSender(cfg config.Config) Sender {
+	dialer := &net.Dialer{
+		Timeout:   cfg.Webhook.Timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   dialPublicOnly,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() // nolint:forcetypeassert
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return Sender{Client: &http.Client{Timeout: cfg.Webhook.Timeout, Transport: transport}}
+}
+
+// dialPublicOnly refuses the connections to the addresses the webhooks may not be sent to.
+func dialPublicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+
+	if !usecase.PublicWebhookAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// Send posts the delivery payload to the subscription url, signed with the subscription secret.
+// It returns ErrNotAccepted if the receiver doesn't respond with a 2xx status code.
+func (s Sender) Send(ctx context.Context, sub entities.WebhookSubscription, d entities.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("creating webhook request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(d.EventType))
+	req.Header.Set(HeaderDelivery, d.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, d.Payload))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("sending webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// draining the body allows the connection to be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%w: status code %d", ErrNotAccepted, resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature of the payload in the format "sha256=<hex>".
+// The signed content is the timestamp and the payload joined by a dot,
+// so a captured request can't be replayed with another timestamp.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify validates the signature and the timestamp headers of a received webhook.
+// Returns ErrTimestampTolerance if the payload was signed more than tolerance away from now.
+// Returns ErrInvalidSignature if the signature doesn't match the payload.
+func Verify(secret, timestamp, signature string, payload []byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrInvalidSignature)
+	}
+
+	if diff := now.Sub(time.Unix(ts, 0)); diff > tolerance || diff < -tolerance {
+		return ErrTimestampTolerance
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, ts, payload))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
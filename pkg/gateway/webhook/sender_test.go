@@ -0,0 +1,175 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/webhook"
+)
+
+func TestSender_Send(t *testing.T) {
+	t.Parallel()
+
+	sub := entities.WebhookSubscription{
+		ID:         uuid.Must(uuid.NewV7()),
+		Secret:     "whsec_test",
+		EventTypes: []entities.WebhookEventType{entities.WebhookEventTransferReceived},
+	}
+	delivery := entities.WebhookDelivery{
+		ID:        uuid.Must(uuid.NewV7()),
+		EventID:   "9ee14852-1011-422e-b9f3-abd905d5103c",
+		EventType: entities.WebhookEventTransferReceived,
+		Payload:   []byte(`{"id":"9ee14852-1011-422e-b9f3-abd905d5103c","type":"transfer.received"}`),
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    error
+	}{
+		{
+			name:       "accepted by the receiver",
+			statusCode: http.StatusNoContent,
+			wantErr:    nil,
+		},
+		{
+			name:       "rejected by the receiver",
+			statusCode: http.StatusServiceUnavailable,
+			wantErr:    webhook.ErrNotAccepted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// setup: the receiver validates the request as a partner would.
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				err = webhook.Verify(sub.Secret, r.Header.Get(webhook.HeaderTimestamp), r.Header.Get(webhook.HeaderSignature), body, time.Minute, time.Now())
+				assert.NoError(t, err)
+				assert.Equal(t, delivery.Payload, body)
+				assert.Equal(t, "transfer.received", r.Header.Get(webhook.HeaderEvent))
+				assert.Equal(t, delivery.ID.String(), r.Header.Get(webhook.HeaderDelivery))
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(receiver.Close)
+
+			sub := sub
+			sub.URL = receiver.URL
+			sender := webhook.Sender{Client: receiver.Client()}
+
+			// execute
+			status, err := sender.Send(context.Background(), sub, delivery)
+
+			// assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.statusCode, status)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":"1"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := webhook.Sign("whsec_test", now.Unix(), payload)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		payload   []byte
+		now       time.Time
+		wantErr   error
+	}{
+		{
+			name:      "valid signature",
+			secret:    "whsec_test",
+			timestamp: timestamp,
+			payload:   payload,
+			now:       now.Add(30 * time.Second),
+			wantErr:   nil,
+		},
+		{
+			name:      "wrong secret",
+			secret:    "whsec_other",
+			timestamp: timestamp,
+			payload:   payload,
+			now:       now,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "tampered payload",
+			secret:    "whsec_test",
+			timestamp: timestamp,
+			payload:   []byte(`{"id":"2"}`),
+			now:       now,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "tampered timestamp",
+			secret:    "whsec_test",
+			timestamp: strconv.FormatInt(now.Unix()+1, 10),
+			payload:   payload,
+			now:       now,
+			wantErr:   webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "replayed after the tolerance",
+			secret:    "whsec_test",
+			timestamp: timestamp,
+			payload:   payload,
+			now:       now.Add(10 * time.Minute),
+			wantErr:   webhook.ErrTimestampTolerance,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := webhook.Verify(tt.secret, tt.timestamp, signature, tt.payload, 5*time.Minute, tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewSender_RefusesInternalAddresses(t *testing.T) {
+	t.Parallel()
+
+	// setup: a receiver on the loopback, as the internal services
+	var called bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	t.Cleanup(receiver.Close)
+
+	sender := webhook.NewSender(config.Config{Webhook: config.WebhookConfig{Timeout: time.Second}})
+	sub := entities.WebhookSubscription{ID: uuid.Must(uuid.NewV7()), URL: receiver.URL, Secret: "whsec_test"}
+	delivery := entities.WebhookDelivery{ID: uuid.Must(uuid.NewV7()), Payload: []byte(`{}`)}
+
+	// execute
+	_, err := sender.Send(context.Background(), sub, delivery)
+
+	// assert
+	assert.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+	assert.False(t, called)
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// batchSize is the maximum number of deliveries attempted on each iteration of the worker.
+const batchSize = 50
+
+// Worker periodically attempts the pending webhook deliveries.
+type Worker struct {
+	uc       usecase.DeliverWebhooksUC
+	interval time.Duration
+}
+
+func NewWorker(uc usecase.DeliverWebhooksUC, interval time.Duration) Worker {
+	return Worker{uc: uc, interval: interval}
+}
+
+// Run attempts the due deliveries until the context is canceled.
+// When a full batch is processed, the next one is attempted without waiting for the interval.
+func (w Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		output, err := w.uc.DeliverWebhooks(ctx, usecase.DeliverWebhooksInput{Limit: batchSize})
+		if err != nil {
+			logger.Error(ctx, "delivering webhooks", zap.Error(err))
+		}
+
+		processed := output.Succeeded + output.Retrying + output.Failed
+		if processed > 0 {
+			logger.Info(ctx, "webhooks delivered",
+				zap.Int("succeeded", output.Succeeded),
+				zap.Int("retrying", output.Retrying),
+				zap.Int("failed", output.Failed),
+			)
+		}
+
+		if processed == batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
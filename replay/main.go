@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain/usecase"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/pkg/gateway/rabbitmq"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/cli"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Replays the events of the event store to a routing key, e.g. to build a new projection:
+//
+//	go run ./replay -routing-key ecorp.replay.balances -from 2024-01-01T00:00:00Z -rate 200
+func main() {
+	var (
+		from        = flag.String("from", "", "replay the events created from this time (RFC 3339)")
+		to          = flag.String("to", "", "replay the events created before this time (RFC 3339)")
+		aggregateID = flag.String("aggregate", "", "replay only the events of this account or transfer id")
+		routingKey  = flag.String("routing-key", "", "routing key the events are republished to")
+		rate        = flag.Int("rate", 100, "maximum number of events republished per second, 0 is unlimited")
+		dryRun      = flag.Bool("dry-run", false, "count the matching events without republishing them")
+	)
+	flag.Parse()
+
+	input, err := parseInput(*from, *to, *aggregateID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	input.RoutingKey = *routingKey
+	input.Rate = *rate
+	input.DryRun = *dryRun
+
+	var uc usecase.ReplayEventsUC
+	cli.Run(fx.Options(Options, fx.Populate(&uc)), func(ctx context.Context) error {
+		output, err := uc.ReplayEvents(ctx, input)
+
+		logger.Info(ctx, "events replayed",
+			zap.Int("matched", output.Matched),
+			zap.Int("replayed", output.Replayed),
+			zap.Bool("dry_run", input.DryRun),
+		)
+		if err != nil {
+			return fmt.Errorf("replaying events: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func parseInput(from, to, aggregateID string) (usecase.ReplayEventsInput, error) {
+	var (
+		input usecase.ReplayEventsInput
+		err   error
+	)
+
+	if from != "" {
+		input.Filter.From, err = time.Parse(time.RFC3339, from)
+		if err != nil {
+			return input, fmt.Errorf("invalid -from: %w", err)
+		}
+	}
+
+	if to != "" {
+		input.Filter.To, err = time.Parse(time.RFC3339, to)
+		if err != nil {
+			return input, fmt.Errorf("invalid -to: %w", err)
+		}
+	}
+
+	if aggregateID != "" {
+		input.Filter.AggregateID, err = uuid.FromString(aggregateID)
+		if err != nil {
+			return input, fmt.Errorf("invalid -aggregate: %w", err)
+		}
+	}
+
+	return input, nil
+}
+
+var Options = fx.Options(
+	logger.Module,
+	apictx.Module,
+	config.Module,
+	dbpool.Module,
+	rabbitmq.ModuleBroker,
+	rabbitmq.ModulePub,
+	fx.Provide(
+		fx.Annotate(
+			postgres.NewRepository,
+			fx.As(fx.Self()),
+			fx.As(new(rabbitmq.EventStore)),
+		),
+		func(r postgres.Repository, p rabbitmq.Publisher) usecase.ReplayEventsUC {
+			return usecase.NewReplayEventsUC(r, p)
+		},
+	),
+)
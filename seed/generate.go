@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+)
+
+// params configures the generated dataset. The same params generate the same dataset.
+type params struct {
+	Seed      uint64
+	Accounts  int
+	Transfers int
+	// Balance is the mean initial balance of the accounts, drawn from a Pareto distribution.
+	Balance int
+	// MinAmount is the minimum amount of the transfers, drawn from a Pareto distribution.
+	// The amounts are limited to the balance of the origin.
+	MinAmount int
+	// Alpha is the shape of the Pareto distributions. The lower it is, the more the amounts concentrate
+	// in a few transfers: 1.16 gives the 80/20 rule.
+	Alpha float64
+	// HotAccounts receive HotRatio of the transfers, as the accounts of merchants.
+	HotAccounts int
+	HotRatio    float64
+	// The transfers are spread over the Days before Until. The accounts are created in the Days before them.
+	Days  int
+	Until time.Time
+}
+
+func (p params) validate() error {
+	switch {
+	case p.Accounts < 2:
+		return errors.New("-accounts must be at least 2")
+	case p.Transfers < 0:
+		return errors.New("-transfers must be greater than or equal to zero")
+	case p.Balance <= 0:
+		return errors.New("-balance must be greater than zero")
+	case p.MinAmount <= 0:
+		return errors.New("-min-amount must be greater than zero")
+	case p.Alpha <= 1:
+		return errors.New("-alpha must be greater than 1")
+	case p.HotAccounts < 0 || p.HotAccounts > p.Accounts-2:
+		return errors.New("-hot-accounts must be between zero and the number of accounts minus 2")
+	case p.HotRatio < 0 || p.HotRatio >= 1:
+		return errors.New("-hot-ratio must be at least 0 and less than 1")
+	case p.HotRatio > 0 && p.HotAccounts == 0:
+		return errors.New("-hot-ratio requires -hot-accounts")
+	case p.Days <= 0:
+		return errors.New("-days must be greater than zero")
+	}
+
+	return nil
+}
+
+// dataset holds the accounts, with their initial balances, and the transfers between them in order of creation.
+type dataset struct {
+	Accounts  []entities.Account
+	Transfers []entities.Transfer
+}
+
+var (
+	firstNames = []string{"Elliot", "Darlene", "Angela", "Tyrell", "Dominique", "Phillip", "Joanna", "Leslie", "Gideon", "Shayla"}
+	lastNames  = []string{"Alderson", "Moss", "Wellick", "DiPierro", "Price", "Romero", "Mobley", "Chang", "Goddard", "Nieves"}
+)
+
+// maxAmount bounds the amounts, which are updated as 32 bits integers.
+const maxAmount = math.MaxInt32
+
+// generate generates the dataset of the params. The accounts share the secret, hashed once.
+//
+// The transfers are simulated in order of creation, so an account never sends more than its balance
+// and the sum of the balances is kept. The origins are drawn among all the accounts, the destinations
+// among the hot accounts with the hot ratio and among the others otherwise.
+func generate(p params, secret vos.Secret) dataset {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], p.Seed)
+	src := rand.NewChaCha8(key)
+	rng := rand.New(src)
+	ids := uuid.NewGenWithOptions(uuid.WithRandomReader(src))
+
+	until := p.Until.Truncate(time.Second)
+	from := until.AddDate(0, 0, -p.Days)
+	window := until.Sub(from)
+
+	// the mean of a Pareto distribution is scale * alpha / (alpha - 1).
+	balanceScale := float64(p.Balance) * (p.Alpha - 1) / p.Alpha
+	pareto := func(scale float64) int {
+		return max(1, int(min(scale/math.Pow(1-rng.Float64(), 1/p.Alpha), maxAmount)))
+	}
+
+	accounts := make([]entities.Account, p.Accounts)
+	documents := make(map[vos.Document]bool, p.Accounts)
+	for i := range accounts {
+		document := newDocument(rng)
+		for documents[document] {
+			document = newDocument(rng)
+		}
+		documents[document] = true
+
+		createdAt := from.Add(-time.Duration(rng.Int64N(int64(window)))).Truncate(time.Second)
+		accounts[i] = entities.Account{
+			ID:        must(ids.NewV7AtTime(createdAt)),
+			Name:      firstNames[rng.IntN(len(firstNames))] + " " + lastNames[rng.IntN(len(lastNames))],
+			Document:  document,
+			Secret:    secret,
+			Balance:   pareto(balanceScale),
+			CreatedAt: createdAt,
+		}
+	}
+
+	times := make([]time.Time, p.Transfers)
+	for i := range times {
+		times[i] = from.Add(time.Duration(rng.Int64N(int64(window)))).Truncate(time.Second)
+	}
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+
+	balances := make([]int, len(accounts))
+	for i, acc := range accounts {
+		balances[i] = acc.Balance
+	}
+
+	transfers := make([]entities.Transfer, 0, p.Transfers)
+	for _, createdAt := range times {
+		origin := rng.IntN(len(accounts))
+		for balances[origin] == 0 {
+			origin = rng.IntN(len(accounts))
+		}
+
+		// drawn again when it's the origin, as when the origin is the single hot account.
+		destination := origin
+		for destination == origin {
+			if rng.Float64() < p.HotRatio {
+				destination = rng.IntN(p.HotAccounts)
+			} else {
+				destination = p.HotAccounts + rng.IntN(len(accounts)-p.HotAccounts)
+			}
+		}
+
+		amount := min(pareto(float64(p.MinAmount)), balances[origin])
+		balances[origin] -= amount
+		balances[destination] += amount
+
+		transfers = append(transfers, entities.Transfer{
+			ID:                   must(ids.NewV7AtTime(createdAt)),
+			AccountOriginID:      accounts[origin].ID,
+			AccountDestinationID: accounts[destination].ID,
+			Amount:               amount,
+			CreatedAt:            createdAt,
+		})
+	}
+
+	return dataset{Accounts: accounts, Transfers: transfers}
+}
+
+// newDocument returns a CPF with valid check digits.
+func newDocument(rng *rand.Rand) vos.Document {
+	digits := make([]byte, 11)
+	for i := range 9 {
+		digits[i] = byte(rng.IntN(10))
+	}
+
+	for n := 9; n < 11; n++ {
+		sum := 0
+		for i := range n {
+			sum += int(digits[i]) * (n + 1 - i)
+		}
+		digits[n] = byte(sum * 10 % 11 % 10)
+	}
+
+	for i := range digits {
+		digits[i] += '0'
+	}
+
+	return vos.Document(digits)
+}
+
+func must(id uuid.UUID, err error) uuid.UUID {
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
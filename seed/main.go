@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/domain/entities"
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/cli"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Creates a synthetic dataset of accounts and transfers, for demos and load tests.
+// The same flags generate the same dataset, except for the hashes of the secret:
+//
+//	go run ./seed -seed 42 -accounts 1000 -transfers 100000 -hot-accounts 5 -hot-ratio 0.3 -until 2024-06-01T00:00:00Z
+func main() {
+	var (
+		seed        = flag.Uint64("seed", 1, "seed of the random numbers")
+		accounts    = flag.Int("accounts", 1000, "number of accounts")
+		transfers   = flag.Int("transfers", 10000, "number of transfers")
+		balance     = flag.Int("balance", 100000, "mean initial balance of the accounts, in cents")
+		minAmount   = flag.Int("min-amount", 100, "minimum amount of the transfers, in cents")
+		alpha       = flag.Float64("alpha", 1.16, "shape of the Pareto distributions of the balances and amounts")
+		hotAccounts = flag.Int("hot-accounts", 10, "number of accounts receiving -hot-ratio of the transfers")
+		hotRatio    = flag.Float64("hot-ratio", 0.3, "fraction of the transfers received by the hot accounts")
+		days        = flag.Int("days", 90, "number of days the transfers are spread over")
+		until       = flag.String("until", "", "time of the end of the transfers (RFC 3339), defaults to the start of the day in UTC")
+		secret      = flag.String("secret", "password123", "secret of all the accounts")
+	)
+	flag.Parse()
+
+	p := params{
+		Seed:        *seed,
+		Accounts:    *accounts,
+		Transfers:   *transfers,
+		Balance:     *balance,
+		MinAmount:   *minAmount,
+		Alpha:       *alpha,
+		HotAccounts: *hotAccounts,
+		HotRatio:    *hotRatio,
+		Days:        *days,
+	}
+	err := parseUntil(&p, *until, time.Now())
+	if err == nil {
+		err = p.validate()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	hash, err := vos.NewSecret(*secret)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid -secret:", err)
+		os.Exit(2)
+	}
+
+	var r postgres.Repository
+	cli.Run(fx.Options(Options, fx.Populate(&r)), func(ctx context.Context) error {
+		d := generate(p, hash)
+		if err := write(ctx, r, d); err != nil {
+			return fmt.Errorf("seeding database: %w", err)
+		}
+
+		hot := make([]string, 0, p.HotAccounts)
+		for _, acc := range d.Accounts[:p.HotAccounts] {
+			hot = append(hot, acc.ID.String())
+		}
+		logger.Info(ctx, "database seeded",
+			zap.Int("accounts", len(d.Accounts)),
+			zap.Int("transfers", len(d.Transfers)),
+			zap.Strings("hot_accounts", hot),
+		)
+
+		return nil
+	})
+}
+
+// parseUntil sets the end of the transfers, which defaults to the start of the day of now in UTC,
+// so the runs of the same day generate the same dataset.
+func parseUntil(p *params, until string, now time.Time) error {
+	if until == "" {
+		now = now.UTC()
+		p.Until = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return fmt.Errorf("invalid -until: %w", err)
+	}
+	p.Until = t
+
+	return nil
+}
+
+// Repository writes the dataset. The balances are updated as the transfers do, so the checks of the
+// repository hold the invariants of the accounts.
+type Repository interface {
+	CreateAccounts(ctx context.Context, accs []entities.Account) error
+	CreateTransfer(ctx context.Context, transfer entities.Transfer) error
+	UpdateBalance(ctx context.Context, id uuid.UUID, transactionAmount int) error
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// batchSize is the number of accounts created, or of transfers made, in a transaction.
+const batchSize = 1000
+
+// write creates the accounts with their initial balances and then makes the transfers, in order.
+func write(ctx context.Context, r Repository, d dataset) error {
+	for batch := range slices.Chunk(d.Accounts, batchSize) {
+		if err := r.CreateAccounts(ctx, batch); err != nil {
+			return fmt.Errorf("creating accounts: %w", err)
+		}
+	}
+
+	for batch := range slices.Chunk(d.Transfers, batchSize) {
+		err := r.WithTx(ctx, func(ctx context.Context) error {
+			for _, transfer := range batch {
+				if err := r.CreateTransfer(ctx, transfer); err != nil {
+					return fmt.Errorf("creating transfer: %w", err)
+				}
+
+				if err := r.UpdateBalance(ctx, transfer.AccountOriginID, -transfer.Amount); err != nil {
+					return fmt.Errorf("updating origin account balance: %w", err)
+				}
+
+				if err := r.UpdateBalance(ctx, transfer.AccountDestinationID, transfer.Amount); err != nil {
+					return fmt.Errorf("updating destination account balance: %w", err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		logger.Info(ctx, "transfers made", zap.Time("until", batch[len(batch)-1].CreatedAt))
+	}
+
+	return nil
+}
+
+var Options = fx.Options(
+	logger.Module,
+	apictx.Module,
+	config.Module,
+	dbpool.Module,
+	fx.Provide(postgres.NewRepository),
+)
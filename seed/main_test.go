@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/higordasneves/e-corp/pkg/domain/vos"
+	"github.com/higordasneves/e-corp/pkg/gateway/memory"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+func TestApp(t *testing.T) {
+	t.Parallel()
+
+	err := fx.ValidateApp(Options)
+	assert.NoError(t, err)
+}
+
+func TestParseUntil(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 15, 30, 0, 0, time.FixedZone("BRT", -3*3600))
+
+	tests := []struct {
+		name        string
+		until       string
+		expected    time.Time
+		expectedErr bool
+	}{
+		{name: "start of the day in UTC", expected: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "until", until: "2024-01-01T12:00:00Z", expected: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{name: "invalid until", until: "2024-01-01", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			var p params
+			err := parseUntil(&p, tt.until, now)
+
+			// assert
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(p.Until))
+		})
+	}
+}
+
+func newParams() params {
+	return params{
+		Seed:        42,
+		Accounts:    50,
+		Transfers:   2000,
+		Balance:     100000,
+		MinAmount:   100,
+		Alpha:       1.16,
+		HotAccounts: 2,
+		HotRatio:    0.5,
+		Days:        30,
+		Until:       time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	p := newParams()
+	require.NoError(t, p.validate())
+
+	// execute
+	got := generate(p, "hash")
+
+	// assert: the same params generate the same dataset
+	assert.Equal(t, got, generate(p, "hash"))
+
+	p.Seed++
+	assert.NotEqual(t, got.Accounts[0].Document, generate(p, "hash").Accounts[0].Document)
+
+	require.Len(t, got.Accounts, 50)
+	require.Len(t, got.Transfers, 2000)
+
+	balances := make(map[uuid.UUID]int, len(got.Accounts))
+	total := 0
+	for _, acc := range got.Accounts {
+		_, err := vos.NewDocument(acc.Document.String())
+		assert.NoError(t, err)
+		assert.Positive(t, acc.Balance)
+		assert.True(t, acc.CreatedAt.Before(p.Until.AddDate(0, 0, -p.Days)))
+
+		balances[acc.ID] = acc.Balance
+		total += acc.Balance
+	}
+
+	// assert: the transfers are in order and never overdraw the origin
+	hot := 0
+	for i, transfer := range got.Transfers {
+		assert.NotEqual(t, transfer.AccountOriginID, transfer.AccountDestinationID)
+		assert.Positive(t, transfer.Amount)
+		assert.False(t, transfer.CreatedAt.Before(p.Until.AddDate(0, 0, -p.Days)))
+		assert.True(t, transfer.CreatedAt.Before(p.Until))
+		if i > 0 {
+			assert.False(t, transfer.CreatedAt.Before(got.Transfers[i-1].CreatedAt))
+		}
+
+		balances[transfer.AccountOriginID] -= transfer.Amount
+		balances[transfer.AccountDestinationID] += transfer.Amount
+		require.GreaterOrEqual(t, balances[transfer.AccountOriginID], 0)
+
+		if transfer.AccountDestinationID == got.Accounts[0].ID || transfer.AccountDestinationID == got.Accounts[1].ID {
+			hot++
+		}
+	}
+
+	sum := 0
+	for _, balance := range balances {
+		sum += balance
+	}
+	assert.Equal(t, total, sum)
+	assert.InDelta(t, 0.5, float64(hot)/float64(len(got.Transfers)), 0.05)
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	ctx := logger.AssociateCtx(context.Background(), zaptest.NewLogger(t))
+	r := memory.NewRepository()
+	p := newParams()
+	p.Transfers = 1500
+	d := generate(p, "hash")
+
+	// execute
+	err := write(ctx, r, d)
+
+	// assert: the balances are the ones of the simulation
+	require.NoError(t, err)
+
+	balances := make(map[uuid.UUID]int, len(d.Accounts))
+	for _, acc := range d.Accounts {
+		balances[acc.ID] = acc.Balance
+	}
+	for _, transfer := range d.Transfers {
+		balances[transfer.AccountOriginID] -= transfer.Amount
+		balances[transfer.AccountDestinationID] += transfer.Amount
+	}
+
+	for id, balance := range balances {
+		got, err := r.GetBalance(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, balance, got)
+	}
+
+	transfers, err := r.ListAccountTransfers(ctx, d.Accounts[0].ID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, transfers)
+
+	// execute: the accounts already exist
+	err = write(ctx, r, d)
+
+	// assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gofrs/uuid/v5"
+	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/pkg/gateway/config"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres"
+	"github.com/higordasneves/e-corp/pkg/gateway/postgres/dbpool"
+	"github.com/higordasneves/e-corp/utils/apictx"
+	"github.com/higordasneves/e-corp/utils/cli"
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Splits the balance of a hot account in shards, so its concurrent credits don't wait for each other.
+// Zero shards moves the balance back to the account:
+//
+//	go run ./shard -account 0190a0f6-7b3c-7c55-9d5e-6f1f2f0b8a11 -shards 16
+func main() {
+	var (
+		account = flag.String("account", "", "id of the account")
+		shards  = flag.Int("shards", 16, "number of balance shards, 0 turns the sharding off")
+	)
+	flag.Parse()
+
+	id, err := parseArgs(*account, *shards)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var r postgres.Repository
+	cli.Run(fx.Options(Options, fx.Populate(&r)), func(ctx context.Context) error {
+		if err := r.SetBalanceShards(ctx, id, *shards); err != nil {
+			return fmt.Errorf("setting balance shards: %w", err)
+		}
+
+		logger.Info(ctx, "balance shards set", zap.Stringer("account_id", id), zap.Int("shards", *shards))
+
+		return nil
+	})
+}
+
+func parseArgs(account string, shards int) (uuid.UUID, error) {
+	id, err := uuid.FromString(account)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid -account: %w", err)
+	}
+
+	if shards < 0 {
+		return uuid.Nil, errors.New("-shards must be greater than or equal to zero")
+	}
+
+	return id, nil
+}
+
+var Options = fx.Options(
+	logger.Module,
+	apictx.Module,
+	config.Module,
+	dbpool.Module,
+	fx.Provide(postgres.NewRepository),
+)
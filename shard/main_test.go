@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+
+	"go.uber.org/fx"
+)
+
+func TestApp(t *testing.T) {
+	t.Parallel()
+
+	err := fx.ValidateApp(Options)
+	assert.NoError(t, err)
+}
+
+func TestParseArgs(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV7())
+
+	tests := []struct {
+		name        string
+		account     string
+		shards      int
+		expected    uuid.UUID
+		expectedErr bool
+	}{
+		{name: "shards", account: id.String(), shards: 16, expected: id},
+		{name: "sharding off", account: id.String(), shards: 0, expected: id},
+		{name: "missing account", shards: 16, expectedErr: true},
+		{name: "invalid account", account: "merchant", shards: 16, expectedErr: true},
+		{name: "negative shards", account: id.String(), shards: -1, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// execute
+			got, err := parseArgs(tt.account, tt.shards)
+
+			// assert
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
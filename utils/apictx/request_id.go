@@ -0,0 +1,20 @@
+package apictx
+
+import "context"
+
+// RequestIDHeader is the header carrying the correlation id of a request.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key used to associate the request id.
+type requestIDKey struct{}
+
+// WithRequestID returns a new context associated with the request id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request id associated with the context, or an empty string if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/higordasneves/e-corp/utils/logger"
+)
+
+// Run starts the app of the options and runs fn with its context, which is canceled on interrupt.
+// The dependencies of fn are populated by the options, e.g. with fx.Populate.
+// The app is stopped once fn returns, and the process exits with status 1 if fn failed.
+func Run(opts fx.Option, fn func(ctx context.Context) error) {
+	var ctx context.Context
+	app := fx.New(opts, fx.NopLogger, fx.Populate(&ctx))
+	if err := app.Err(); err != nil {
+		panic(err)
+	}
+
+	if err := app.Start(context.Background()); err != nil {
+		panic(err)
+	}
+
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	err := fn(runCtx)
+	stop()
+
+	if err != nil {
+		logger.Error(ctx, "command failed", zap.Error(err))
+	}
+
+	if err := app.Stop(context.Background()); err != nil {
+		logger.Error(ctx, "stopping app", zap.Error(err))
+	}
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
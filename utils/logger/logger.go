@@ -42,6 +42,11 @@ func AssociateCtx(ctx context.Context, logger *zap.Logger) context.Context {
 	return context.WithValue(ctx, key{}, logger)
 }
 
+// With returns a new context associated with a child of the context logger that has the given fields.
+func With(ctx context.Context, fields ...zapcore.Field) context.Context {
+	return AssociateCtx(ctx, Logger(ctx).With(fields...))
+}
+
 // Logger returns the logger associated with the given context. If there is no logger, it will create a new logger.
 func Logger(ctx context.Context) *zap.Logger {
 	if ctx == nil {